@@ -0,0 +1,64 @@
+// Command gconvgen generates reflection-free converting methods for structs, which are used
+// by gconv.Struct/Scan and gconv.Map instead of the runtime reflection converting.
+//
+// It generates two methods for each struct:
+//
+//	func (s *T) FromMap(m map[string]interface{}) error // Used by gconv.Struct/Scan.
+//	func (s T) MapStrAny() map[string]interface{}       // Used by gconv.Map.
+//
+// The structs are specified by the comment "//gconv:generate" above their declarations,
+// or by the flag -type. The attributes of builtin types like int/string/[]string/time.Time
+// are converted statically, and the others fall back to the runtime converting of gconv.Scan.
+// Note that the generated FromMap is used only if no custom mapping rules are given.
+//
+// Usage:
+//
+//	gconvgen [-dir .] [-type User,Order] [-output gconv_gen.go]
+//
+// Eg:
+//
+//	//go:generate gconvgen
+//
+//	//gconv:generate
+//	type User struct {
+//		Id   int    `json:"id"`
+//		Name string `json:"name"`
+//	}
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// ANNOTATION is the comment marking a struct for generating.
+	ANNOTATION = "//gconv:generate"
+	// DEFAULT_OUTPUT is the default output file name.
+	DEFAULT_OUTPUT = "gconv_gen.go"
+)
+
+func main() {
+	var (
+		dir    = flag.String("dir", ".", "directory of the package to generate for")
+		types  = flag.String("type", "", "comma-separated struct names to generate for, besides the annotated ones")
+		output = flag.String("output", DEFAULT_OUTPUT, "output file name in the package directory")
+	)
+	flag.Parse()
+	var typeNames []string
+	if *types != "" {
+		for _, name := range strings.Split(*types, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				typeNames = append(typeNames, name)
+			}
+		}
+	}
+	outputPath := filepath.Join(*dir, *output)
+	if err := Generate(*dir, outputPath, typeNames); err != nil {
+		fmt.Fprintln(os.Stderr, "gconvgen:", err)
+		os.Exit(1)
+	}
+}
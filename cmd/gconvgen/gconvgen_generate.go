@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ilylx/gconv"
+)
+
+const (
+	// GENERATED_HEADER is the header comment of the generated file.
+	GENERATED_HEADER = "// Code generated by gconvgen. DO NOT EDIT."
+	// IMPORT_GCONV is the import path of package gconv.
+	IMPORT_GCONV = "github.com/ilylx/gconv"
+	// IMPORT_EMPTY is the import path of package empty.
+	IMPORT_EMPTY = "github.com/ilylx/gconv/empty"
+)
+
+var (
+	// staticConverters maps the builtin attribute types to their gconv converting functions.
+	staticConverters = map[string]string{
+		"string":                 "String",
+		"int":                    "Int",
+		"int8":                   "Int8",
+		"int16":                  "Int16",
+		"int32":                  "Int32",
+		"int64":                  "Int64",
+		"uint":                   "Uint",
+		"uint8":                  "Uint8",
+		"uint16":                 "Uint16",
+		"uint32":                 "Uint32",
+		"uint64":                 "Uint64",
+		"float32":                "Float32",
+		"float64":                "Float64",
+		"bool":                   "Bool",
+		"byte":                   "Byte",
+		"rune":                   "Rune",
+		"[]byte":                 "Bytes",
+		"[]rune":                 "Runes",
+		"[]string":               "Strings",
+		"[]int":                  "Ints",
+		"[]int32":                "Int32s",
+		"[]int64":                "Int64s",
+		"[]uint":                 "Uints",
+		"[]uint32":               "Uint32s",
+		"[]uint64":               "Uint64s",
+		"[]float32":              "Float32s",
+		"[]float64":              "Float64s",
+		"[]interface{}":          "Interfaces",
+		"[]any":                  "Interfaces",
+		"map[string]interface{}": "Map",
+		"map[string]any":         "Map",
+		"map[string]string":      "MapStrStr",
+		"time.Time":              "Time",
+		"time.Duration":          "Duration",
+	}
+)
+
+// Generate parses the package in <dir>, and generates the converting methods of the structs
+// annotated with ANNOTATION or named in <typeNames> to file <outputPath>.
+func Generate(dir string, outputPath string, typeNames []string) error {
+	pkgName, specs, err := parsePackage(dir, outputPath, typeNames)
+	if err != nil {
+		return err
+	}
+	src, err := generateSource(pkgName, specs)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outputPath, src, 0644)
+}
+
+// generateSource generates and returns the formatted source of package <pkgName> for <specs>.
+func generateSource(pkgName string, specs []*structSpec) ([]byte, error) {
+	var (
+		body    = bytes.NewBuffer(nil)
+		imports = map[string]string{IMPORT_GCONV: ""}
+	)
+	for _, spec := range specs {
+		generateFromMap(body, spec, imports)
+		generateMapStrAny(body, spec, imports)
+	}
+	paths := make([]string, 0, len(imports))
+	for importPath := range imports {
+		paths = append(paths, importPath)
+	}
+	sort.Strings(paths)
+	buffer := bytes.NewBuffer(nil)
+	fmt.Fprintf(buffer, "%s\n\npackage %s\n\nimport (\n", GENERATED_HEADER, pkgName)
+	for _, importPath := range paths {
+		if name := imports[importPath]; name != "" {
+			fmt.Fprintf(buffer, "\t%s %s\n", name, strconv.Quote(importPath))
+		} else {
+			fmt.Fprintf(buffer, "\t%s\n", strconv.Quote(importPath))
+		}
+	}
+	buffer.WriteString(")\n")
+	buffer.Write(body.Bytes())
+	src, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated source failed: %v", err)
+	}
+	return src, nil
+}
+
+// generateFromMap generates the FromMap method of <spec>, which has the same attribute
+// matching rules as gconv.Struct: tag names first and then attribute names, compared
+// case-insensitively and ignoring symbols.
+func generateFromMap(buffer *bytes.Buffer, spec *structSpec, imports map[string]string) {
+	var (
+		fields   = make([]*fieldSpec, 0, len(spec.fields))
+		fieldMap = make(map[string]int) // Normalized key => index of fields.
+		keys     = make([][]string, 0, len(spec.fields))
+	)
+	fmt.Fprintf(buffer, "\n// FromMap implements the interface for gconv.Struct/Scan converting map to %s.\n", spec.name)
+	fmt.Fprintf(buffer, "func (s *%s) FromMap(m map[string]interface{}) error {\n", spec.name)
+	for _, field := range spec.fields {
+		if field.embedded {
+			fmt.Fprintf(buffer, "if err := gconv.Struct(m, &s.%s); err != nil {\nreturn err\n}\n", field.name)
+			continue
+		}
+		fields = append(fields, field)
+		keys = append(keys, nil)
+	}
+	if len(fields) == 0 {
+		buffer.WriteString("return nil\n}\n")
+		return
+	}
+	// The tag names have priority over the attribute names.
+	for i, field := range fields {
+		if name := getFieldTag(field); name != "" && name != "-" {
+			addFieldKey(fieldMap, keys, gconv.NormalizeKey(name), i)
+		}
+	}
+	for i, field := range fields {
+		addFieldKey(fieldMap, keys, gconv.NormalizeKey(field.name), i)
+	}
+	fmt.Fprintf(buffer, "var done [%d]bool\n", len(fields))
+	buffer.WriteString("for k, v := range m {\nswitch gconv.NormalizeKey(k) {\n")
+	for i, field := range fields {
+		if len(keys[i]) == 0 {
+			continue
+		}
+		quoted := make([]string, len(keys[i]))
+		for j, key := range keys[i] {
+			quoted[j] = strconv.Quote(key)
+		}
+		fmt.Fprintf(buffer, "case %s:\nif !done[%d] {\ndone[%d] = true\n", strings.Join(quoted, ", "), i, i)
+		switch {
+		case field.typeExpr == "interface{}" || field.typeExpr == "any":
+			fmt.Fprintf(buffer, "s.%s = v\n", field.name)
+		case staticConverters[field.typeExpr] != "":
+			fmt.Fprintf(buffer, "s.%s = gconv.%s(v)\n", field.name, staticConverters[field.typeExpr])
+		default:
+			imports["fmt"] = ""
+			addImports(imports, field)
+			fmt.Fprintf(buffer, "if v == nil {\nvar zero %s\ns.%s = zero\n", field.typeExpr, field.name)
+			fmt.Fprintf(buffer, "} else if err := gconv.Scan(v, &s.%s); err != nil {\n", field.name)
+			fmt.Fprintf(buffer, "return fmt.Errorf(`error binding value to attribute \"%s\": %%w`, err)\n}\n", field.name)
+		}
+		buffer.WriteString("}\n")
+	}
+	buffer.WriteString("}\n}\nreturn nil\n}\n")
+}
+
+// generateMapStrAny generates the MapStrAny method of <spec>, which has the same naming
+// rules as gconv.Map, supporting the json tag features "-" and "omitempty".
+func generateMapStrAny(buffer *bytes.Buffer, spec *structSpec, imports map[string]string) {
+	fmt.Fprintf(buffer, "\n// MapStrAny implements the interface for gconv.Map converting %s to map.\n", spec.name)
+	fmt.Fprintf(buffer, "func (s %s) MapStrAny() map[string]interface{} {\n", spec.name)
+	fmt.Fprintf(buffer, "m := make(map[string]interface{}, %d)\n", len(spec.fields))
+	for _, field := range spec.fields {
+		var (
+			name      = getFieldTag(field)
+			omitempty = false
+		)
+		if name == "" {
+			name = field.name
+		} else {
+			name = strings.TrimSpace(name)
+			if name == "-" {
+				continue
+			}
+			if array := strings.Split(name, ","); len(array) > 1 {
+				name = strings.TrimSpace(array[0])
+				omitempty = strings.TrimSpace(array[1]) == "omitempty"
+			}
+		}
+		if omitempty {
+			fmt.Fprintf(buffer, "if %s {\n", getNotEmptyExpr(field, imports))
+		}
+		switch {
+		case field.embedded && name == field.name:
+			fmt.Fprintf(buffer, "for k, v := range gconv.MapDeep(s.%s) {\nm[k] = v\n}\n", field.name)
+		case field.embedded:
+			fmt.Fprintf(buffer, "m[%s] = gconv.MapDeep(s.%s)\n", strconv.Quote(name), field.name)
+		default:
+			fmt.Fprintf(buffer, "m[%s] = s.%s\n", strconv.Quote(name), field.name)
+		}
+		if omitempty {
+			buffer.WriteString("}\n")
+		}
+	}
+	buffer.WriteString("return m\n}\n")
+}
+
+// getFieldTag returns the first non-empty tag value of <field> in gconv.StructTagPriority.
+func getFieldTag(field *fieldSpec) string {
+	for _, tag := range gconv.StructTagPriority {
+		if value := field.tag.Get(tag); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// getNotEmptyExpr returns the expression checking whether the attribute <field> is not empty.
+func getNotEmptyExpr(field *fieldSpec, imports map[string]string) string {
+	switch {
+	case field.typeExpr == "string":
+		return fmt.Sprintf(`s.%s != ""`, field.name)
+	case field.typeExpr == "bool":
+		return "s." + field.name
+	case strings.HasPrefix(field.typeExpr, "[]") || strings.HasPrefix(field.typeExpr, "map["):
+		return fmt.Sprintf(`len(s.%s) > 0`, field.name)
+	case staticConverters[field.typeExpr] != "" && field.typeExpr != "time.Time":
+		return fmt.Sprintf(`s.%s != 0`, field.name)
+	}
+	imports[IMPORT_EMPTY] = ""
+	return fmt.Sprintf(`!empty.IsEmpty(s.%s)`, field.name)
+}
+
+// addFieldKey adds normalized key <key> for the field at <index> if the key is not used.
+func addFieldKey(fieldMap map[string]int, keys [][]string, key string, index int) {
+	if key == "" {
+		return
+	}
+	if _, ok := fieldMap[key]; ok {
+		return
+	}
+	fieldMap[key] = index
+	keys[index] = append(keys[index], key)
+}
+
+// addImports adds the imports used by the type of <field> to <imports>.
+func addImports(imports map[string]string, field *fieldSpec) {
+	for _, spec := range field.imports {
+		if _, ok := imports[spec.path]; ok {
+			continue
+		}
+		name := ""
+		if spec.name != parseImportName(spec.path) {
+			name = spec.name
+		}
+		imports[spec.path] = name
+	}
+}
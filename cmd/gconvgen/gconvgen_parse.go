@@ -0,0 +1,215 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// structSpec is the parsed struct for generating.
+type structSpec struct {
+	name   string
+	fields []*fieldSpec
+}
+
+// fieldSpec is the parsed struct attribute for generating.
+type fieldSpec struct {
+	name     string            // Attribute name, which is the type name for embedded attribute.
+	typeExpr string            // Type expression of the attribute.
+	tag      reflect.StructTag // Tag of the attribute.
+	embedded bool              // Whether it's an embedded attribute.
+	imports  []importSpec      // Imports used by the type expression.
+}
+
+// importSpec is the import used by the attribute type.
+type importSpec struct {
+	name string
+	path string
+}
+
+var (
+	// versionSuffixRegex matches the major version suffix of import path, like "v2".
+	versionSuffixRegex = regexp.MustCompile(`^v[0-9]+$`)
+)
+
+// parsePackage parses the go files in <dir>, and returns the package name and the structs
+// to generate, which are annotated with ANNOTATION or named in <typeNames>.
+// The file <outputPath> is ignored, as it's the previously generated file.
+func parsePackage(dir string, outputPath string, typeNames []string) (pkgName string, specs []*structSpec, err error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", nil, err
+	}
+	sort.Strings(files)
+	outputAbsPath, _ := filepath.Abs(outputPath)
+	var (
+		fset       = token.NewFileSet()
+		wantedMap  = make(map[string]bool, len(typeNames))
+		foundNames = make(map[string]bool)
+	)
+	for _, name := range typeNames {
+		wantedMap[name] = true
+	}
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		if absPath, _ := filepath.Abs(file); absPath == outputAbsPath {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
+		if err != nil {
+			return "", nil, err
+		}
+		if pkgName == "" {
+			pkgName = f.Name.Name
+		} else if pkgName != f.Name.Name {
+			return "", nil, fmt.Errorf(`found packages "%s" and "%s" in "%s"`, pkgName, f.Name.Name, dir)
+		}
+		imports := parseFileImports(f)
+		for _, decl := range f.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				name := typeSpec.Name.Name
+				if !wantedMap[name] && !hasAnnotation(genDecl.Doc) && !hasAnnotation(typeSpec.Doc) {
+					continue
+				}
+				if typeSpec.TypeParams != nil && len(typeSpec.TypeParams.List) > 0 {
+					return "", nil, fmt.Errorf(`generic struct "%s" is not supported`, name)
+				}
+				foundNames[name] = true
+				specs = append(specs, parseStruct(name, structType, imports))
+			}
+		}
+	}
+	for _, name := range typeNames {
+		if !foundNames[name] {
+			return "", nil, fmt.Errorf(`struct "%s" not found in "%s"`, name, dir)
+		}
+	}
+	if len(specs) == 0 {
+		return "", nil, errors.New(`no struct found, mark the structs with "` + ANNOTATION + `" or use flag -type`)
+	}
+	return pkgName, specs, nil
+}
+
+// parseStruct parses the exported attributes of struct type <structType> named <name>.
+func parseStruct(name string, structType *ast.StructType, imports map[string]string) *structSpec {
+	spec := &structSpec{name: name}
+	for _, field := range structType.Fields.List {
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			if s, err := strconv.Unquote(field.Tag.Value); err == nil {
+				tag = reflect.StructTag(s)
+			}
+		}
+		var (
+			typeExpr    = types.ExprString(field.Type)
+			typeImports = parseTypeImports(field.Type, imports)
+		)
+		if len(field.Names) == 0 {
+			// Embedded attribute, its name is the type name.
+			fieldName := typeExpr[strings.LastIndexAny(typeExpr, "*.")+1:]
+			if ast.IsExported(fieldName) {
+				spec.fields = append(spec.fields, &fieldSpec{
+					name:     fieldName,
+					typeExpr: typeExpr,
+					tag:      tag,
+					embedded: true,
+					imports:  typeImports,
+				})
+			}
+			continue
+		}
+		for _, ident := range field.Names {
+			if !ast.IsExported(ident.Name) {
+				continue
+			}
+			spec.fields = append(spec.fields, &fieldSpec{
+				name:     ident.Name,
+				typeExpr: typeExpr,
+				tag:      tag,
+				imports:  typeImports,
+			})
+		}
+	}
+	return spec
+}
+
+// parseFileImports returns the imports of file <f>, which maps import name to import path.
+func parseFileImports(f *ast.File) map[string]string {
+	imports := make(map[string]string)
+	for _, spec := range f.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		if spec.Name != nil {
+			imports[spec.Name.Name] = importPath
+			continue
+		}
+		imports[parseImportName(importPath)] = importPath
+	}
+	return imports
+}
+
+// parseImportName returns the default package name of <importPath>, which is guessed
+// from the path as the package name is not available without loading the package.
+func parseImportName(importPath string) string {
+	name := path.Base(importPath)
+	if versionSuffixRegex.MatchString(name) {
+		name = path.Base(path.Dir(importPath))
+	}
+	if index := strings.IndexByte(name, '.'); index > 0 {
+		name = name[:index]
+	}
+	return strings.TrimPrefix(name, "go-")
+}
+
+// parseTypeImports returns the imports used by type expression <expr>.
+func parseTypeImports(expr ast.Expr, imports map[string]string) []importSpec {
+	var specs []importSpec
+	ast.Inspect(expr, func(node ast.Node) bool {
+		if selector, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok {
+				if importPath, ok := imports[ident.Name]; ok {
+					specs = append(specs, importSpec{name: ident.Name, path: importPath})
+				}
+			}
+			return false
+		}
+		return true
+	})
+	return specs
+}
+
+// hasAnnotation checks whether comment group <doc> contains ANNOTATION.
+func hasAnnotation(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+	for _, comment := range doc.List {
+		if strings.TrimSpace(comment.Text) == ANNOTATION {
+			return true
+		}
+	}
+	return false
+}
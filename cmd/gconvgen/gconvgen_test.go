@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// update rewrites the golden files with the generated source, eg: go test -update.
+var update = flag.Bool("update", false, "update the golden files")
+
+// Test_Generate_Golden checks the generated source for testdata/user, which covers the
+// static and fallback converting, the json tag features "-" and "omitempty", and the embedded
+// attributes. Note that the FromMap keys keep the tag options like "omitempty" as gconv.Struct does.
+func Test_Generate_Golden(t *testing.T) {
+	var (
+		dir        = filepath.Join("testdata", "user")
+		goldenPath = filepath.Join(dir, DEFAULT_OUTPUT+".golden")
+		outputPath = filepath.Join(t.TempDir(), DEFAULT_OUTPUT)
+	)
+	if err := Generate(dir, outputPath, nil); err != nil {
+		t.Fatal(err)
+	}
+	src, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *update {
+		if err = os.WriteFile(goldenPath, src, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	golden, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(src, golden) {
+		t.Errorf("generated source differs from %s:\n%s", goldenPath, src)
+	}
+}
+
+func Test_Generate_Errors(t *testing.T) {
+	dir := filepath.Join("testdata", "user")
+	if err := Generate(dir, filepath.Join(t.TempDir(), DEFAULT_OUTPUT), []string{"Missing"}); err == nil {
+		t.Error("expected error for missing struct")
+	}
+	if err := Generate(t.TempDir(), filepath.Join(t.TempDir(), DEFAULT_OUTPUT), nil); err == nil {
+		t.Error("expected error for no struct")
+	}
+}
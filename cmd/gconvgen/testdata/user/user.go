@@ -0,0 +1,34 @@
+package user
+
+import (
+	"time"
+
+	gtime "github.com/ilylx/gconv/internal/os/gtime"
+)
+
+// Base is embedded by User.
+type Base struct {
+	Id      int       `json:"id"`
+	Created time.Time `json:"created"`
+}
+
+// Extra is embedded with tag by User.
+type Extra struct {
+	Note string
+}
+
+//gconv:generate
+type User struct {
+	Base
+	Extra   `json:"extra"`
+	Name    string            `json:"name"`
+	Nick    string            `json:"nick,omitempty"`
+	Age     int               `json:"age,omitempty"`
+	Active  bool              `json:"active,omitempty"`
+	Tags    []string          `json:"tags,omitempty"`
+	Attrs   map[string]string `json:"attrs,omitempty"`
+	Login   *gtime.Time       `json:"login,omitempty"`
+	Data    interface{}       `json:"data"`
+	Secret  string            `json:"-"`
+	private string
+}
@@ -1,6 +1,9 @@
 package garray
 
-import "strings"
+import (
+	"github.com/ilylx/gconv/internal/grand"
+	"strings"
+)
 
 // apiInterfaces is used for type assert api for Interfaces.
 type apiInterfaces interface {
@@ -66,3 +69,21 @@ func quickSortStr(values []string, comparator func(a, b string) int) {
 	quickSortStr(values[:head], comparator)
 	quickSortStr(values[head+1:], comparator)
 }
+
+// randIntn returns a random int number in [0, max) using the first item of <source>
+// if given, or else the default random generator.
+func randIntn(source []*grand.Source, max int) int {
+	if len(source) > 0 && source[0] != nil {
+		return source[0].Intn(max)
+	}
+	return grand.Intn(max)
+}
+
+// randPerm returns a random permutation of the integers [0,n) using the first item of
+// <source> if given, or else the default random generator.
+func randPerm(source []*grand.Source, n int) []int {
+	if len(source) > 0 && source[0] != nil {
+		return source[0].Perm(n)
+	}
+	return grand.Perm(n)
+}
@@ -242,14 +242,16 @@ func (a *Array) PushRight(value ...interface{}) *Array {
 
 // PopRand randomly pops and return an item out of array.
 // Note that if the array is empty, the <found> is false.
-func (a *Array) PopRand() (value interface{}, found bool) {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *Array) PopRand(source ...*grand.Source) (value interface{}, found bool) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
-	return a.doRemoveWithoutLock(grand.Intn(len(a.array)))
+	return a.doRemoveWithoutLock(randIntn(source, len(a.array)))
 }
 
 // PopRands randomly pops and returns <size> items out of array.
-func (a *Array) PopRands(size int) []interface{} {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *Array) PopRands(size int, source ...*grand.Source) []interface{} {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 	if size <= 0 || len(a.array) == 0 {
@@ -260,7 +262,7 @@ func (a *Array) PopRands(size int) []interface{} {
 	}
 	array := make([]interface{}, size)
 	for i := 0; i < size; i++ {
-		array[i], _ = a.doRemoveWithoutLock(grand.Intn(len(a.array)))
+		array[i], _ = a.doRemoveWithoutLock(randIntn(source, len(a.array)))
 	}
 	return array
 }
@@ -593,17 +595,19 @@ func (a *Array) Pad(size int, val interface{}) *Array {
 }
 
 // Rand randomly returns one item from array(no deleting).
-func (a *Array) Rand() (value interface{}, found bool) {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *Array) Rand(source ...*grand.Source) (value interface{}, found bool) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 	if len(a.array) == 0 {
 		return nil, false
 	}
-	return a.array[grand.Intn(len(a.array))], true
+	return a.array[randIntn(source, len(a.array))], true
 }
 
 // Rands randomly returns <size> items from array(no deleting).
-func (a *Array) Rands(size int) []interface{} {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *Array) Rands(size int, source ...*grand.Source) []interface{} {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 	if size <= 0 || len(a.array) == 0 {
@@ -611,16 +615,17 @@ func (a *Array) Rands(size int) []interface{} {
 	}
 	array := make([]interface{}, size)
 	for i := 0; i < size; i++ {
-		array[i] = a.array[grand.Intn(len(a.array))]
+		array[i] = a.array[randIntn(source, len(a.array))]
 	}
 	return array
 }
 
 // Shuffle randomly shuffles the array.
-func (a *Array) Shuffle() *Array {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *Array) Shuffle(source ...*grand.Source) *Array {
 	a.mu.Lock()
 	defer a.mu.Unlock()
-	for i, v := range grand.Perm(len(a.array)) {
+	for i, v := range randPerm(source, len(a.array)) {
 		a.array[i], a.array[v] = a.array[v], a.array[i]
 	}
 	return a
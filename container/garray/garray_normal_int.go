@@ -270,16 +270,18 @@ func (a *IntArray) PopRight() (value int, found bool) {
 
 // PopRand randomly pops and return an item out of array.
 // Note that if the array is empty, the <found> is false.
-func (a *IntArray) PopRand() (value int, found bool) {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *IntArray) PopRand(source ...*grand.Source) (value int, found bool) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
-	return a.doRemoveWithoutLock(grand.Intn(len(a.array)))
+	return a.doRemoveWithoutLock(randIntn(source, len(a.array)))
 }
 
 // PopRands randomly pops and returns <size> items out of array.
 // If the given <size> is greater than size of the array, it returns all elements of the array.
 // Note that if given <size> <= 0 or the array is empty, it returns nil.
-func (a *IntArray) PopRands(size int) []int {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *IntArray) PopRands(size int, source ...*grand.Source) []int {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 	if size <= 0 || len(a.array) == 0 {
@@ -290,7 +292,7 @@ func (a *IntArray) PopRands(size int) []int {
 	}
 	array := make([]int, size)
 	for i := 0; i < size; i++ {
-		array[i], _ = a.doRemoveWithoutLock(grand.Intn(len(a.array)))
+		array[i], _ = a.doRemoveWithoutLock(randIntn(source, len(a.array)))
 	}
 	return array
 }
@@ -609,17 +611,19 @@ func (a *IntArray) Pad(size int, value int) *IntArray {
 }
 
 // Rand randomly returns one item from array(no deleting).
-func (a *IntArray) Rand() (value int, found bool) {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *IntArray) Rand(source ...*grand.Source) (value int, found bool) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 	if len(a.array) == 0 {
 		return 0, false
 	}
-	return a.array[grand.Intn(len(a.array))], true
+	return a.array[randIntn(source, len(a.array))], true
 }
 
 // Rands randomly returns <size> items from array(no deleting).
-func (a *IntArray) Rands(size int) []int {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *IntArray) Rands(size int, source ...*grand.Source) []int {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 	if size <= 0 || len(a.array) == 0 {
@@ -627,16 +631,17 @@ func (a *IntArray) Rands(size int) []int {
 	}
 	array := make([]int, size)
 	for i := 0; i < size; i++ {
-		array[i] = a.array[grand.Intn(len(a.array))]
+		array[i] = a.array[randIntn(source, len(a.array))]
 	}
 	return array
 }
 
 // Shuffle randomly shuffles the array.
-func (a *IntArray) Shuffle() *IntArray {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *IntArray) Shuffle(source ...*grand.Source) *IntArray {
 	a.mu.Lock()
 	defer a.mu.Unlock()
-	for i, v := range grand.Perm(len(a.array)) {
+	for i, v := range randPerm(source, len(a.array)) {
 		a.array[i], a.array[v] = a.array[v], a.array[i]
 	}
 	return a
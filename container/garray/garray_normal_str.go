@@ -258,16 +258,18 @@ func (a *StrArray) PopRight() (value string, found bool) {
 
 // PopRand randomly pops and return an item out of array.
 // Note that if the array is empty, the <found> is false.
-func (a *StrArray) PopRand() (value string, found bool) {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *StrArray) PopRand(source ...*grand.Source) (value string, found bool) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
-	return a.doRemoveWithoutLock(grand.Intn(len(a.array)))
+	return a.doRemoveWithoutLock(randIntn(source, len(a.array)))
 }
 
 // PopRands randomly pops and returns <size> items out of array.
 // If the given <size> is greater than size of the array, it returns all elements of the array.
 // Note that if given <size> <= 0 or the array is empty, it returns nil.
-func (a *StrArray) PopRands(size int) []string {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *StrArray) PopRands(size int, source ...*grand.Source) []string {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 	if size <= 0 || len(a.array) == 0 {
@@ -278,7 +280,7 @@ func (a *StrArray) PopRands(size int) []string {
 	}
 	array := make([]string, size)
 	for i := 0; i < size; i++ {
-		array[i], _ = a.doRemoveWithoutLock(grand.Intn(len(a.array)))
+		array[i], _ = a.doRemoveWithoutLock(randIntn(source, len(a.array)))
 	}
 	return array
 }
@@ -613,17 +615,19 @@ func (a *StrArray) Pad(size int, value string) *StrArray {
 }
 
 // Rand randomly returns one item from array(no deleting).
-func (a *StrArray) Rand() (value string, found bool) {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *StrArray) Rand(source ...*grand.Source) (value string, found bool) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 	if len(a.array) == 0 {
 		return "", false
 	}
-	return a.array[grand.Intn(len(a.array))], true
+	return a.array[randIntn(source, len(a.array))], true
 }
 
 // Rands randomly returns <size> items from array(no deleting).
-func (a *StrArray) Rands(size int) []string {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *StrArray) Rands(size int, source ...*grand.Source) []string {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 	if size <= 0 || len(a.array) == 0 {
@@ -631,16 +635,17 @@ func (a *StrArray) Rands(size int) []string {
 	}
 	array := make([]string, size)
 	for i := 0; i < size; i++ {
-		array[i] = a.array[grand.Intn(len(a.array))]
+		array[i] = a.array[randIntn(source, len(a.array))]
 	}
 	return array
 }
 
 // Shuffle randomly shuffles the array.
-func (a *StrArray) Shuffle() *StrArray {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *StrArray) Shuffle(source ...*grand.Source) *StrArray {
 	a.mu.Lock()
 	defer a.mu.Unlock()
-	for i, v := range grand.Perm(len(a.array)) {
+	for i, v := range randPerm(source, len(a.array)) {
 		a.array[i], a.array[v] = a.array[v], a.array[i]
 	}
 	return a
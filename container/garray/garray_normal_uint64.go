@@ -275,16 +275,18 @@ func (a *Uint64) PopRight() (value uint64, found bool) {
 
 // PopRand randomly pops and return an item out of array.
 // Note that if the array is empty, the <found> is false.
-func (a *Uint64) PopRand() (value uint64, found bool) {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *Uint64) PopRand(source ...*grand.Source) (value uint64, found bool) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
-	return a.doRemoveWithoutLock(grand.Intn(len(a.array)))
+	return a.doRemoveWithoutLock(randIntn(source, len(a.array)))
 }
 
 // PopRands randomly pops and returns <size> items out of array.
 // If the given <size> is greater than size of the array, it returns all elements of the array.
 // Note that if given <size> <= 0 or the array is empty, it returns nil.
-func (a *Uint64) PopRands(size int) []uint64 {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *Uint64) PopRands(size int, source ...*grand.Source) []uint64 {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 	if size <= 0 || len(a.array) == 0 {
@@ -295,7 +297,7 @@ func (a *Uint64) PopRands(size int) []uint64 {
 	}
 	array := make([]uint64, size)
 	for i := 0; i < size; i++ {
-		array[i], _ = a.doRemoveWithoutLock(grand.Intn(len(a.array)))
+		array[i], _ = a.doRemoveWithoutLock(randIntn(source, len(a.array)))
 	}
 	return array
 }
@@ -614,17 +616,19 @@ func (a *Uint64) Pad(size int, value uint64) *Uint64 {
 }
 
 // Rand randomly returns one item from array(no deleting).
-func (a *Uint64) Rand() (value uint64, found bool) {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *Uint64) Rand(source ...*grand.Source) (value uint64, found bool) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 	if len(a.array) == 0 {
 		return 0, false
 	}
-	return a.array[grand.Intn(len(a.array))], true
+	return a.array[randIntn(source, len(a.array))], true
 }
 
 // Rands randomly returns <size> items from array(no deleting).
-func (a *Uint64) Rands(size int) []uint64 {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *Uint64) Rands(size int, source ...*grand.Source) []uint64 {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 	if size <= 0 || len(a.array) == 0 {
@@ -632,16 +636,17 @@ func (a *Uint64) Rands(size int) []uint64 {
 	}
 	array := make([]uint64, size)
 	for i := 0; i < size; i++ {
-		array[i] = a.array[grand.Intn(len(a.array))]
+		array[i] = a.array[randIntn(source, len(a.array))]
 	}
 	return array
 }
 
 // Shuffle randomly shuffles the array.
-func (a *Uint64) Shuffle() *Uint64 {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *Uint64) Shuffle(source ...*grand.Source) *Uint64 {
 	a.mu.Lock()
 	defer a.mu.Unlock()
-	for i, v := range grand.Perm(len(a.array)) {
+	for i, v := range randPerm(source, len(a.array)) {
 		a.array[i], a.array[v] = a.array[v], a.array[i]
 	}
 	return a
@@ -231,14 +231,16 @@ func (a *SortedArray) PopRight() (value interface{}, found bool) {
 
 // PopRand randomly pops and return an item out of array.
 // Note that if the array is empty, the <found> is false.
-func (a *SortedArray) PopRand() (value interface{}, found bool) {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *SortedArray) PopRand(source ...*grand.Source) (value interface{}, found bool) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
-	return a.doRemoveWithoutLock(grand.Intn(len(a.array)))
+	return a.doRemoveWithoutLock(randIntn(source, len(a.array)))
 }
 
 // PopRands randomly pops and returns <size> items out of array.
-func (a *SortedArray) PopRands(size int) []interface{} {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *SortedArray) PopRands(size int, source ...*grand.Source) []interface{} {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 	if size <= 0 || len(a.array) == 0 {
@@ -249,7 +251,7 @@ func (a *SortedArray) PopRands(size int) []interface{} {
 	}
 	array := make([]interface{}, size)
 	for i := 0; i < size; i++ {
-		array[i], _ = a.doRemoveWithoutLock(grand.Intn(len(a.array)))
+		array[i], _ = a.doRemoveWithoutLock(randIntn(source, len(a.array)))
 	}
 	return array
 }
@@ -560,17 +562,19 @@ func (a *SortedArray) Chunk(size int) [][]interface{} {
 }
 
 // Rand randomly returns one item from array(no deleting).
-func (a *SortedArray) Rand() (value interface{}, found bool) {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *SortedArray) Rand(source ...*grand.Source) (value interface{}, found bool) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 	if len(a.array) == 0 {
 		return nil, false
 	}
-	return a.array[grand.Intn(len(a.array))], true
+	return a.array[randIntn(source, len(a.array))], true
 }
 
 // Rands randomly returns <size> items from array(no deleting).
-func (a *SortedArray) Rands(size int) []interface{} {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *SortedArray) Rands(size int, source ...*grand.Source) []interface{} {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 	if size <= 0 || len(a.array) == 0 {
@@ -578,7 +582,7 @@ func (a *SortedArray) Rands(size int) []interface{} {
 	}
 	array := make([]interface{}, size)
 	for i := 0; i < size; i++ {
-		array[i] = a.array[grand.Intn(len(a.array))]
+		array[i] = a.array[randIntn(source, len(a.array))]
 	}
 	return array
 }
@@ -216,16 +216,18 @@ func (a *SortedIntArray) PopRight() (value int, found bool) {
 
 // PopRand randomly pops and return an item out of array.
 // Note that if the array is empty, the <found> is false.
-func (a *SortedIntArray) PopRand() (value int, found bool) {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *SortedIntArray) PopRand(source ...*grand.Source) (value int, found bool) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
-	return a.doRemoveWithoutLock(grand.Intn(len(a.array)))
+	return a.doRemoveWithoutLock(randIntn(source, len(a.array)))
 }
 
 // PopRands randomly pops and returns <size> items out of array.
 // If the given <size> is greater than size of the array, it returns all elements of the array.
 // Note that if given <size> <= 0 or the array is empty, it returns nil.
-func (a *SortedIntArray) PopRands(size int) []int {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *SortedIntArray) PopRands(size int, source ...*grand.Source) []int {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 	if size <= 0 || len(a.array) == 0 {
@@ -236,7 +238,7 @@ func (a *SortedIntArray) PopRands(size int) []int {
 	}
 	array := make([]int, size)
 	for i := 0; i < size; i++ {
-		array[i], _ = a.doRemoveWithoutLock(grand.Intn(len(a.array)))
+		array[i], _ = a.doRemoveWithoutLock(randIntn(source, len(a.array)))
 	}
 	return array
 }
@@ -551,17 +553,19 @@ func (a *SortedIntArray) Chunk(size int) [][]int {
 }
 
 // Rand randomly returns one item from array(no deleting).
-func (a *SortedIntArray) Rand() (value int, found bool) {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *SortedIntArray) Rand(source ...*grand.Source) (value int, found bool) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 	if len(a.array) == 0 {
 		return 0, false
 	}
-	return a.array[grand.Intn(len(a.array))], true
+	return a.array[randIntn(source, len(a.array))], true
 }
 
 // Rands randomly returns <size> items from array(no deleting).
-func (a *SortedIntArray) Rands(size int) []int {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *SortedIntArray) Rands(size int, source ...*grand.Source) []int {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 	if size <= 0 || len(a.array) == 0 {
@@ -569,7 +573,7 @@ func (a *SortedIntArray) Rands(size int) []int {
 	}
 	array := make([]int, size)
 	for i := 0; i < size; i++ {
-		array[i] = a.array[grand.Intn(len(a.array))]
+		array[i] = a.array[randIntn(source, len(a.array))]
 	}
 	return array
 }
@@ -201,16 +201,18 @@ func (a *SortedStrArray) PopRight() (value string, found bool) {
 
 // PopRand randomly pops and return an item out of array.
 // Note that if the array is empty, the <found> is false.
-func (a *SortedStrArray) PopRand() (value string, found bool) {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *SortedStrArray) PopRand(source ...*grand.Source) (value string, found bool) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
-	return a.doRemoveWithoutLock(grand.Intn(len(a.array)))
+	return a.doRemoveWithoutLock(randIntn(source, len(a.array)))
 }
 
 // PopRands randomly pops and returns <size> items out of array.
 // If the given <size> is greater than size of the array, it returns all elements of the array.
 // Note that if given <size> <= 0 or the array is empty, it returns nil.
-func (a *SortedStrArray) PopRands(size int) []string {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *SortedStrArray) PopRands(size int, source ...*grand.Source) []string {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 	if size <= 0 || len(a.array) == 0 {
@@ -221,7 +223,7 @@ func (a *SortedStrArray) PopRands(size int) []string {
 	}
 	array := make([]string, size)
 	for i := 0; i < size; i++ {
-		array[i], _ = a.doRemoveWithoutLock(grand.Intn(len(a.array)))
+		array[i], _ = a.doRemoveWithoutLock(randIntn(source, len(a.array)))
 	}
 	return array
 }
@@ -552,17 +554,19 @@ func (a *SortedStrArray) Chunk(size int) [][]string {
 }
 
 // Rand randomly returns one item from array(no deleting).
-func (a *SortedStrArray) Rand() (value string, found bool) {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *SortedStrArray) Rand(source ...*grand.Source) (value string, found bool) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 	if len(a.array) == 0 {
 		return "", false
 	}
-	return a.array[grand.Intn(len(a.array))], true
+	return a.array[randIntn(source, len(a.array))], true
 }
 
 // Rands randomly returns <size> items from array(no deleting).
-func (a *SortedStrArray) Rands(size int) []string {
+// The optional parameter <source> specifies the random generator, eg: grand.NewSource.
+func (a *SortedStrArray) Rands(size int, source ...*grand.Source) []string {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 	if size <= 0 || len(a.array) == 0 {
@@ -570,7 +574,7 @@ func (a *SortedStrArray) Rands(size int) []string {
 	}
 	array := make([]string, size)
 	for i := 0; i < size; i++ {
-		array[i] = a.array[grand.Intn(len(a.array))]
+		array[i] = a.array[randIntn(source, len(a.array))]
 	}
 	return array
 }
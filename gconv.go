@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/ilylx/gconv/internal/encoding/gbinary"
+	"github.com/ilylx/gconv/internal/os/gtime"
+	"math"
 	"reflect"
 	"strconv"
 	"strings"
@@ -27,6 +29,12 @@ var (
 	// Note, the "gconv", "param", "params" tags are used by old version of package.
 	// It is strongly recommended using short tag "c" or "p" instead in the future.
 	StructTagPriority = []string{"gconv", "param", "params", "c", "p", "json"}
+
+	// Priority tags for default values of attributes in Struct* functions.
+	StructDefaultTagPriority = []string{"default", "d"}
+
+	// Priority tags for validation rules of attributes in Struct* functions.
+	StructValidTagPriority = []string{"valid", "v"}
 )
 
 // Convert converts the variable <i> to the type <t>, the type <t> is specified by string.
@@ -326,6 +334,10 @@ func String(i interface{}) string {
 		if value == nil {
 			return ""
 		}
+		// Custom converter checks.
+		if result, err, ok := callConverter(value, stringType); ok && err == nil {
+			return result.String()
+		}
 		if f, ok := value.(apiString); ok {
 			// If the variable implements the String() interface,
 			// then use that interface to perform the conversion
@@ -336,6 +348,16 @@ func String(i interface{}) string {
 			// then use that interface to perform the conversion
 			return f.Error()
 		}
+		if v, ok := driverValue(value); ok {
+			// If the variable implements the driver.Valuer interface,
+			// then use its driver value to perform the conversion
+			return String(v)
+		}
+		if text, ok := marshalText(value); ok {
+			// If the variable implements the MarshalText() interface,
+			// then use that interface to perform the conversion
+			return text
+		}
 		// Reflect checks.
 		var (
 			rv   = reflect.ValueOf(value)
@@ -367,8 +389,49 @@ func String(i interface{}) string {
 	}
 }
 
+// marshalText converts <value> to string using its MarshalText interface.
+// It returns false if <value> does not implement the interface, or it fails marshaling.
+// Note that the time types are excluded, as they are handled specially by the converting.
+func marshalText(value interface{}) (text string, ok bool) {
+	switch value.(type) {
+	case time.Time, *time.Time, gtime.Time, *gtime.Time:
+		return "", false
+	}
+	m, ok := value.(apiMarshalText)
+	if !ok {
+		return "", false
+	}
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return "", false
+	}
+	b, err := m.MarshalText()
+	if err != nil {
+		return "", false
+	}
+	return string(b), true
+}
+
+// driverValue retrieves the database driver value of <value> using its driver.Valuer interface,
+// which makes the database null types like sql.NullString converted as their values.
+// It returns false if <value> does not implement the interface, or it fails retrieving.
+func driverValue(value interface{}) (v interface{}, ok bool) {
+	valuer, ok := value.(apiValue)
+	if !ok {
+		return nil, false
+	}
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil, false
+	}
+	dv, err := valuer.Value()
+	if err != nil {
+		return nil, false
+	}
+	return dv, true
+}
+
 // Bool converts <i> to bool.
-// It returns false if <i> is: false, "", 0, "false", "off", "no", empty slice/map.
+// It returns false if <i> is: false, "", 0, "false", "off", "no", empty slice/map,
+// or the false words registered by RegisterBoolWords.
 func Bool(i interface{}) bool {
 	if i == nil {
 		return false
@@ -377,16 +440,14 @@ func Bool(i interface{}) bool {
 	case bool:
 		return value
 	case []byte:
-		if _, ok := emptyStringMap[strings.ToLower(string(value))]; ok {
-			return false
-		}
-		return true
+		return parseBool(strings.ToLower(string(value)))
 	case string:
-		if _, ok := emptyStringMap[strings.ToLower(value)]; ok {
-			return false
-		}
-		return true
+		return parseBool(strings.ToLower(value))
 	default:
+		if v, ok := driverValue(i); ok {
+			// The database null types like sql.NullBool.
+			return Bool(v)
+		}
 		rv := reflect.ValueOf(i)
 		switch rv.Kind() {
 		case reflect.Ptr:
@@ -400,15 +461,23 @@ func Bool(i interface{}) bool {
 		case reflect.Struct:
 			return true
 		default:
-			s := strings.ToLower(String(i))
-			if _, ok := emptyStringMap[s]; ok {
-				return false
-			}
-			return true
+			return parseBool(strings.ToLower(String(i)))
 		}
 	}
 }
 
+// parseBool converts lower case string <s> to bool, which is false if <s> is one of the
+// registered false words or the empty strings, or else it's true.
+func parseBool(s string) bool {
+	if v, ok := getBoolWord(s); ok {
+		return v
+	}
+	if _, ok := emptyStringMap[s]; ok {
+		return false
+	}
+	return true
+}
+
 // Int converts <i> to int.
 func Int(i interface{}) int {
 	if i == nil {
@@ -417,7 +486,7 @@ func Int(i interface{}) int {
 	if v, ok := i.(int); ok {
 		return v
 	}
-	return int(Int64(i))
+	return int(boundInt64(Int64(i), math.MinInt, math.MaxInt))
 }
 
 // Int8 converts <i> to int8.
@@ -428,7 +497,7 @@ func Int8(i interface{}) int8 {
 	if v, ok := i.(int8); ok {
 		return v
 	}
-	return int8(Int64(i))
+	return int8(boundInt64(Int64(i), math.MinInt8, math.MaxInt8))
 }
 
 // Int16 converts <i> to int16.
@@ -439,7 +508,7 @@ func Int16(i interface{}) int16 {
 	if v, ok := i.(int16); ok {
 		return v
 	}
-	return int16(Int64(i))
+	return int16(boundInt64(Int64(i), math.MinInt16, math.MaxInt16))
 }
 
 // Int32 converts <i> to int32.
@@ -450,7 +519,7 @@ func Int32(i interface{}) int32 {
 	if v, ok := i.(int32); ok {
 		return v
 	}
-	return int32(Int64(i))
+	return int32(boundInt64(Int64(i), math.MinInt32, math.MaxInt32))
 }
 
 // Int64 converts <i> to int64.
@@ -470,7 +539,7 @@ func Int64(i interface{}) int64 {
 	case int64:
 		return value
 	case uint:
-		return int64(value)
+		return uint64ToInt64(uint64(value))
 	case uint8:
 		return int64(value)
 	case uint16:
@@ -478,11 +547,11 @@ func Int64(i interface{}) int64 {
 	case uint32:
 		return int64(value)
 	case uint64:
-		return int64(value)
+		return uint64ToInt64(value)
 	case float32:
-		return int64(value)
+		return float64ToInt64(float64(value))
 	case float64:
-		return int64(value)
+		return float64ToInt64(value)
 	case bool:
 		if value {
 			return 1
@@ -491,43 +560,7 @@ func Int64(i interface{}) int64 {
 	case []byte:
 		return gbinary.DecodeToInt64(value)
 	default:
-		s := String(value)
-		isMinus := false
-		if len(s) > 0 {
-			if s[0] == '-' {
-				isMinus = true
-				s = s[1:]
-			} else if s[0] == '+' {
-				s = s[1:]
-			}
-		}
-		// Hexadecimal
-		if len(s) > 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
-			if v, e := strconv.ParseInt(s[2:], 16, 64); e == nil {
-				if isMinus {
-					return -v
-				}
-				return v
-			}
-		}
-		// Octal
-		if len(s) > 1 && s[0] == '0' {
-			if v, e := strconv.ParseInt(s[1:], 8, 64); e == nil {
-				if isMinus {
-					return -v
-				}
-				return v
-			}
-		}
-		// Decimal
-		if v, e := strconv.ParseInt(s, 10, 64); e == nil {
-			if isMinus {
-				return -v
-			}
-			return v
-		}
-		// Float64
-		return int64(Float64(value))
+		return stringToInt64(String(value))
 	}
 }
 
@@ -539,7 +572,7 @@ func Uint(i interface{}) uint {
 	if v, ok := i.(uint); ok {
 		return v
 	}
-	return uint(Uint64(i))
+	return uint(boundUint64(Uint64(i), math.MaxUint))
 }
 
 // Uint8 converts <i> to uint8.
@@ -550,7 +583,7 @@ func Uint8(i interface{}) uint8 {
 	if v, ok := i.(uint8); ok {
 		return v
 	}
-	return uint8(Uint64(i))
+	return uint8(boundUint64(Uint64(i), math.MaxUint8))
 }
 
 // Uint16 converts <i> to uint16.
@@ -561,7 +594,7 @@ func Uint16(i interface{}) uint16 {
 	if v, ok := i.(uint16); ok {
 		return v
 	}
-	return uint16(Uint64(i))
+	return uint16(boundUint64(Uint64(i), math.MaxUint16))
 }
 
 // Uint32 converts <i> to uint32.
@@ -572,7 +605,7 @@ func Uint32(i interface{}) uint32 {
 	if v, ok := i.(uint32); ok {
 		return v
 	}
-	return uint32(Uint64(i))
+	return uint32(boundUint64(Uint64(i), math.MaxUint32))
 }
 
 // Uint64 converts <i> to uint64.
@@ -582,15 +615,15 @@ func Uint64(i interface{}) uint64 {
 	}
 	switch value := i.(type) {
 	case int:
-		return uint64(value)
+		return int64ToUint64(int64(value))
 	case int8:
-		return uint64(value)
+		return int64ToUint64(int64(value))
 	case int16:
-		return uint64(value)
+		return int64ToUint64(int64(value))
 	case int32:
-		return uint64(value)
+		return int64ToUint64(int64(value))
 	case int64:
-		return uint64(value)
+		return int64ToUint64(value)
 	case uint:
 		return uint64(value)
 	case uint8:
@@ -602,9 +635,9 @@ func Uint64(i interface{}) uint64 {
 	case uint64:
 		return value
 	case float32:
-		return uint64(value)
+		return float64ToUint64(float64(value))
 	case float64:
-		return uint64(value)
+		return float64ToUint64(value)
 	case bool:
 		if value {
 			return 1
@@ -613,29 +646,12 @@ func Uint64(i interface{}) uint64 {
 	case []byte:
 		return gbinary.DecodeToUint64(value)
 	default:
-		s := String(value)
-		// Hexadecimal
-		if len(s) > 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
-			if v, e := strconv.ParseUint(s[2:], 16, 64); e == nil {
-				return v
-			}
-		}
-		// Octal
-		if len(s) > 1 && s[0] == '0' {
-			if v, e := strconv.ParseUint(s[1:], 8, 64); e == nil {
-				return v
-			}
-		}
-		// Decimal
-		if v, e := strconv.ParseUint(s, 10, 64); e == nil {
-			return v
-		}
-		// Float64
-		return uint64(Float64(value))
+		return stringToUint64(String(value))
 	}
 }
 
 // Float32 converts <i> to float32.
+// The numeric string <i> is parsed in the locale set by SetNumberLocale, see NumberLocale.
 func Float32(i interface{}) float32 {
 	if i == nil {
 		return 0
@@ -648,12 +664,12 @@ func Float32(i interface{}) float32 {
 	case []byte:
 		return gbinary.DecodeToFloat32(value)
 	default:
-		v, _ := strconv.ParseFloat(String(i), 64)
-		return float32(v)
+		return float32(stringToFloat64(String(i)))
 	}
 }
 
 // Float64 converts <i> to float64.
+// The numeric string <i> is parsed in the locale set by SetNumberLocale, see NumberLocale.
 func Float64(i interface{}) float64 {
 	if i == nil {
 		return 0
@@ -666,7 +682,101 @@ func Float64(i interface{}) float64 {
 	case []byte:
 		return gbinary.DecodeToFloat64(value)
 	default:
-		v, _ := strconv.ParseFloat(String(i), 64)
+		return stringToFloat64(String(i))
+	}
+}
+
+// stringToFloat64 converts string <s> to float64 like Float64, which avoids the interface boxing
+// for the callers having the typed string value, like the slice converting.
+func stringToFloat64(s string) float64 {
+	v, _ := strconv.ParseFloat(normalizeNumber(s), 64)
+	return v
+}
+
+// stringToInt64 converts string <s> to int64 like Int64, which avoids the interface boxing
+// for the callers having the typed string value, like the slice converting.
+func stringToInt64(s string) int64 {
+	var (
+		origin  = s
+		isMinus = false
+	)
+	if len(s) > 0 {
+		if s[0] == '-' {
+			isMinus = true
+			s = s[1:]
+		} else if s[0] == '+' {
+			s = s[1:]
+		}
+	}
+	// Hexadecimal
+	if len(s) > 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		if v, e := strconv.ParseInt(s[2:], 16, 64); e == nil {
+			if isMinus {
+				return -v
+			}
+			return v
+		} else if isRangeError(e) && isSaturating() {
+			if isMinus {
+				return math.MinInt64
+			}
+			return math.MaxInt64
+		}
+	}
+	// Octal
+	if len(s) > 1 && s[0] == '0' {
+		if v, e := strconv.ParseInt(s[1:], 8, 64); e == nil {
+			if isMinus {
+				return -v
+			}
+			return v
+		} else if isRangeError(e) && isSaturating() {
+			if isMinus {
+				return math.MinInt64
+			}
+			return math.MaxInt64
+		}
+	}
+	// Decimal
+	if v, e := strconv.ParseInt(s, 10, 64); e == nil {
+		if isMinus {
+			return -v
+		}
+		return v
+	} else if isRangeError(e) && isSaturating() {
+		if isMinus {
+			return math.MinInt64
+		}
+		return math.MaxInt64
+	}
+	// Float64
+	return float64ToInt64(stringToFloat64(origin))
+}
+
+// stringToUint64 converts string <s> to uint64 like Uint64, which avoids the interface boxing
+// for the callers having the typed string value, like the slice converting.
+func stringToUint64(s string) uint64 {
+	// Hexadecimal
+	if len(s) > 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		if v, e := strconv.ParseUint(s[2:], 16, 64); e == nil {
+			return v
+		} else if isRangeError(e) && isSaturating() {
+			return math.MaxUint64
+		}
+	}
+	// Octal
+	if len(s) > 1 && s[0] == '0' {
+		if v, e := strconv.ParseUint(s[1:], 8, 64); e == nil {
+			return v
+		} else if isRangeError(e) && isSaturating() {
+			return math.MaxUint64
+		}
+	}
+	// Decimal
+	if v, e := strconv.ParseUint(s, 10, 64); e == nil {
 		return v
+	} else if isRangeError(e) && isSaturating() {
+		return math.MaxUint64
 	}
+	// Float64
+	return float64ToUint64(stringToFloat64(s))
 }
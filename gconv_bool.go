@@ -0,0 +1,46 @@
+package gconv
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// boolWordMu ensures the concurrent safety of registering bool words.
+	boolWordMu sync.Mutex
+	// boolWordMap stores the registered bool words, which is copy-on-write map[string]bool
+	// mapping lower case word to its bool value.
+	boolWordMap atomic.Value
+)
+
+// RegisterBoolWords registers custom <words> as bool <value> for converting string to bool,
+// which is consulted by Bool and BoolE before the builtin words.
+// The words are case-insensitive, and registering an existing word overwrites its value.
+//
+// Eg:
+//
+//	gconv.RegisterBoolWords(true, "enabled", "是")
+//	gconv.RegisterBoolWords(false, "disabled", "否")
+//	gconv.Bool("Disabled") // false
+func RegisterBoolWords(value bool, words ...string) {
+	boolWordMu.Lock()
+	defer boolWordMu.Unlock()
+	oldMap, _ := boolWordMap.Load().(map[string]bool)
+	newMap := make(map[string]bool, len(oldMap)+len(words))
+	for k, v := range oldMap {
+		newMap[k] = v
+	}
+	for _, word := range words {
+		newMap[strings.ToLower(word)] = value
+	}
+	boolWordMap.Store(newMap)
+}
+
+// getBoolWord returns the bool value of registered word <s>, which should be in lower case.
+func getBoolWord(s string) (value bool, ok bool) {
+	if m, _ := boolWordMap.Load().(map[string]bool); m != nil {
+		value, ok = m[s]
+	}
+	return
+}
@@ -0,0 +1,118 @@
+package gconv
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/ilylx/gconv/internal/gerror"
+)
+
+// BytesEncoding is the encoding of the string form of []byte,
+// which is used by BytesE/BytesWithEncoding decoding string and StringWithEncoding encoding []byte.
+type BytesEncoding int
+
+const (
+	ENCODING_RAW            BytesEncoding = iota // Raw bytes of string, which is the default.
+	ENCODING_BASE64                              // Standard base64 encoding with padding.
+	ENCODING_BASE64_URL                          // URL-safe base64 encoding with padding.
+	ENCODING_BASE64_RAW_URL                      // URL-safe base64 encoding without padding.
+	ENCODING_HEX                                 // Hexadecimal encoding.
+)
+
+// BytesE converts <i> to []byte, it returns error if decoding fails.
+// The optional parameter <encoding> specifies the encoding of string or []byte <i>,
+// which is decoded explicitly without guessing. See BytesWithEncoding.
+//
+// Eg:
+//
+//	gconv.BytesE("aGVsbG8=", gconv.ENCODING_BASE64) // []byte("hello"), nil
+//	gconv.BytesE("68656c6c6f", gconv.ENCODING_HEX)  // []byte("hello"), nil
+func BytesE(i interface{}, encoding ...BytesEncoding) ([]byte, error) {
+	if len(encoding) == 0 || encoding[0] == ENCODING_RAW {
+		return Bytes(i), nil
+	}
+	var src []byte
+	switch value := i.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		src = []byte(value)
+	case []byte:
+		src = value
+	default:
+		src = []byte(String(i))
+	}
+	var (
+		dst []byte
+		n   int
+		err error
+	)
+	switch encoding[0] {
+	case ENCODING_BASE64, ENCODING_BASE64_URL, ENCODING_BASE64_RAW_URL:
+		enc := getBase64Encoding(encoding[0])
+		dst = make([]byte, enc.DecodedLen(len(src)))
+		n, err = enc.Decode(dst, src)
+	case ENCODING_HEX:
+		dst = make([]byte, hex.DecodedLen(len(src)))
+		n, err = hex.Decode(dst, src)
+	default:
+		return nil, gerror.Newf(`unsupported bytes encoding: %d`, encoding[0])
+	}
+	if err != nil {
+		return nil, gerror.Wrapf(err, `decoding "%s" failed`, src)
+	}
+	return dst[:n], nil
+}
+
+// BytesWithEncoding converts string or []byte <i> in <encoding> to []byte like BytesE,
+// but it returns nil if decoding fails.
+//
+// Eg:
+//
+//	gconv.BytesWithEncoding("aGVsbG8=", gconv.ENCODING_BASE64) // []byte("hello")
+//	gconv.BytesWithEncoding("6x", gconv.ENCODING_HEX)          // nil
+func BytesWithEncoding(i interface{}, encoding BytesEncoding) []byte {
+	b, _ := BytesE(i, encoding)
+	return b
+}
+
+// StringWithEncoding converts <i> to string in <encoding>, which encodes []byte <i>,
+// or the string form of any other type <i>, like ENCODING_BASE64.
+//
+// Eg:
+//
+//	gconv.StringWithEncoding([]byte("hello"), gconv.ENCODING_BASE64) // "aGVsbG8="
+//	gconv.StringWithEncoding("hello", gconv.ENCODING_HEX)            // "68656c6c6f"
+func StringWithEncoding(i interface{}, encoding BytesEncoding) string {
+	switch value := i.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return encodeBytes(value, encoding)
+	default:
+		return encodeBytes([]byte(String(i)), encoding)
+	}
+}
+
+// encodeBytes encodes <b> to string in <encoding>.
+func encodeBytes(b []byte, encoding BytesEncoding) string {
+	switch encoding {
+	case ENCODING_BASE64, ENCODING_BASE64_URL, ENCODING_BASE64_RAW_URL:
+		return getBase64Encoding(encoding).EncodeToString(b)
+	case ENCODING_HEX:
+		return hex.EncodeToString(b)
+	default:
+		return string(b)
+	}
+}
+
+// getBase64Encoding returns the base64.Encoding of <encoding>.
+func getBase64Encoding(encoding BytesEncoding) *base64.Encoding {
+	switch encoding {
+	case ENCODING_BASE64_URL:
+		return base64.URLEncoding
+	case ENCODING_BASE64_RAW_URL:
+		return base64.RawURLEncoding
+	default:
+		return base64.StdEncoding
+	}
+}
@@ -0,0 +1,101 @@
+package gconv
+
+import (
+	"github.com/ilylx/gconv/internal/gerror"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// converterMu ensures the concurrent safety of converterMap.
+	converterMu sync.RWMutex
+	// converterMap stores the registered custom converters,
+	// which maps source type to destination type to the converting function.
+	converterMap = make(map[reflect.Type]map[reflect.Type]reflect.Value)
+	// converterCount is the count of registered converters,
+	// which is used for fast checks if there's no converter registered.
+	converterCount int32
+	// errorType is the reflect type of error interface.
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+	// stringType is the reflect type of string.
+	stringType = reflect.TypeOf("")
+)
+
+// RegisterConverter registers custom converting function <fn> for certain types,
+// which is consulted by String, Struct, Scan, MapToMap and To/ToE functions.
+// The parameter <fn> should be defined as: func(src SrcType) (DstType, error).
+// Registering a function for the same source and destination types overwrites the previous one.
+//
+// Eg:
+//
+//	gconv.RegisterConverter(func(src MyID) (string, error) {
+//		return "ID-" + strconv.Itoa(int(src)), nil
+//	})
+func RegisterConverter(fn interface{}) error {
+	var (
+		rv = reflect.ValueOf(fn)
+		rt = rv.Type()
+	)
+	if rv.Kind() != reflect.Func {
+		return gerror.Newf(`converter should be type of func, but got: %s`, rt.String())
+	}
+	if rt.NumIn() != 1 || rt.NumOut() != 2 || rt.Out(1) != errorType {
+		return gerror.Newf(
+			`converter should be defined as "func(src SrcType) (DstType, error)", but got: %s`,
+			rt.String(),
+		)
+	}
+	var (
+		srcType = rt.In(0)
+		dstType = rt.Out(0)
+	)
+	if srcType == dstType {
+		return gerror.Newf(`converter source and destination types cannot be the same: %s`, srcType.String())
+	}
+	converterMu.Lock()
+	defer converterMu.Unlock()
+	if converterMap[srcType] == nil {
+		converterMap[srcType] = make(map[reflect.Type]reflect.Value)
+	}
+	if _, ok := converterMap[srcType][dstType]; !ok {
+		atomic.AddInt32(&converterCount, 1)
+	}
+	converterMap[srcType][dstType] = rv
+	return nil
+}
+
+// getConverter returns the registered converter from <srcType> to <dstType>.
+func getConverter(srcType, dstType reflect.Type) (fn reflect.Value, ok bool) {
+	if atomic.LoadInt32(&converterCount) == 0 || srcType == nil {
+		return
+	}
+	converterMu.RLock()
+	fn, ok = converterMap[srcType][dstType]
+	converterMu.RUnlock()
+	return
+}
+
+// callConverter converts <value> to <dstType> using the registered converter.
+// The returned <ok> is false if there's no converter registered for the types.
+func callConverter(value interface{}, dstType reflect.Type) (result reflect.Value, err error, ok bool) {
+	fn, ok := getConverter(reflect.TypeOf(value), dstType)
+	if !ok {
+		return
+	}
+	out := fn.Call([]reflect.Value{reflect.ValueOf(value)})
+	if e := out[1].Interface(); e != nil {
+		return reflect.Value{}, e.(error), true
+	}
+	return out[0], nil, true
+}
+
+// bindVarToReflectValueWithConverter sets <value> to <rv> using the registered converter.
+// The returned <ok> is false if there's no converter registered for the types.
+func bindVarToReflectValueWithConverter(rv reflect.Value, value interface{}) (err error, ok bool) {
+	result, err, ok := callConverter(value, rv.Type())
+	if ok && err == nil {
+		rv.Set(result)
+	}
+	return err, ok
+}
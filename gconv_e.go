@@ -0,0 +1,599 @@
+package gconv
+
+import (
+	"github.com/ilylx/gconv/internal/gerror"
+	"github.com/ilylx/gconv/internal/os/gtime"
+	"github.com/ilylx/gconv/internal/utils"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IntE converts <i> to int, it returns error if <i> cannot be converted or overflows.
+// The optional parameter <option> specifies the converting option, see ConvertOption.
+func IntE(i interface{}, option ...ConvertOption) (int, error) {
+	if v, ok := i.(int); ok {
+		return v, nil
+	}
+	v, err := Int64E(i, option...)
+	if err != nil {
+		return 0, err
+	}
+	if strconv.IntSize == 32 && (v < math.MinInt32 || v > math.MaxInt32) {
+		return 0, newOverflowError(i, "int")
+	}
+	return int(v), nil
+}
+
+// Int8E converts <i> to int8, it returns error if <i> cannot be converted or overflows.
+// The optional parameter <option> specifies the converting option, see ConvertOption.
+func Int8E(i interface{}, option ...ConvertOption) (int8, error) {
+	v, err := Int64E(i, option...)
+	if err != nil {
+		return 0, err
+	}
+	if v < math.MinInt8 || v > math.MaxInt8 {
+		return 0, newOverflowError(i, "int8")
+	}
+	return int8(v), nil
+}
+
+// Int16E converts <i> to int16, it returns error if <i> cannot be converted or overflows.
+// The optional parameter <option> specifies the converting option, see ConvertOption.
+func Int16E(i interface{}, option ...ConvertOption) (int16, error) {
+	v, err := Int64E(i, option...)
+	if err != nil {
+		return 0, err
+	}
+	if v < math.MinInt16 || v > math.MaxInt16 {
+		return 0, newOverflowError(i, "int16")
+	}
+	return int16(v), nil
+}
+
+// Int32E converts <i> to int32, it returns error if <i> cannot be converted or overflows.
+// The optional parameter <option> specifies the converting option, see ConvertOption.
+func Int32E(i interface{}, option ...ConvertOption) (int32, error) {
+	v, err := Int64E(i, option...)
+	if err != nil {
+		return 0, err
+	}
+	if v < math.MinInt32 || v > math.MaxInt32 {
+		return 0, newOverflowError(i, "int32")
+	}
+	return int32(v), nil
+}
+
+// Int64E converts <i> to int64, it returns error if <i> cannot be converted.
+// A nil <i> is converted to 0 without error.
+// The optional parameter <option> specifies the converting option, see ConvertOption.
+func Int64E(i interface{}, option ...ConvertOption) (int64, error) {
+	if i == nil {
+		return 0, nil
+	}
+	strict := isStrict(option)
+	switch value := i.(type) {
+	case int, int8, int16, int32, int64:
+		return Int64(value), nil
+	case bool, []byte:
+		if strict {
+			return 0, newConvertError(i, "int64")
+		}
+		return Int64(value), nil
+	case uint8, uint16, uint32:
+		return Int64(value), nil
+	case uint, uint64:
+		if v := Uint64(value); v <= math.MaxInt64 {
+			return int64(v), nil
+		}
+		return 0, newOverflowError(i, "int64")
+	case float32, float64:
+		return float64ToInt64E(i, Float64(value), strict)
+	default:
+		s := normalizeNumber(strings.TrimSpace(String(value)), option...)
+		isMinus := false
+		if len(s) > 0 {
+			if s[0] == '-' {
+				isMinus = true
+				s = s[1:]
+			} else if s[0] == '+' {
+				s = s[1:]
+			}
+		}
+		var (
+			v uint64
+			e error
+		)
+		switch {
+		case len(s) > 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X'):
+			// Hexadecimal
+			v, e = strconv.ParseUint(s[2:], 16, 64)
+		case len(s) > 1 && s[0] == '0' && isOctalString(s[1:]):
+			// Octal
+			v, e = strconv.ParseUint(s[1:], 8, 64)
+		default:
+			// Decimal
+			v, e = strconv.ParseUint(s, 10, 64)
+			if e != nil && !isRangeError(e) {
+				// Float64
+				f, fe := strconv.ParseFloat(s, 64)
+				if fe != nil {
+					return 0, newConvertError(i, "int64")
+				}
+				if isMinus {
+					f = -f
+				}
+				return float64ToInt64E(i, f, strict)
+			}
+		}
+		if e != nil {
+			if isRangeError(e) {
+				return 0, newOverflowError(i, "int64")
+			}
+			return 0, newConvertError(i, "int64")
+		}
+		if isMinus {
+			if v > 1<<63 {
+				return 0, newOverflowError(i, "int64")
+			}
+			return -int64(v), nil
+		}
+		if v > math.MaxInt64 {
+			return 0, newOverflowError(i, "int64")
+		}
+		return int64(v), nil
+	}
+}
+
+// UintE converts <i> to uint, it returns error if <i> cannot be converted or overflows.
+// The optional parameter <option> specifies the converting option, see ConvertOption.
+func UintE(i interface{}, option ...ConvertOption) (uint, error) {
+	if v, ok := i.(uint); ok {
+		return v, nil
+	}
+	v, err := Uint64E(i, option...)
+	if err != nil {
+		return 0, err
+	}
+	if strconv.IntSize == 32 && v > math.MaxUint32 {
+		return 0, newOverflowError(i, "uint")
+	}
+	return uint(v), nil
+}
+
+// Uint8E converts <i> to uint8, it returns error if <i> cannot be converted or overflows.
+// The optional parameter <option> specifies the converting option, see ConvertOption.
+func Uint8E(i interface{}, option ...ConvertOption) (uint8, error) {
+	v, err := Uint64E(i, option...)
+	if err != nil {
+		return 0, err
+	}
+	if v > math.MaxUint8 {
+		return 0, newOverflowError(i, "uint8")
+	}
+	return uint8(v), nil
+}
+
+// Uint16E converts <i> to uint16, it returns error if <i> cannot be converted or overflows.
+// The optional parameter <option> specifies the converting option, see ConvertOption.
+func Uint16E(i interface{}, option ...ConvertOption) (uint16, error) {
+	v, err := Uint64E(i, option...)
+	if err != nil {
+		return 0, err
+	}
+	if v > math.MaxUint16 {
+		return 0, newOverflowError(i, "uint16")
+	}
+	return uint16(v), nil
+}
+
+// Uint32E converts <i> to uint32, it returns error if <i> cannot be converted or overflows.
+// The optional parameter <option> specifies the converting option, see ConvertOption.
+func Uint32E(i interface{}, option ...ConvertOption) (uint32, error) {
+	v, err := Uint64E(i, option...)
+	if err != nil {
+		return 0, err
+	}
+	if v > math.MaxUint32 {
+		return 0, newOverflowError(i, "uint32")
+	}
+	return uint32(v), nil
+}
+
+// Uint64E converts <i> to uint64, it returns error if <i> cannot be converted or is negative.
+// A nil <i> is converted to 0 without error.
+// The optional parameter <option> specifies the converting option, see ConvertOption.
+func Uint64E(i interface{}, option ...ConvertOption) (uint64, error) {
+	if i == nil {
+		return 0, nil
+	}
+	strict := isStrict(option)
+	switch value := i.(type) {
+	case uint, uint8, uint16, uint32, uint64:
+		return Uint64(value), nil
+	case bool, []byte:
+		if strict {
+			return 0, newConvertError(i, "uint64")
+		}
+		return Uint64(value), nil
+	case int, int8, int16, int32, int64:
+		if Int64(value) < 0 {
+			return 0, newOverflowError(i, "uint64")
+		}
+		return Uint64(value), nil
+	case float32, float64:
+		return float64ToUint64E(i, Float64(value), strict)
+	default:
+		s := normalizeNumber(strings.TrimSpace(String(value)), option...)
+		if len(s) > 0 && s[0] == '+' {
+			s = s[1:]
+		}
+		var (
+			v uint64
+			e error
+		)
+		switch {
+		case len(s) > 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X'):
+			// Hexadecimal
+			v, e = strconv.ParseUint(s[2:], 16, 64)
+		case len(s) > 1 && s[0] == '0' && isOctalString(s[1:]):
+			// Octal
+			v, e = strconv.ParseUint(s[1:], 8, 64)
+		default:
+			// Decimal
+			v, e = strconv.ParseUint(s, 10, 64)
+			if e != nil && !isRangeError(e) {
+				// Float64
+				f, fe := strconv.ParseFloat(s, 64)
+				if fe != nil {
+					return 0, newConvertError(i, "uint64")
+				}
+				return float64ToUint64E(i, f, strict)
+			}
+		}
+		if e != nil {
+			if isRangeError(e) {
+				return 0, newOverflowError(i, "uint64")
+			}
+			return 0, newConvertError(i, "uint64")
+		}
+		return v, nil
+	}
+}
+
+// Float32E converts <i> to float32, it returns error if <i> cannot be converted.
+// The optional parameter <option> specifies the converting option, see ConvertOption.
+// It returns error if the finite value overflows float32, and in strict mode it also
+// returns error if the value loses precision beyond float32, like 16777217 or 0.123456789,
+// but not 0.1, which is kept by the shortest float32 representation.
+func Float32E(i interface{}, option ...ConvertOption) (float32, error) {
+	if v, ok := i.(float32); ok {
+		return v, nil
+	}
+	v, err := doFloatE(i, 32, option...)
+	return float32(v), err
+}
+
+// Float64E converts <i> to float64, it returns error if <i> cannot be converted.
+// A nil <i> is converted to 0 without error.
+// The optional parameter <option> specifies the converting option, see ConvertOption.
+// In strict mode it returns error if the value loses precision beyond float64,
+// like 9007199254740993.
+func Float64E(i interface{}, option ...ConvertOption) (float64, error) {
+	return doFloatE(i, 64, option...)
+}
+
+// doFloatE converts <i> to float of <bitSize> 32 or 64, which implements Float32E and Float64E.
+func doFloatE(i interface{}, bitSize int, option ...ConvertOption) (float64, error) {
+	if i == nil {
+		return 0, nil
+	}
+	var (
+		v        float64
+		origin   string // The decimal representation of <i> for the precision checks in strict mode.
+		strict   = isStrict(option)
+		typeName = "float" + strconv.Itoa(bitSize)
+	)
+	switch value := i.(type) {
+	case float32, float64:
+		v = Float64(value)
+		origin = strconv.FormatFloat(v, 'g', -1, 64)
+	case int, int8, int16, int32, int64:
+		n := Int64(value)
+		v, origin = float64(n), strconv.FormatInt(n, 10)
+	case uint, uint8, uint16, uint32, uint64:
+		n := Uint64(value)
+		v, origin = float64(n), strconv.FormatUint(n, 10)
+	case []byte:
+		if strict {
+			return 0, newConvertError(i, typeName)
+		}
+		v = Float64(value)
+	case bool:
+		if strict {
+			return 0, newConvertError(i, typeName)
+		}
+		if value {
+			v = 1
+		}
+	default:
+		origin = normalizeNumber(strings.TrimSpace(String(i)), option...)
+		var err error
+		if v, err = strconv.ParseFloat(origin, 64); err != nil {
+			return 0, newConvertError(i, typeName)
+		}
+	}
+	if bitSize == 32 {
+		f := float64(float32(v))
+		if math.IsInf(f, 0) && !math.IsInf(v, 0) {
+			return 0, newOverflowError(i, typeName)
+		}
+		v = f
+	}
+	if strict && origin != "" && !isFloatKeepingDecimal(v, bitSize, origin) {
+		return 0, newLossError(i, typeName)
+	}
+	return v, nil
+}
+
+// BoolE converts <i> to bool, it returns error if <i> cannot be converted.
+// The string <i> is case-insensitive and can be: "1", "t", "true", "on", "yes" as true,
+// or "", "0", "f", "false", "off", "no" as false, or the words registered by RegisterBoolWords.
+// A nil <i> is converted to false without error.
+// The optional parameter <option> specifies the converting option, see ConvertOption.
+func BoolE(i interface{}, option ...ConvertOption) (bool, error) {
+	if i == nil {
+		return false, nil
+	}
+	strict := isStrict(option)
+	switch value := i.(type) {
+	case bool:
+		return value, nil
+	case []byte:
+		return parseBoolString(i, string(value))
+	case string:
+		return parseBoolString(i, value)
+	default:
+		rv := reflect.ValueOf(i)
+		switch rv.Kind() {
+		case reflect.Ptr, reflect.Map, reflect.Array, reflect.Slice, reflect.Struct:
+			if strict {
+				return false, newConvertError(i, "bool")
+			}
+			return Bool(i), nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			f, err := Float64E(i)
+			if err != nil {
+				return false, err
+			}
+			if strict && f != 0 && f != 1 {
+				return false, newConvertError(i, "bool")
+			}
+			return f != 0, nil
+		default:
+			return parseBoolString(i, String(i))
+		}
+	}
+}
+
+// TimeE converts <i> to time.Time, it returns error if <i> cannot be converted.
+// A nil <i> is converted to zero time without error.
+func TimeE(i interface{}, format ...string) (time.Time, error) {
+	if len(format) == 0 {
+		if v, ok := i.(time.Time); ok {
+			return v, nil
+		}
+	}
+	t, err := GTimeE(i, format...)
+	if err != nil || t == nil {
+		return time.Time{}, err
+	}
+	return t.Time, nil
+}
+
+// GTimeE converts <i> to *gtime.Time, it returns error if <i> cannot be converted.
+// A nil <i> is converted to nil without error.
+func GTimeE(i interface{}, format ...string) (*gtime.Time, error) {
+	if i == nil {
+		return nil, nil
+	}
+	if len(format) == 0 {
+		if v, ok := i.(*gtime.Time); ok {
+			return v, nil
+		}
+	}
+	s := strings.TrimSpace(String(i))
+	if len(s) == 0 {
+		return nil, newConvertError(i, "time")
+	}
+	if len(format) > 0 {
+		t, err := gtime.StrToTimeFormat(s, format[0])
+		if err != nil {
+			return nil, gerror.Wrapf(err, `cannot convert "%v" to time with format "%s"`, i, format[0])
+		}
+		return t, nil
+	}
+	if utils.IsNumeric(s) {
+		v, err := Int64E(s)
+		if err != nil {
+			return nil, err
+		}
+		return gtime.NewFromTimeStamp(v), nil
+	}
+	if layouts := gtime.GetLayouts(); len(layouts) > 0 {
+		if t, err := gtime.ParseLayouts(s, layouts...); err == nil {
+			return t, nil
+		}
+	}
+	t, err := gtime.StrToTime(s)
+	if err != nil {
+		return nil, gerror.Wrapf(err, `cannot convert "%v" to time`, i)
+	}
+	return t, nil
+}
+
+// StringE converts <i> to string, it returns error if <i> implements driver.Valuer or
+// encoding.TextMarshaler and fails retrieving its value.
+// In strict mode, it also returns error if <i> is map/slice/struct that has no text representation.
+// The optional parameter <option> specifies the converting option, see ConvertOption.
+func StringE(i interface{}, option ...ConvertOption) (string, error) {
+	if i == nil {
+		return "", nil
+	}
+	switch value := i.(type) {
+	case string:
+		return value, nil
+	case []byte:
+		return string(value), nil
+	case time.Time, *time.Time, gtime.Time, *gtime.Time:
+		return String(value), nil
+	}
+	if rv := reflect.ValueOf(i); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return "", nil
+	}
+	if v, ok := i.(apiValue); ok {
+		dv, err := v.Value()
+		if err != nil {
+			return "", gerror.Wrapf(err, `cannot convert "%v" to string`, i)
+		}
+		return String(dv), nil
+	}
+	if v, ok := i.(apiMarshalText); ok {
+		text, err := v.MarshalText()
+		if err != nil {
+			return "", gerror.Wrapf(err, `cannot convert "%v" to string`, i)
+		}
+		return string(text), nil
+	}
+	if isStrict(option) {
+		if _, ok := i.(apiString); !ok {
+			switch reflect.Indirect(reflect.ValueOf(i)).Kind() {
+			case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+				return "", newConvertError(i, "string")
+			}
+		}
+	}
+	return String(i), nil
+}
+
+// DurationE converts <i> to time.Duration, it returns error if <i> cannot be converted.
+// If <i> is string, then it uses gtime.ParseDuration to convert it.
+// If <i> is numeric, then it converts <i> as nanoseconds, or as the optional <unit>.
+func DurationE(i interface{}, unit ...time.Duration) (time.Duration, error) {
+	if v, ok := i.(time.Duration); ok {
+		return v, nil
+	}
+	if i == nil {
+		return 0, nil
+	}
+	s := strings.TrimSpace(String(i))
+	if !utils.IsNumeric(s) {
+		d, err := gtime.ParseDuration(s)
+		if err != nil {
+			return 0, gerror.Wrapf(err, `cannot convert "%v" to duration`, i)
+		}
+		return d, nil
+	}
+	if len(unit) > 0 && unit[0] > 0 {
+		f, err := Float64E(i)
+		if err != nil {
+			return 0, err
+		}
+		if math.Abs(f*float64(unit[0])) >= math.MaxInt64 {
+			return 0, newOverflowError(i, "duration")
+		}
+		return time.Duration(f * float64(unit[0])), nil
+	}
+	v, err := Int64E(i)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(v), nil
+}
+
+// float64ToInt64E converts float <f> to int64, <i> is the original value for error message.
+// It returns error if <f> has fractional part in strict mode.
+func float64ToInt64E(i interface{}, f float64, strict bool) (int64, error) {
+	if math.IsNaN(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, newOverflowError(i, "int64")
+	}
+	if strict && f != math.Trunc(f) {
+		return 0, newLossError(i, "int64")
+	}
+	return int64(f), nil
+}
+
+// float64ToUint64E converts float <f> to uint64, <i> is the original value for error message.
+// It returns error if <f> has fractional part in strict mode.
+func float64ToUint64E(i interface{}, f float64, strict bool) (uint64, error) {
+	if math.IsNaN(f) || f < 0 || f >= math.MaxUint64 {
+		return 0, newOverflowError(i, "uint64")
+	}
+	if strict && f != math.Trunc(f) {
+		return 0, newLossError(i, "uint64")
+	}
+	return uint64(f), nil
+}
+
+// parseBoolString parses string <s> to bool, <i> is the original value for error message.
+func parseBoolString(i interface{}, s string) (bool, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if v, ok := getBoolWord(s); ok {
+		return v, nil
+	}
+	switch s {
+	case "1", "t", "true", "on", "yes":
+		return true, nil
+	case "", "0", "f", "false", "off", "no":
+		return false, nil
+	}
+	return false, newConvertError(i, "bool")
+}
+
+// isOctalString checks whether <s> contains only octal digits.
+func isOctalString(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '7' {
+			return false
+		}
+	}
+	return true
+}
+
+// newConvertError creates and returns an error for value <i> that cannot be converted to <typeName>.
+func newConvertError(i interface{}, typeName string) error {
+	return gerror.Newf(`cannot convert "%v" to %s`, i, typeName)
+}
+
+// newOverflowError creates and returns an error for value <i> that overflows <typeName>.
+func newOverflowError(i interface{}, typeName string) error {
+	return gerror.Newf(`value "%v" overflows %s`, i, typeName)
+}
+
+// newLossError creates and returns an error for value <i> that loses precision converting to <typeName>.
+func newLossError(i interface{}, typeName string) error {
+	return gerror.Newf(`value "%v" loses precision converting to %s`, i, typeName)
+}
+
+// isFloatKeepingDecimal checks whether float <f> of <bitSize> keeps the value of decimal string <s>,
+// that is, either the exact value of <f>, like 1<<60, or the shortest representation of <f>
+// in <bitSize>, like 0.1, equals to <s> in value. The non-finite values are always kept.
+func isFloatKeepingDecimal(f float64, bitSize int, s string) bool {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return true
+	}
+	origin, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return true
+	}
+	if origin.Cmp(new(big.Rat).SetFloat64(f)) == 0 {
+		return true
+	}
+	shortest, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, bitSize))
+	return ok && origin.Cmp(shortest) == 0
+}
@@ -0,0 +1,216 @@
+package gconv
+
+import (
+	"fmt"
+	"github.com/ilylx/gconv/internal/gerror"
+	"github.com/ilylx/gconv/internal/os/gtime"
+	"reflect"
+	"time"
+)
+
+// To converts <value> to type T, which is a generic entry point of the converting functions.
+// It returns the zero value of T if the converting fails, use ToE if the error is concerned.
+//
+// Eg:
+//
+//	gconv.To[int]("100")                // 100
+//	gconv.To[[]string]([]int{1, 2})     // ["1", "2"]
+//	gconv.To[*User](map[string]interface{}{"name": "john"})
+func To[T any](value interface{}, option ...ConvertOption) T {
+	result, _ := ToE[T](value, option...)
+	return result
+}
+
+// ToE converts <value> to type T, and returns error if the converting fails.
+// It dispatches to the existing converting functions like IntE/StringE/StringsE for the
+// common types, and falls back to Struct/Structs/MapToMap for the struct/slice/map types.
+// The optional parameter <option> specifies the converting option for the primitive types,
+// see ConvertOption.
+func ToE[T any](value interface{}, option ...ConvertOption) (result T, err error) {
+	if v, ok := value.(T); ok {
+		return v, nil
+	}
+	if value == nil {
+		return result, nil
+	}
+	// Custom converter checks.
+	if err, ok := bindVarToReflectValueWithConverter(reflect.ValueOf(&result).Elem(), value); ok {
+		return result, err
+	}
+	switch p := interface{}(&result).(type) {
+	case *int:
+		*p, err = IntE(value, option...)
+	case *int8:
+		*p, err = Int8E(value, option...)
+	case *int16:
+		*p, err = Int16E(value, option...)
+	case *int32:
+		*p, err = Int32E(value, option...)
+	case *int64:
+		*p, err = Int64E(value, option...)
+	case *uint:
+		*p, err = UintE(value, option...)
+	case *uint8:
+		*p, err = Uint8E(value, option...)
+	case *uint16:
+		*p, err = Uint16E(value, option...)
+	case *uint32:
+		*p, err = Uint32E(value, option...)
+	case *uint64:
+		*p, err = Uint64E(value, option...)
+	case *float32:
+		*p, err = Float32E(value, option...)
+	case *float64:
+		*p, err = Float64E(value, option...)
+	case *bool:
+		*p, err = BoolE(value, option...)
+	case *string:
+		*p, err = StringE(value, option...)
+	case *[]byte:
+		*p, err = BytesE(value)
+	case *[]int:
+		*p, err = IntsE(value, option...)
+	case *[]int32:
+		*p, err = Int32sE(value, option...)
+	case *[]int64:
+		*p, err = Int64sE(value, option...)
+	case *[]uint:
+		*p, err = UintsE(value, option...)
+	case *[]uint32:
+		*p, err = Uint32sE(value, option...)
+	case *[]uint64:
+		*p, err = Uint64sE(value, option...)
+	case *[]float32:
+		*p, err = Float32sE(value, option...)
+	case *[]float64:
+		*p, err = Float64sE(value, option...)
+	case *[]string:
+		*p, err = StringsE(value, option...)
+	case *[]interface{}:
+		*p = Interfaces(value)
+	case *time.Time:
+		*p, err = TimeE(value)
+	case *time.Duration:
+		*p, err = DurationE(value)
+	case *map[string]interface{}:
+		*p = Map(value)
+	case *map[string]string:
+		*p = MapStrStr(value)
+	case *[]map[string]interface{}:
+		*p = Maps(value)
+	default:
+		err = doConvertToReflectValue(reflect.ValueOf(&result).Elem(), value, option...)
+	}
+	return
+}
+
+var (
+	// timeType is the reflect type of time.Time.
+	timeType = reflect.TypeOf(time.Time{})
+	// gtimeType is the reflect type of gtime.Time.
+	gtimeType = reflect.TypeOf(gtime.Time{})
+)
+
+// doConvertToReflectValue converts <value> and sets the result to <rv>,
+// which is used for the types that have no dedicated converting function.
+// The optional parameter <option> is used for the named basic types.
+func doConvertToReflectValue(rv reflect.Value, value interface{}, option ...ConvertOption) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = gerror.New(fmt.Sprintf(`cannot convert value "%+v" to type "%s": %v`, value, rv.Type().String(), e))
+		}
+	}()
+	// The time types are converted by the time converting functions.
+	switch rv.Type() {
+	case timeType:
+		rv.Set(reflect.ValueOf(Time(value)))
+		return nil
+	case gtimeType:
+		if t := GTime(value); t != nil {
+			rv.Set(reflect.ValueOf(*t))
+		}
+		return nil
+	}
+	if err, ok := bindVarToReflectValueWithInterfaceCheck(rv, value); ok {
+		return err
+	}
+	// The named basic types, like: type MyInt int,
+	// which are converted by the E functions of their kinds.
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(String(value))
+
+	case reflect.Bool:
+		v, err := BoolE(value, option...)
+		if err != nil {
+			return err
+		}
+		rv.SetBool(v)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := Int64E(value, option...)
+		if err != nil {
+			return err
+		}
+		if rv.OverflowInt(v) {
+			return newOverflowError(value, rv.Type().String())
+		}
+		rv.SetInt(v)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := Uint64E(value, option...)
+		if err != nil {
+			return err
+		}
+		if rv.OverflowUint(v) {
+			return newOverflowError(value, rv.Type().String())
+		}
+		rv.SetUint(v)
+
+	case reflect.Float32:
+		v, err := Float32E(value, option...)
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(float64(v))
+
+	case reflect.Float64:
+		v, err := Float64E(value, option...)
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(v)
+
+	case reflect.Struct:
+		return Struct(value, rv.Addr().Interface())
+
+	case reflect.Ptr:
+		elem := reflect.New(rv.Type().Elem())
+		if err = doConvertToReflectValue(elem.Elem(), value, option...); err != nil {
+			return err
+		}
+		rv.Set(elem)
+
+	case reflect.Slice:
+		if isStructType(rv.Type().Elem()) {
+			return Structs(value, rv.Addr().Interface())
+		}
+		var (
+			items = Interfaces(value)
+			slice = reflect.MakeSlice(rv.Type(), len(items), len(items))
+		)
+		for i, item := range items {
+			if err = doConvertToReflectValue(slice.Index(i), item, option...); err != nil {
+				return err
+			}
+		}
+		rv.Set(slice)
+
+	case reflect.Map:
+		return MapToMap(value, rv.Addr().Interface())
+
+	default:
+		return bindVarToReflectValue(rv, value)
+	}
+	return nil
+}
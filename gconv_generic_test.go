@@ -0,0 +1,275 @@
+package gconv
+
+import (
+	"database/sql/driver"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testValueError struct{}
+
+func (testValueError) Value() (driver.Value, error) {
+	return nil, errors.New("value error")
+}
+
+func Test_ToE_String(t *testing.T) {
+	if v, err := ToE[string](123); err != nil || v != "123" {
+		t.Fatalf("unexpected result: %v, %v", v, err)
+	}
+	// The error of driver.Valuer is reported.
+	if _, err := ToE[string](testValueError{}); err == nil {
+		t.Fatal("expected error of driver.Valuer")
+	}
+	// The option is passed through.
+	if _, err := ToE[string](map[string]int{"a": 1}, ConvertOption{Strict: true}); err == nil {
+		t.Fatal("expected error converting map to string in strict mode")
+	}
+	if v, err := ToE[string](map[string]int{"a": 1}); err != nil || v != `{"a":1}` {
+		t.Fatalf("unexpected result: %v, %v", v, err)
+	}
+}
+
+func Test_ToE_Bytes(t *testing.T) {
+	if v, err := ToE[[]byte]("abc"); err != nil || string(v) != "abc" {
+		t.Fatalf("unexpected result: %v, %v", v, err)
+	}
+	if v := To[[]byte](nil); v != nil {
+		t.Fatalf("unexpected result: %v", v)
+	}
+}
+
+type (
+	testMyInt   int
+	testMyInt8  int8
+	testMyUint  uint
+	testMyFloat float64
+	testMyBool  bool
+)
+
+func Test_ToE_NamedBasic(t *testing.T) {
+	if v, err := ToE[testMyInt]("12"); err != nil || v != 12 {
+		t.Fatalf("unexpected result: %v, %v", v, err)
+	}
+	if v, err := ToE[testMyFloat]("1.5"); err != nil || v != 1.5 {
+		t.Fatalf("unexpected result: %v, %v", v, err)
+	}
+	if v, err := ToE[testMyBool]("true"); err != nil || !bool(v) {
+		t.Fatalf("unexpected result: %v, %v", v, err)
+	}
+	if v, err := ToE[*testMyInt]("3"); err != nil || v == nil || *v != 3 {
+		t.Fatalf("unexpected result: %v, %v", v, err)
+	}
+	// The errors of the E functions are returned.
+	tests := []struct {
+		name string
+		f    func() error
+		err  string
+	}{
+		{"int invalid", func() error { _, err := ToE[testMyInt]("abc"); return err }, "cannot convert"},
+		{"int8 overflow", func() error { _, err := ToE[testMyInt8](300); return err }, "overflows"},
+		{"uint negative", func() error { _, err := ToE[testMyUint](-1); return err }, "overflows"},
+		{"float invalid", func() error { _, err := ToE[testMyFloat]("1.5x"); return err }, "cannot convert"},
+		{"bool strict", func() error { _, err := ToE[testMyBool]("x", ConvertOption{Strict: true}); return err }, "cannot convert"},
+		{"slice element", func() error { _, err := ToE[[]testMyInt]([]string{"1", "x"}); return err }, "cannot convert"},
+	}
+	for _, test := range tests {
+		if err := test.f(); err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: expected error containing %q, got %v", test.name, test.err, err)
+		}
+	}
+}
+
+func Test_E(t *testing.T) {
+	tests := []convertTest{
+		{"int", func() (interface{}, error) { return IntE("12") }, 12, ""},
+		{"int invalid", func() (interface{}, error) { return IntE("abc") }, nil, "cannot convert"},
+		{"int truncated", func() (interface{}, error) { return IntE(3.7) }, 3, ""},
+		{"int8 overflow", func() (interface{}, error) { return Int8E(300) }, nil, "overflows int8"},
+		{"int64 string overflow", func() (interface{}, error) { return Int64E("99999999999999999999") }, nil, "overflows int64"},
+		{"uint negative", func() (interface{}, error) { return UintE(-1) }, nil, "overflows"},
+		{"uint64 string", func() (interface{}, error) { return Uint64E("18446744073709551615") }, uint64(18446744073709551615), ""},
+		{"uint64 string overflow", func() (interface{}, error) { return Uint64E("99999999999999999999") }, nil, "overflows uint64"},
+		{"float64", func() (interface{}, error) { return Float64E(" 1.5 ") }, 1.5, ""},
+		{"float64 invalid", func() (interface{}, error) { return Float64E("1.5x") }, nil, "cannot convert"},
+		{"float64 nil", func() (interface{}, error) { return Float64E(nil) }, float64(0), ""},
+		{"float32 rounded", func() (interface{}, error) { return Float32E(16777217) }, float32(16777216), ""},
+		{"float32 overflow", func() (interface{}, error) { return Float32E(1e300) }, nil, "overflows float32"},
+		{"float32 overflow negative", func() (interface{}, error) { return Float32E("-1e300") }, nil, "overflows float32"},
+		{"bool", func() (interface{}, error) { return BoolE("on") }, true, ""},
+		{"bool false", func() (interface{}, error) { return BoolE("0") }, false, ""},
+		{"bool invalid", func() (interface{}, error) { return BoolE("maybe") }, nil, "cannot convert"},
+		{"duration", func() (interface{}, error) { return DurationE("1m") }, time.Minute, ""},
+		{"duration invalid", func() (interface{}, error) { return DurationE("1x") }, nil, ""},
+		{"time invalid", func() (interface{}, error) { return TimeE("not a time") }, nil, ""},
+	}
+	runConvertTests(t, tests)
+}
+
+func Test_E_Strict(t *testing.T) {
+	strict := Strict()
+	tests := []convertTest{
+		{"int from float", func() (interface{}, error) { return IntE(3.7, strict) }, nil, "loses precision"},
+		{"int from float string", func() (interface{}, error) { return IntE("3.7", strict) }, nil, "loses precision"},
+		{"int from whole float", func() (interface{}, error) { return IntE(3.0, strict) }, 3, ""},
+		{"int from bool", func() (interface{}, error) { return IntE(true, strict) }, nil, "cannot convert"},
+		{"uint negative", func() (interface{}, error) { return UintE("-1", strict) }, nil, "overflows"},
+		{"float64 from big int", func() (interface{}, error) { return Float64E(int64(1)<<53+1, strict) }, nil, "loses precision"},
+		{"float64 from big int string", func() (interface{}, error) { return Float64E("9007199254740993", strict) }, nil, "loses precision"},
+		{"float64 from exact big int", func() (interface{}, error) { return Float64E(int64(1)<<60, strict) }, float64(1 << 60), ""},
+		{"float64 from long decimal", func() (interface{}, error) { return Float64E("0.10000000000000000001", strict) }, nil, "loses precision"},
+		{"float64 from decimal", func() (interface{}, error) { return Float64E("0.1", strict) }, 0.1, ""},
+		{"float64 from exact int", func() (interface{}, error) { return Float64E(int64(1)<<53, strict) }, float64(1 << 53), ""},
+		{"float64 from bool", func() (interface{}, error) { return Float64E(true, strict) }, nil, "cannot convert"},
+		{"float32", func() (interface{}, error) { return Float32E(1.5, strict) }, float32(1.5), ""},
+		{"float32 from exact int", func() (interface{}, error) { return Float32E(16777216, strict) }, float32(16777216), ""},
+		{"float32 from int", func() (interface{}, error) { return Float32E(16777217, strict) }, nil, "loses precision"},
+		{"float32 from float", func() (interface{}, error) { return Float32E(0.1, strict) }, float32(0.1), ""},
+		{"float32 from string", func() (interface{}, error) { return Float32E("0.1", strict) }, float32(0.1), ""},
+		{"float32 from long float", func() (interface{}, error) { return Float32E(0.123456789, strict) }, nil, "loses precision converting to float32"},
+		{"float32 overflow", func() (interface{}, error) { return Float32E(1e300, strict) }, nil, "overflows float32"},
+		{"float32 inf", func() (interface{}, error) { return Float32E(math.Inf(-1), strict) }, float32(math.Inf(-1)), ""},
+		{"to float32", func() (interface{}, error) { return ToE[float32]("16777217", strict) }, nil, "loses precision"},
+		{"to int", func() (interface{}, error) { return ToE[int]("3.7", strict) }, nil, "loses precision"},
+		{"to uint", func() (interface{}, error) { return ToE[uint]("-1", strict) }, nil, "overflows"},
+	}
+	runConvertTests(t, tests)
+}
+
+func Test_E_Locale(t *testing.T) {
+	tests := []convertTest{
+		{"de", func() (interface{}, error) { return Float64E("1.234,56", Locale(LocaleDE)) }, 1234.56, ""},
+		{"en", func() (interface{}, error) { return Float64E("1,234.56", Locale(LocaleEN)) }, 1234.56, ""},
+		{"fr", func() (interface{}, error) { return Float64E("-1 234,5", Locale(LocaleFR)) }, -1234.5, ""},
+		{"int de", func() (interface{}, error) { return IntE("1.234.567", Locale(LocaleDE)) }, 1234567, ""},
+		{"bad grouping", func() (interface{}, error) { return Float64E("12,34.5", Locale(LocaleEN)) }, nil, "cannot convert"},
+		{"no locale", func() (interface{}, error) { return Float64E("1,234.56") }, nil, "cannot convert"},
+		{"float32 de", func() (interface{}, error) { return Float32E("1.234,5", Locale(LocaleDE)) }, float32(1234.5), ""},
+	}
+	runConvertTests(t, tests)
+
+	// The package-level locale is used if the option does not specify one.
+	SetNumberLocale(LocaleDE)
+	defer SetNumberLocale(NumberLocale{})
+	if v := Float64("1.234,5"); v != 1234.5 {
+		t.Errorf("expected 1234.5, got %v", v)
+	}
+	if v := Float32("1,5"); v != 1.5 {
+		t.Errorf("expected 1.5, got %v", v)
+	}
+	if v, err := Float64E("1,234.5", Locale(LocaleEN)); err != nil || v != 1234.5 {
+		t.Errorf("expected option locale to have priority, got %v, %v", v, err)
+	}
+}
+
+func Test_Bytes_Encoding(t *testing.T) {
+	tests := []convertTest{
+		{"raw", func() (interface{}, error) { return BytesE("aGVsbG8=") }, []byte("aGVsbG8="), ""},
+		{"base64", func() (interface{}, error) { return BytesE("aGVsbG8=", ENCODING_BASE64) }, []byte("hello"), ""},
+		{"base64 invalid", func() (interface{}, error) { return BytesE("aGVsbG8", ENCODING_BASE64) }, nil, "decoding"},
+		{"base64 url", func() (interface{}, error) { return BytesE("-_8=", ENCODING_BASE64_URL) }, []byte{0xfb, 0xff}, ""},
+		{"base64 raw url", func() (interface{}, error) { return BytesE("-_8", ENCODING_BASE64_RAW_URL) }, []byte{0xfb, 0xff}, ""},
+		{"hex", func() (interface{}, error) { return BytesE([]byte("68656c6c6f"), ENCODING_HEX) }, []byte("hello"), ""},
+		{"hex invalid", func() (interface{}, error) { return BytesE("6x", ENCODING_HEX) }, nil, "decoding"},
+		{"nil", func() (interface{}, error) { return BytesE(nil, ENCODING_HEX) }, []byte(nil), ""},
+		{"unsupported", func() (interface{}, error) { return BytesE("a", BytesEncoding(100)) }, nil, "unsupported"},
+	}
+	runConvertTests(t, tests)
+
+	// BytesWithEncoding returns nil if decoding fails, and StringWithEncoding encodes []byte.
+	if v := BytesWithEncoding("6x", ENCODING_HEX); v != nil {
+		t.Errorf("expected nil, got %v", v)
+	}
+	if v := BytesWithEncoding("aGVsbG8=", ENCODING_BASE64); string(v) != "hello" {
+		t.Errorf("expected hello, got %s", v)
+	}
+	b := []byte{0xfb, 0xff}
+	for encoding, expect := range map[BytesEncoding]string{
+		ENCODING_BASE64:         "+/8=",
+		ENCODING_BASE64_URL:     "-_8=",
+		ENCODING_BASE64_RAW_URL: "-_8",
+		ENCODING_HEX:            "fbff",
+	} {
+		if v := StringWithEncoding(b, encoding); v != expect {
+			t.Errorf("encoding %d: expected %s, got %s", encoding, expect, v)
+		}
+	}
+	// The string form of the other types is encoded.
+	if v := StringWithEncoding(123, ENCODING_HEX); v != "313233" {
+		t.Errorf("expected 313233, got %s", v)
+	}
+	if v := StringWithEncoding(nil, ENCODING_HEX); v != "" {
+		t.Errorf("expected empty, got %s", v)
+	}
+	// String and Bytes keep the func(interface{}) signatures.
+	var (
+		_ func(interface{}) string = String
+		_ func(interface{}) []byte = Bytes
+	)
+}
+
+func Test_OverflowMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		convert func() interface{}
+		expect  interface{}
+	}{
+		{"uint64 string", func() interface{} { return Uint64("99999999999999999999") }, uint64(math.MaxUint64)},
+		{"int64 string", func() interface{} { return Int64("-99999999999999999999") }, int64(math.MinInt64)},
+		{"int8", func() interface{} { return Int8(300) }, int8(math.MaxInt8)},
+		{"int8 negative", func() interface{} { return Int8(-300) }, int8(math.MinInt8)},
+		{"uint negative", func() interface{} { return Uint(-1) }, uint(0)},
+		{"uint8", func() interface{} { return Uint8(256) }, uint8(math.MaxUint8)},
+		{"int64 from uint64", func() interface{} { return Int64(uint64(math.MaxUint64)) }, int64(math.MaxInt64)},
+		{"int64 from float", func() interface{} { return Int64(1e30) }, int64(math.MaxInt64)},
+		{"uint64 from float", func() interface{} { return Uint64(-1.5) }, uint64(0)},
+	}
+	// It wraps by default.
+	if v := Int8(300); v != int8(44) {
+		t.Errorf("expected wrapped 44, got %v", v)
+	}
+	SetOverflowMode(OVERFLOW_SATURATE)
+	defer SetOverflowMode(OVERFLOW_WRAP)
+	for _, test := range tests {
+		if v := test.convert(); v != test.expect {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expect, v)
+		}
+	}
+	// The E functions always report the overflow.
+	if _, err := Int8E(300); err == nil {
+		t.Error("expected overflow error in saturating mode")
+	}
+}
+
+// convertTest is the test case of the error-returning converting functions,
+// which expects error containing errText if expect is nil.
+type convertTest struct {
+	name    string
+	convert func() (interface{}, error)
+	expect  interface{}
+	errText string
+}
+
+// runConvertTests runs the test cases <tests>.
+func runConvertTests(t *testing.T, tests []convertTest) {
+	t.Helper()
+	for _, test := range tests {
+		result, err := test.convert()
+		if test.expect == nil {
+			if err == nil || !strings.Contains(err.Error(), test.errText) {
+				t.Errorf("%s: expected error containing %q, got %v, %v", test.name, test.errText, result, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(result, test.expect) {
+			t.Errorf("%s: expected %#v, got %#v", test.name, test.expect, result)
+		}
+	}
+}
@@ -1,5 +1,9 @@
 package gconv
 
+import (
+	"database/sql/driver"
+)
+
 // apiString is used for type assert api for String().
 type apiString interface {
 	String() string
@@ -40,18 +44,64 @@ type apiMapStrAny interface {
 	MapStrAny() map[string]interface{}
 }
 
+// apiFromMap is the interface for struct converting from map without reflection,
+// which is usually implemented by the code generated by gconvgen.
+// Note that only pointer can implement interface apiFromMap.
+type apiFromMap interface {
+	FromMap(m map[string]interface{}) error
+}
+
+// PreConverter is the interface for struct which is called before the attribute mapping in
+// struct converting, which can be used for normalizing the params map, like renaming keys or
+// filling default values. The passed <params> is a copy and can be modified safely.
+// It is called only if the params is converted to map for the attribute mapping, that is,
+// it is not called if the params is JSON, the same type as the struct, or converted by the
+// interfaces like UnmarshalValue and sql.Scanner.
+// Note that only pointer can implement interface PreConverter.
+type PreConverter interface {
+	BeforeConvert(params map[string]interface{}) error
+}
+
+// PostConverter is the interface for struct which is called after any successful struct
+// converting, including the JSON, same type assignment, UnmarshalValue and sql.Scanner ones,
+// which can be used for validating or computing derived attributes.
+// Note that only pointer can implement interface PostConverter.
+type PostConverter interface {
+	AfterConvert() error
+}
+
 // apiUnmarshalValue is the interface for custom defined types customizing value assignment.
 // Note that only pointer can implement interface apiUnmarshalValue.
 type apiUnmarshalValue interface {
 	UnmarshalValue(interface{}) error
 }
 
-// apiUnmarshalText is the interface for custom defined types customizing value assignment.
+// apiUnmarshalText is the interface for custom defined types customizing value assignment,
+// which is the same as encoding.TextUnmarshaler.
 // Note that only pointer can implement interface apiUnmarshalText.
 type apiUnmarshalText interface {
 	UnmarshalText(text []byte) error
 }
 
+// apiMarshalText is the interface for custom defined types customizing text representation,
+// which is the same as encoding.TextMarshaler.
+type apiMarshalText interface {
+	MarshalText() (text []byte, err error)
+}
+
+// apiScan is the interface for custom defined types customizing value assignment,
+// which is the same as sql.Scanner.
+// Note that only pointer can implement interface apiScan.
+type apiScan interface {
+	Scan(src interface{}) error
+}
+
+// apiValue is the interface for custom defined types providing database driver value,
+// which is the same as driver.Valuer.
+type apiValue interface {
+	Value() (driver.Value, error)
+}
+
 // apiSet is the interface for custom value assignment.
 type apiSet interface {
 	Set(value interface{}) (old interface{})
@@ -0,0 +1,38 @@
+package gconv
+
+import (
+	"github.com/ilylx/gconv/internal/json"
+	"sync/atomic"
+)
+
+var (
+	// jsonUseNumber specifies whether decoding the JSON numbers as json.Number,
+	// which is 1 if enabled.
+	jsonUseNumber int32
+)
+
+// SetJsonUseNumber sets whether decoding the numbers of JSON <params> as json.Number instead of
+// float64 in the converting functions like Map/Maps/Struct, which keeps the precision of the
+// integers above 2^53, like the IDs. The json.Number values are converted exactly by the
+// numeric converting functions like Int64/Uint64.
+//
+// Eg:
+//
+//	gconv.SetJsonUseNumber(true)
+//	gconv.Map(`{"id":9007199254740993}`) // map[id:9007199254740993], which is json.Number
+func SetJsonUseNumber(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&jsonUseNumber, 1)
+	} else {
+		atomic.StoreInt32(&jsonUseNumber, 0)
+	}
+}
+
+// jsonUnmarshal decodes JSON <data> to <v>, which decodes the numbers as json.Number
+// if it's enabled by SetJsonUseNumber.
+func jsonUnmarshal(data []byte, v interface{}) error {
+	if atomic.LoadInt32(&jsonUseNumber) == 1 {
+		return json.UnmarshalUseNumber(data, v)
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,91 @@
+package gconv
+
+import (
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// NumberLocale specifies the separators for parsing numeric strings, like "1.234,56" or "1,234.56".
+// The zero NumberLocale means no locale, which parses the numeric strings as Go syntax.
+type NumberLocale struct {
+	Decimal   rune // Decimal separator, like '.' or ','.
+	Thousands rune // Thousands separator, like ',', '.' or ' ', or 0 if there's no thousands separator.
+}
+
+var (
+	// LocaleEN is the locale using '.' as decimal separator and ',' as thousands separator, like "1,234.56".
+	LocaleEN = NumberLocale{Decimal: '.', Thousands: ','}
+	// LocaleDE is the locale using ',' as decimal separator and '.' as thousands separator, like "1.234,56".
+	LocaleDE = NumberLocale{Decimal: ',', Thousands: '.'}
+	// LocaleFR is the locale using ',' as decimal separator and ' ' as thousands separator, like "1 234,56".
+	LocaleFR = NumberLocale{Decimal: ',', Thousands: ' '}
+
+	// defaultNumberLocale is the package-level NumberLocale, which stores *NumberLocale.
+	defaultNumberLocale atomic.Value
+)
+
+// SetNumberLocale sets the package-level NumberLocale for parsing numeric strings,
+// which is used by the numeric converting functions like Int/Float64 and IntE/Float64E.
+// The locale specified by ConvertOption of the E functions has priority over it.
+// Passing the zero NumberLocale resets it, which parses the numeric strings as Go syntax.
+//
+// Eg:
+//
+//	gconv.SetNumberLocale(gconv.LocaleDE)
+//	gconv.Float64("1.234,56") // 1234.56
+func SetNumberLocale(locale NumberLocale) {
+	defaultNumberLocale.Store(&locale)
+}
+
+// getNumberLocale returns the NumberLocale of <option>, or the package-level one if not specified.
+func getNumberLocale(option []ConvertOption) NumberLocale {
+	if len(option) > 0 && option[0].Locale.Decimal != 0 {
+		return option[0].Locale
+	}
+	if v, ok := defaultNumberLocale.Load().(*NumberLocale); ok {
+		return *v
+	}
+	return NumberLocale{}
+}
+
+// normalizeNumber converts numeric string <s> in the locale of <option> to Go syntax,
+// which removes the thousands separators and replaces the decimal separator with '.'.
+// It returns <s> unchanged if the thousands separators are not grouped by three digits.
+func normalizeNumber(s string, option ...ConvertOption) string {
+	locale := getNumberLocale(option)
+	if locale.Decimal == 0 || (locale.Decimal == '.' && locale.Thousands == 0) {
+		return s
+	}
+	var (
+		intPart  = s
+		fracPart = ""
+		hasFrac  = false
+	)
+	if index := strings.LastIndex(s, string(locale.Decimal)); index >= 0 {
+		intPart, fracPart, hasFrac = s[:index], s[index+utf8.RuneLen(locale.Decimal):], true
+	}
+	if locale.Thousands != 0 && strings.ContainsRune(intPart, locale.Thousands) {
+		var (
+			sign   = ""
+			groups []string
+		)
+		if len(intPart) > 0 && (intPart[0] == '-' || intPart[0] == '+') {
+			sign, intPart = intPart[:1], intPart[1:]
+		}
+		groups = strings.Split(intPart, string(locale.Thousands))
+		if len(groups[0]) == 0 || len(groups[0]) > 3 {
+			return s
+		}
+		for _, group := range groups[1:] {
+			if len(group) != 3 {
+				return s
+			}
+		}
+		intPart = sign + strings.Join(groups, "")
+	}
+	if hasFrac {
+		return intPart + "." + fracPart
+	}
+	return intPart
+}
@@ -3,8 +3,6 @@ package gconv
 import (
 	"github.com/ilylx/gconv/empty"
 	"github.com/ilylx/gconv/internal/gerror"
-	"github.com/ilylx/gconv/internal/json"
-	"github.com/ilylx/gconv/internal/utils"
 	"reflect"
 	"strings"
 )
@@ -16,22 +14,80 @@ import (
 // tags that will be detected, otherwise it detects the tags in order of:
 // gconv, json, field name.
 func Map(value interface{}, tags ...string) map[string]interface{} {
-	return doMapConvert(value, false, tags...)
+	return doMapConvert(value, mapConvertOption{}, tags...)
+}
+
+// MapOptions is the options for MapWithOptions.
+type MapOptions struct {
+	// Tags specifies the most priority tags that will be detected, see Map.
+	Tags []string
+
+	// Deep specifies whether converting the attributes recursively, see MapDeep.
+	Deep bool
+
+	// EmbeddedNested specifies whether keeping the attributes of embedded struct nested under
+	// the embedded type name, or else they are flattened into the parent map.
+	// Note that the embedded struct having tag is always nested under its tag name.
+	EmbeddedNested bool
+
+	// OmitEmpty specifies whether ignoring the struct attributes of empty value,
+	// like the attributes having json tag feature "omitempty".
+	OmitEmpty bool
+
+	// KeyCase specifies the naming convention of the map keys converted from struct attributes,
+	// which applies to both the tag names and attribute names, like KEY_CASE_SNAKE.
+	KeyCase KeyCase
+}
+
+// mapConvertOption is the internal option for map converting.
+type mapConvertOption struct {
+	Recursive      bool    // Whether converting the attributes recursively.
+	EmbeddedNested bool    // Whether keeping the attributes of embedded struct nested.
+	OmitEmpty      bool    // Whether ignoring the struct attributes of empty value.
+	KeyCase        KeyCase // Naming convention of the map keys converted from struct attributes.
+}
+
+// MapWithOptions converts any variable <value> to map[string]interface{} like Map,
+// but with custom converting behavior specified by <options>.
+//
+// Eg:
+//
+//	gconv.MapWithOptions(user, gconv.MapOptions{
+//		Tags:      []string{"json"},
+//		Deep:      true,
+//		OmitEmpty: true,
+//		KeyCase:   gconv.KEY_CASE_SNAKE,
+//	})
+func MapWithOptions(value interface{}, options MapOptions) map[string]interface{} {
+	return doMapConvert(value, mapConvertOption{
+		Recursive:      options.Deep,
+		EmbeddedNested: options.EmbeddedNested,
+		OmitEmpty:      options.OmitEmpty,
+		KeyCase:        options.KeyCase,
+	}, options.Tags...)
+}
+
+// deep returns the copy of <option> with recursive converting enabled.
+func (option mapConvertOption) deep() mapConvertOption {
+	option.Recursive = true
+	return option
 }
 
 // MapDeep does Map function recursively, which means if the attribute of <value>
 // is also a struct/*struct, calls Map function on this attribute converting it to
 // a map[string]interface{} type variable.
+// The attributes of slice/map type, like []struct, *[]struct and map[string][]struct, are also
+// converted recursively, of which the struct items are converted to []map[string]interface{}.
 // Also see Map.
 func MapDeep(value interface{}, tags ...string) map[string]interface{} {
-	return doMapConvert(value, true, tags...)
+	return doMapConvert(value, mapConvertOption{Recursive: true}, tags...)
 }
 
 // doMapConvert implements the map converting.
 // It automatically checks and converts json string to map if <value> is string/[]byte.
 //
 // TODO completely implement the recursive converting for all types, especially the map.
-func doMapConvert(value interface{}, recursive bool, tags ...string) map[string]interface{} {
+func doMapConvert(value interface{}, option mapConvertOption, tags ...string) map[string]interface{} {
 	if value == nil {
 		return nil
 	}
@@ -50,7 +106,7 @@ func doMapConvert(value interface{}, recursive bool, tags ...string) map[string]
 	case string:
 		// If it is a JSON string, automatically unmarshal it!
 		if len(r) > 0 && r[0] == '{' && r[len(r)-1] == '}' {
-			if err := json.Unmarshal([]byte(r), &dataMap); err != nil {
+			if err := jsonUnmarshal([]byte(r), &dataMap); err != nil {
 				return nil
 			}
 		} else {
@@ -59,7 +115,7 @@ func doMapConvert(value interface{}, recursive bool, tags ...string) map[string]
 	case []byte:
 		// If it is a JSON string, automatically unmarshal it!
 		if len(r) > 0 && r[0] == '{' && r[len(r)-1] == '}' {
-			if err := json.Unmarshal(r, &dataMap); err != nil {
+			if err := jsonUnmarshal(r, &dataMap); err != nil {
 				return nil
 			}
 		} else {
@@ -67,7 +123,7 @@ func doMapConvert(value interface{}, recursive bool, tags ...string) map[string]
 		}
 	case map[interface{}]interface{}:
 		for k, v := range r {
-			dataMap[String(k)] = doMapConvertForMapOrStructValue(false, v, recursive, newTags...)
+			dataMap[String(k)] = doMapConvertForMapOrStructValue(false, v, option, newTags...)
 		}
 	case map[interface{}]string:
 		for k, v := range r {
@@ -110,10 +166,10 @@ func doMapConvert(value interface{}, recursive bool, tags ...string) map[string]
 			dataMap[k] = v
 		}
 	case map[string]interface{}:
-		if recursive {
+		if option.Recursive {
 			// A copy of current map.
 			for k, v := range r {
-				dataMap[k] = doMapConvertForMapOrStructValue(false, v, recursive, newTags...)
+				dataMap[k] = doMapConvertForMapOrStructValue(false, v, option, newTags...)
 			}
 		} else {
 			// It returns the map directly without any changing.
@@ -121,7 +177,7 @@ func doMapConvert(value interface{}, recursive bool, tags ...string) map[string]
 		}
 	case map[int]interface{}:
 		for k, v := range r {
-			dataMap[String(k)] = doMapConvertForMapOrStructValue(false, v, recursive, newTags...)
+			dataMap[String(k)] = doMapConvertForMapOrStructValue(false, v, option, newTags...)
 		}
 	case map[int]string:
 		for k, v := range r {
@@ -161,7 +217,7 @@ func doMapConvert(value interface{}, recursive bool, tags ...string) map[string]
 				}
 			}
 		case reflect.Map, reflect.Struct:
-			convertedValue := doMapConvertForMapOrStructValue(true, value, recursive, newTags...)
+			convertedValue := doMapConvertForMapOrStructValue(true, value, option, newTags...)
 			if m, ok := convertedValue.(map[string]interface{}); ok {
 				return m
 			}
@@ -173,8 +229,8 @@ func doMapConvert(value interface{}, recursive bool, tags ...string) map[string]
 	return dataMap
 }
 
-func doMapConvertForMapOrStructValue(isRoot bool, value interface{}, recursive bool, tags ...string) interface{} {
-	if isRoot == false && recursive == false {
+func doMapConvertForMapOrStructValue(isRoot bool, value interface{}, option mapConvertOption, tags ...string) interface{} {
+	if isRoot == false && !option.Recursive {
 		return value
 	}
 	var reflectValue reflect.Value
@@ -184,6 +240,16 @@ func doMapConvertForMapOrStructValue(isRoot bool, value interface{}, recursive b
 	} else {
 		reflectValue = reflect.ValueOf(value)
 	}
+	if !isRoot {
+		// The attribute value implementing driver.Valuer is converted to its driver value.
+		if v, ok := driverValue(value); ok {
+			return v
+		}
+		// The attribute value implementing MarshalText is converted to its text.
+		if text, ok := marshalText(value); ok {
+			return text
+		}
+	}
 	reflectKind := reflectValue.Kind()
 	// If it is a pointer, we should find its real data type.
 	for reflectKind == reflect.Ptr {
@@ -200,7 +266,7 @@ func doMapConvertForMapOrStructValue(isRoot bool, value interface{}, recursive b
 			dataMap[String(k.Interface())] = doMapConvertForMapOrStructValue(
 				false,
 				reflectValue.MapIndex(k).Interface(),
-				recursive,
+				option,
 				tags...,
 			)
 		}
@@ -212,14 +278,25 @@ func doMapConvertForMapOrStructValue(isRoot bool, value interface{}, recursive b
 		// Map converting interface check.
 		if v, ok := value.(apiMapStrAny); ok {
 			m := v.MapStrAny()
-			if recursive {
+			if option.Recursive {
 				for k, v := range m {
-					m[k] = doMapConvertForMapOrStructValue(false, v, recursive, tags...)
+					m[k] = doMapConvertForMapOrStructValue(false, v, option, tags...)
+				}
+			}
+			if option.OmitEmpty || option.KeyCase != KEY_CASE_RAW {
+				newMap := make(map[string]interface{}, len(m))
+				for k, v := range m {
+					if option.OmitEmpty && empty.IsEmpty(v) {
+						continue
+					}
+					newMap[convertKeyCase(k, option.KeyCase)] = v
 				}
+				m = newMap
 			}
 			return m
 		}
 		// Using reflect for converting.
+		// The attribute descriptors of the struct type are cached for performance purpose.
 		var (
 			rtField     reflect.StructField
 			rvField     reflect.Value
@@ -227,44 +304,23 @@ func doMapConvertForMapOrStructValue(isRoot bool, value interface{}, recursive b
 			reflectType = reflectValue.Type()          // attribute value type.
 			name        = ""                           // name may be the tag name or the struct attribute name.
 		)
-		for i := 0; i < reflectValue.NumField(); i++ {
-			rtField = reflectType.Field(i)
-			rvField = reflectValue.Field(i)
-			// Only convert the public attributes.
-			fieldName := rtField.Name
-			if !utils.IsLetterUpper(fieldName[0]) {
+		for _, field := range getMapFields(reflectType, tags) {
+			rtField = reflectType.Field(field.index)
+			rvField = reflectValue.Field(field.index)
+			fieldName := field.fieldName
+			name = convertKeyCase(field.name, option.KeyCase)
+			// Support json tag feature: omitempty
+			if (field.omitempty || option.OmitEmpty) && empty.IsEmpty(rvField.Interface()) {
 				continue
 			}
-			name = ""
-			fieldTag := rtField.Tag
-			for _, tag := range tags {
-				if name = fieldTag.Get(tag); name != "" {
-					break
-				}
-			}
-			if name == "" {
-				name = fieldName
-			} else {
-				// Support json tag feature: -, omitempty
-				name = strings.TrimSpace(name)
-				if name == "-" {
+			// The database null types like sql.NullString are converted to their values.
+			if !rtField.Anonymous {
+				if v, ok := driverValue(rvField.Interface()); ok {
+					dataMap[name] = v
 					continue
 				}
-				array := strings.Split(name, ",")
-				if len(array) > 1 {
-					switch strings.TrimSpace(array[1]) {
-					case "omitempty":
-						if empty.IsEmpty(rvField.Interface()) {
-							continue
-						} else {
-							name = strings.TrimSpace(array[0])
-						}
-					default:
-						name = strings.TrimSpace(array[0])
-					}
-				}
 			}
-			if recursive || rtField.Anonymous {
+			if option.Recursive || rtField.Anonymous {
 				// Do map converting recursively.
 				var (
 					rvAttrField = rvField
@@ -277,43 +333,54 @@ func doMapConvertForMapOrStructValue(isRoot bool, value interface{}, recursive b
 				switch rvAttrKind {
 				case reflect.Struct:
 					var (
-						hasNoTag        = name == fieldName
+						hasNoTag        = field.name == fieldName
 						rvAttrInterface = rvAttrField.Interface()
 					)
-					if hasNoTag && rtField.Anonymous {
+					if hasNoTag && rtField.Anonymous && !option.EmbeddedNested {
 						// It means this attribute field has no tag.
 						// Overwrite the attribute with sub-struct attribute fields.
-						anonymousValue := doMapConvertForMapOrStructValue(false, rvAttrInterface, true, tags...)
+						anonymousValue := doMapConvertForMapOrStructValue(false, rvAttrInterface, option.deep(), tags...)
 						if m, ok := anonymousValue.(map[string]interface{}); ok {
 							for k, v := range m {
 								dataMap[k] = v
 							}
-						} else {
+						} else if !option.OmitEmpty {
 							dataMap[name] = rvAttrInterface
 						}
-					} else if !hasNoTag && rtField.Anonymous {
-						// It means this attribute field has desired tag.
-						dataMap[name] = doMapConvertForMapOrStructValue(false, rvAttrInterface, true, tags...)
+					} else if rtField.Anonymous {
+						// It means this attribute field has desired tag, or it's kept nested
+						// under the embedded type name.
+						dataMap[name] = doMapConvertForMapOrStructValue(false, rvAttrInterface, option.deep(), tags...)
+					} else if text, ok := marshalText(rvField.Interface()); ok && option.Recursive {
+						// The attribute value implementing MarshalText is converted to its text.
+						dataMap[name] = text
 					} else {
-						dataMap[name] = doMapConvertForMapOrStructValue(false, rvAttrInterface, false, tags...)
+						dataMap[name] = doMapConvertForMapOrStructValue(false, rvAttrInterface, option, tags...)
 					}
 
-				// The struct attribute is type of slice.
+				// The struct attribute is type of slice or pointer to slice.
 				case reflect.Array, reflect.Slice:
-					length := rvField.Len()
-					if length == 0 {
+					if text, ok := marshalText(rvField.Interface()); ok {
+						dataMap[name] = text
+						break
+					}
+					if rvAttrField.Len() == 0 {
 						dataMap[name] = rvField.Interface()
 						break
 					}
-					array := make([]interface{}, length)
-					for i := 0; i < length; i++ {
-						array[i] = doMapConvertForMapOrStructValue(false, rvField.Index(i), recursive, tags...)
+					dataMap[name] = doMapConvertForSliceValue(rvAttrField, option, tags...)
+
+				// The struct attribute is type of map, like map[string][]struct.
+				case reflect.Map:
+					if !option.Recursive || rvAttrField.IsNil() {
+						dataMap[name] = rvField.Interface()
+						break
 					}
-					dataMap[name] = array
+					dataMap[name] = doMapConvertForMapOrStructValue(false, rvAttrField, option, tags...)
 
 				default:
 					if rvField.IsValid() {
-						dataMap[name] = reflectValue.Field(i).Interface()
+						dataMap[name] = rvField.Interface()
 					} else {
 						dataMap[name] = nil
 					}
@@ -321,7 +388,7 @@ func doMapConvertForMapOrStructValue(isRoot bool, value interface{}, recursive b
 			} else {
 				// No recursive map value converting
 				if rvField.IsValid() {
-					dataMap[name] = reflectValue.Field(i).Interface()
+					dataMap[name] = rvField.Interface()
 				} else {
 					dataMap[name] = nil
 				}
@@ -334,19 +401,43 @@ func doMapConvertForMapOrStructValue(isRoot bool, value interface{}, recursive b
 
 	// The given value is type of slice.
 	case reflect.Array, reflect.Slice:
-		length := reflectValue.Len()
-		if length == 0 {
+		if reflectValue.Len() == 0 {
 			break
 		}
-		array := make([]interface{}, reflectValue.Len())
-		for i := 0; i < length; i++ {
-			array[i] = doMapConvertForMapOrStructValue(false, reflectValue.Index(i), recursive, tags...)
-		}
-		return array
+		return doMapConvertForSliceValue(reflectValue, option, tags...)
 	}
 	return value
 }
 
+// doMapConvertForSliceValue converts the items of slice/array <reflectValue> recursively.
+// The slice of struct/map, like []struct/[]*struct/[]map, is converted to []map[string]interface{}
+// if all its items are converted to map or nil, or else it's converted to []interface{}.
+func doMapConvertForSliceValue(reflectValue reflect.Value, option mapConvertOption, tags ...string) interface{} {
+	var (
+		length   = reflectValue.Len()
+		array    = make([]interface{}, length)
+		itemType = reflectValue.Type().Elem()
+	)
+	for itemType.Kind() == reflect.Ptr {
+		itemType = itemType.Elem()
+	}
+	for i := 0; i < length; i++ {
+		array[i] = doMapConvertForMapOrStructValue(false, reflectValue.Index(i), option, tags...)
+	}
+	if itemType.Kind() != reflect.Struct && itemType.Kind() != reflect.Map {
+		return array
+	}
+	maps := make([]map[string]interface{}, length)
+	for i, item := range array {
+		if m, ok := item.(map[string]interface{}); ok {
+			maps[i] = m
+		} else if !empty.IsNil(item) {
+			return array
+		}
+	}
+	return maps
+}
+
 // MapStrStr converts <value> to map[string]string.
 // Note that there might be data copy for this map type converting.
 func MapStrStr(value interface{}, tags ...string) map[string]string {
@@ -447,20 +538,27 @@ func doMapToMap(params interface{}, pointer interface{}, mapping ...map[string]s
 	}
 	for _, key := range paramsKeys {
 		e := reflect.New(pointerValueType).Elem()
-		switch pointerValueKind {
-		case reflect.Map, reflect.Struct:
-			if err = Struct(paramsRv.MapIndex(key).Interface(), e, mapping...); err != nil {
+		// The registered custom converter has priority.
+		if err, ok := bindVarToReflectValueWithConverter(e, paramsRv.MapIndex(key).Interface()); ok {
+			if err != nil {
 				return err
 			}
-		default:
-			e.Set(
-				reflect.ValueOf(
-					Convert(
-						paramsRv.MapIndex(key).Interface(),
-						pointerValueType.String(),
+		} else {
+			switch pointerValueKind {
+			case reflect.Map, reflect.Struct:
+				if err = Struct(paramsRv.MapIndex(key).Interface(), e, mapping...); err != nil {
+					return err
+				}
+			default:
+				e.Set(
+					reflect.ValueOf(
+						Convert(
+							paramsRv.MapIndex(key).Interface(),
+							pointerValueType.String(),
+						),
 					),
-				),
-			)
+				)
+			}
 		}
 		dataMap.SetMapIndex(
 			reflect.ValueOf(
@@ -487,13 +585,11 @@ func MapToMaps(params interface{}, pointer interface{}, mapping ...map[string]st
 // The parameter <params> can be any type of map, of which the item type is slice map, like:
 // map[int][]map, map[string][]map.
 //
-// The parameter <pointer> should be type of *map, of which the item type is slice map, like:
-// map[string][]struct, map[string][]*struct.
+// The parameter <pointer> should be type of *map, of which the item type is slice struct or
+// slice map, like: map[string][]struct, map[string][]*struct, map[int][]map, map[string][]map.
 //
 // The optional parameter <mapping> is used for struct attribute to map key mapping, which makes
 // sense only if the items of original map is type struct.
-//
-// TODO it's supposed supporting target type <pointer> like: map[int][]map, map[string][]map.
 func doMapToMaps(params interface{}, pointer interface{}, mapping ...map[string]string) (err error) {
 	var (
 		paramsRv   = reflect.ValueOf(params)
@@ -533,9 +629,15 @@ func doMapToMaps(params interface{}, pointer interface{}, mapping ...map[string]
 		pointerValueType = pointerRv.Type().Elem()
 		dataMap          = reflect.MakeMapWithSize(pointerRv.Type(), len(paramsKeys))
 	)
+	isMapItem := pointerValueType.Kind() == reflect.Slice && isMapType(pointerValueType.Elem())
 	for _, key := range paramsKeys {
 		e := reflect.New(pointerValueType).Elem()
-		if err = Structs(paramsRv.MapIndex(key).Interface(), e.Addr(), mapping...); err != nil {
+		if isMapItem {
+			err = doScanMaps(paramsRv.MapIndex(key).Interface(), e, mapping...)
+		} else {
+			err = Structs(paramsRv.MapIndex(key).Interface(), e.Addr(), mapping...)
+		}
+		if err != nil {
 			return err
 		}
 		dataMap.SetMapIndex(
@@ -0,0 +1,75 @@
+package gconv
+
+import (
+	"github.com/ilylx/gconv/internal/utils"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// KeyCase is the naming convention of the map keys converted from struct attributes.
+type KeyCase int
+
+const (
+	KEY_CASE_RAW             KeyCase = iota // The tag name or attribute name as it is, which is the default.
+	KEY_CASE_SNAKE                          // Like: user_name.
+	KEY_CASE_SNAKE_SCREAMING                // Like: USER_NAME.
+	KEY_CASE_KEBAB                          // Like: user-name.
+	KEY_CASE_CAMEL                          // Like: userName.
+	KEY_CASE_PASCAL                         // Like: UserName.
+)
+
+// keyCaseCacheKey is the key of keyCaseCache.
+type keyCaseCacheKey struct {
+	name    string
+	keyCase KeyCase
+}
+
+var (
+	// keyCaseCache caches the converted keys, which maps keyCaseCacheKey to string,
+	// as the keys of struct attributes are limited and converted repeatedly.
+	keyCaseCache = sync.Map{}
+)
+
+// convertKeyCase converts key <name> to naming convention <keyCase>.
+func convertKeyCase(name string, keyCase KeyCase) string {
+	if keyCase == KEY_CASE_RAW {
+		return name
+	}
+	cacheKey := keyCaseCacheKey{name: name, keyCase: keyCase}
+	if v, ok := keyCaseCache.Load(cacheKey); ok {
+		return v.(string)
+	}
+	words := utils.SplitCaseWords(name)
+	switch keyCase {
+	case KEY_CASE_SNAKE, KEY_CASE_KEBAB:
+		for i, word := range words {
+			words[i] = strings.ToLower(word)
+		}
+	case KEY_CASE_SNAKE_SCREAMING:
+		for i, word := range words {
+			words[i] = strings.ToUpper(word)
+		}
+	case KEY_CASE_CAMEL, KEY_CASE_PASCAL:
+		for i, word := range words {
+			if i == 0 && keyCase == KEY_CASE_CAMEL {
+				words[i] = strings.ToLower(word)
+				continue
+			}
+			r, size := utf8.DecodeRuneInString(word)
+			words[i] = string(unicode.ToUpper(r)) + word[size:]
+		}
+	}
+	var key string
+	switch keyCase {
+	case KEY_CASE_SNAKE, KEY_CASE_SNAKE_SCREAMING:
+		key = strings.Join(words, "_")
+	case KEY_CASE_KEBAB:
+		key = strings.Join(words, "-")
+	default:
+		key = strings.Join(words, "")
+	}
+	keyCaseCache.Store(cacheKey, key)
+	return key
+}
@@ -0,0 +1,114 @@
+package gconv
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"sync/atomic"
+)
+
+// OverflowMode specifies how the non-E integer converting functions like Int64/Uint8
+// handle the values out of the range of the target type.
+type OverflowMode int32
+
+const (
+	// OVERFLOW_WRAP truncates the out of range values like the Go conversion, which is the default mode.
+	OVERFLOW_WRAP OverflowMode = iota
+	// OVERFLOW_SATURATE clamps the out of range values to the minimum or maximum of the target type.
+	OVERFLOW_SATURATE
+)
+
+var (
+	// overflowMode is the package-level OverflowMode.
+	overflowMode int32
+)
+
+// SetOverflowMode sets the package-level OverflowMode for the non-E integer converting functions
+// like Int/Int64/Uint/Uint64, which is OVERFLOW_WRAP by default.
+// Use the E functions like Int64E/Uint64E to get error for the out of range values.
+//
+// Eg:
+//
+//	gconv.Uint64("99999999999999999999") // Truncated value.
+//	gconv.SetOverflowMode(gconv.OVERFLOW_SATURATE)
+//	gconv.Uint64("99999999999999999999") // 18446744073709551615
+//	gconv.Int8(300)                      // 127
+//	gconv.Uint(-1)                       // 0
+func SetOverflowMode(mode OverflowMode) {
+	atomic.StoreInt32(&overflowMode, int32(mode))
+}
+
+// isSaturating checks whether the package-level OverflowMode is OVERFLOW_SATURATE.
+func isSaturating() bool {
+	return atomic.LoadInt32(&overflowMode) == int32(OVERFLOW_SATURATE)
+}
+
+// isRangeError checks whether <err> is the out of range error of strconv parsing.
+func isRangeError(err error) bool {
+	return errors.Is(err, strconv.ErrRange)
+}
+
+// boundInt64 returns <v> clamped to [<min>, <max>] in saturating mode, or else <v> itself.
+func boundInt64(v, min, max int64) int64 {
+	if isSaturating() {
+		if v < min {
+			return min
+		}
+		if v > max {
+			return max
+		}
+	}
+	return v
+}
+
+// boundUint64 returns <v> clamped to <max> in saturating mode, or else <v> itself.
+func boundUint64(v, max uint64) uint64 {
+	if v > max && isSaturating() {
+		return max
+	}
+	return v
+}
+
+// uint64ToInt64 converts <v> to int64, which is clamped to math.MaxInt64 in saturating mode.
+func uint64ToInt64(v uint64) int64 {
+	if v > math.MaxInt64 && isSaturating() {
+		return math.MaxInt64
+	}
+	return int64(v)
+}
+
+// int64ToUint64 converts <v> to uint64, which is clamped to 0 in saturating mode.
+func int64ToUint64(v int64) uint64 {
+	if v < 0 && isSaturating() {
+		return 0
+	}
+	return uint64(v)
+}
+
+// float64ToInt64 converts <f> to int64, which is clamped to the int64 range in saturating mode.
+func float64ToInt64(f float64) int64 {
+	if isSaturating() {
+		switch {
+		case math.IsNaN(f):
+			return 0
+		case f >= math.MaxInt64:
+			return math.MaxInt64
+		case f <= math.MinInt64:
+			return math.MinInt64
+		}
+	}
+	return int64(f)
+}
+
+// float64ToUint64 converts <f> to uint64, which is clamped to the uint64 range in saturating mode.
+func float64ToUint64(f float64) uint64 {
+	if isSaturating() {
+		switch {
+		case math.IsNaN(f) || f <= 0:
+			return 0
+		case f >= math.MaxUint64:
+			return math.MaxUint64
+		}
+	}
+	return uint64(f)
+}
@@ -0,0 +1,45 @@
+package gconv
+
+import (
+	"reflect"
+)
+
+// Ptr returns the pointer to <v>, which is usually used for the pointer attributes of struct,
+// like *int and *string.
+//
+// Eg:
+//
+//	user := User{Age: gconv.Ptr(18)}
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// PtrOf returns the pointer to a copy of <value>, like *int for int value.
+// It returns nil if <value> is nil.
+func PtrOf(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(value)
+	pointer := reflect.New(rv.Type())
+	pointer.Elem().Set(rv)
+	return pointer.Interface()
+}
+
+// Deref returns the value pointed by <p>, or the optional default value <def> if <p> is nil,
+// which is the zero value of T if <def> is not given.
+//
+// Eg:
+//
+//	gconv.Deref(user.Age)     // 0 if user.Age is nil
+//	gconv.Deref(user.Age, 18) // 18 if user.Age is nil
+func Deref[T any](p *T, def ...T) T {
+	if p != nil {
+		return *p
+	}
+	if len(def) > 0 {
+		return def[0]
+	}
+	var zero T
+	return zero
+}
@@ -0,0 +1,101 @@
+package gconv
+
+import (
+	"encoding/json"
+	"github.com/ilylx/gconv/internal/gerror"
+	"reflect"
+)
+
+var (
+	// rawMessageType is the reflect type of json.RawMessage.
+	rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+	// bytesType is the reflect type of []byte.
+	bytesType = reflect.TypeOf([]byte(nil))
+)
+
+// rawMessagesToMap converts <params> of type map[string]json.RawMessage to map[string]interface{}
+// for struct converting, in which the values are kept as json.RawMessage without decoding.
+// The values are decoded lazily only for the matched attributes, see bindVarToStructAttrWithRawMessage.
+// It returns false if <params> is not type of map[string]json.RawMessage.
+func rawMessagesToMap(params interface{}) (map[string]interface{}, bool) {
+	messages, ok := params.(map[string]json.RawMessage)
+	if !ok {
+		rv, isReflectValue := params.(reflect.Value)
+		if !isReflectValue || !rv.IsValid() || rv.Type() != reflect.TypeOf(messages) {
+			return nil, false
+		}
+		messages = rv.Interface().(map[string]json.RawMessage)
+	}
+	m := make(map[string]interface{}, len(messages))
+	for k, v := range messages {
+		m[k] = v
+	}
+	return m, true
+}
+
+// bindVarToStructAttrWithRawMessage decodes <value> of type json.RawMessage to <structFieldValue>
+// directly, which avoids decoding the whole JSON payload. The attribute of type json.RawMessage
+// or []byte receives the raw JSON itself. If the JSON type does not match the attribute, like
+// string "1" for int attribute, it converts the generic decoded value as the common converting.
+// It returns false if <value> is not type of json.RawMessage.
+func bindVarToStructAttrWithRawMessage(structFieldValue reflect.Value, name string, value interface{}, options *StructOptions, mapping ...map[string]string) (err error, ok bool) {
+	raw, ok := value.(json.RawMessage)
+	if !ok {
+		return nil, false
+	}
+	fieldType := structFieldValue.Type()
+	if fieldType == rawMessageType || fieldType == bytesType {
+		structFieldValue.Set(reflect.ValueOf(raw).Convert(fieldType))
+		return nil, true
+	}
+	if len(raw) == 0 {
+		structFieldValue.Set(reflect.Zero(fieldType))
+		return nil, true
+	}
+	pointer := reflect.New(fieldType)
+	if err = jsonUnmarshal(raw, pointer.Interface()); err == nil {
+		structFieldValue.Set(pointer.Elem())
+		return nil, true
+	}
+	var decoded interface{}
+	if jsonUnmarshal(raw, &decoded) != nil {
+		return gerror.Wrapf(err, `error binding value to attribute "%s"`, name), true
+	}
+	return bindVarToStructAttr(structFieldValue, name, decoded, options, mapping...), true
+}
+
+// decodeRawMessages returns a copy of <paramsMap> with the json.RawMessage values decoded,
+// which is used for the reflection-free converting like FromMap that cannot decode lazily.
+// It returns <paramsMap> directly if it contains no json.RawMessage value.
+func decodeRawMessages(paramsMap map[string]interface{}) (map[string]interface{}, error) {
+	var decodedMap map[string]interface{}
+	for k, v := range paramsMap {
+		raw, ok := v.(json.RawMessage)
+		if !ok {
+			continue
+		}
+		if decodedMap == nil {
+			decodedMap = make(map[string]interface{}, len(paramsMap))
+			for k, v := range paramsMap {
+				decodedMap[k] = v
+			}
+		}
+		var decoded interface{}
+		if len(raw) > 0 {
+			if err := jsonUnmarshal(raw, &decoded); err != nil {
+				return nil, gerror.Wrapf(err, `error decoding value of key "%s"`, k)
+			}
+		}
+		decodedMap[k] = decoded
+	}
+	if decodedMap == nil {
+		return paramsMap, nil
+	}
+	return decodedMap, nil
+}
+
+// isNullRawMessage checks whether <value> is an empty or null json.RawMessage.
+func isNullRawMessage(value interface{}) bool {
+	raw, ok := value.(json.RawMessage)
+	return ok && (len(raw) == 0 || string(raw) == "null")
+}
@@ -2,6 +2,7 @@ package gconv
 
 import (
 	"github.com/ilylx/gconv/internal/gerror"
+	"github.com/ilylx/gconv/internal/json"
 	"reflect"
 )
 
@@ -9,16 +10,140 @@ import (
 // <pointer> to implement the converting.
 // It calls function Struct if <pointer> is type of *struct/**struct to do the converting.
 // It calls function Structs if <pointer> is type of *[]struct/*[]*struct to do the converting.
+// It calls function MapToMap if <pointer> is type of *map/**map to do the converting.
+// It calls function MapToMap for each item if <pointer> is type of *[]map/*[]*map to do the converting.
+// It converts <params> to the pointed type for any other type of <pointer>, like *int/*[]string.
 func Scan(params interface{}, pointer interface{}, mapping ...map[string]string) (err error) {
 	t := reflect.TypeOf(pointer)
 	k := t.Kind()
 	if k != reflect.Ptr {
 		return gerror.Newf("params should be type of pointer, but got: %v", k)
 	}
-	switch t.Elem().Kind() {
+	pointerRv := reflect.ValueOf(pointer)
+	if pointerRv.IsNil() {
+		return gerror.New("object pointer cannot be nil")
+	}
+	// Custom converter checks.
+	if err, ok := bindVarToReflectValueWithConverter(pointerRv.Elem(), params); ok {
+		return err
+	}
+	switch elemType := t.Elem(); elemType.Kind() {
 	case reflect.Array, reflect.Slice:
-		return Structs(params, pointer, mapping...)
-	default:
+		if isStructType(elemType.Elem()) {
+			return Structs(params, pointer, mapping...)
+		}
+		if elemType.Kind() == reflect.Slice && isMapType(elemType.Elem()) {
+			return doScanMaps(params, pointerRv.Elem(), mapping...)
+		}
+	}
+	if isMapType(t.Elem()) {
+		return doScanMap(params, pointerRv, mapping...)
+	}
+	if isStructType(t.Elem()) {
 		return Struct(params, pointer, mapping...)
 	}
+	if params == nil {
+		return nil
+	}
+	return doConvertToReflectValue(pointerRv.Elem(), params)
+}
+
+// isStructType checks whether <t> is type of struct or pointer to struct,
+// note that the time types are not considered as struct.
+func isStructType(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t != timeType && t != gtimeType
+}
+
+// isMapType checks whether <t> is type of map or pointer to map.
+func isMapType(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Map
+}
+
+// doScanMap converts <params> to the map pointed by <pointerRv> using MapToMap.
+// The parameter <params> which is not type of map, like struct or json string,
+// is converted to map[string]interface{} using MapDeep before the converting.
+func doScanMap(params interface{}, pointerRv reflect.Value, mapping ...map[string]string) error {
+	if params == nil {
+		return nil
+	}
+	paramsRv := reflect.ValueOf(params)
+	for paramsRv.Kind() == reflect.Ptr {
+		if paramsRv.IsNil() {
+			return nil
+		}
+		paramsRv = paramsRv.Elem()
+	}
+	if paramsRv.Kind() != reflect.Map {
+		paramsMap := MapDeep(params)
+		if paramsMap == nil {
+			return gerror.Newf("params should be type of map, but got: %v", paramsRv.Kind())
+		}
+		params = paramsMap
+	}
+	// Allocate the pointed map for type **map.
+	for pointerRv.Kind() == reflect.Ptr && pointerRv.Elem().Kind() == reflect.Ptr {
+		if pointerRv.Elem().IsNil() {
+			pointerRv.Elem().Set(reflect.New(pointerRv.Elem().Type().Elem()))
+		}
+		pointerRv = pointerRv.Elem()
+	}
+	return MapToMap(params, pointerRv, mapping...)
+}
+
+// doScanMaps converts <params> to the map slice <sliceRv> using MapToMap for each item.
+// The parameter <params> can be a slice of any map/struct type or a json string of map array.
+func doScanMaps(params interface{}, sliceRv reflect.Value, mapping ...map[string]string) error {
+	if params == nil {
+		return nil
+	}
+	// If given <params> is JSON, it then uses json.Unmarshal doing the converting.
+	switch r := params.(type) {
+	case []byte:
+		if json.Valid(r) {
+			return jsonUnmarshal(r, sliceRv.Addr().Interface())
+		}
+	case string:
+		if paramsBytes := []byte(r); json.Valid(paramsBytes) {
+			return jsonUnmarshal(paramsBytes, sliceRv.Addr().Interface())
+		}
+	}
+	paramsRv := reflect.ValueOf(params)
+	for paramsRv.Kind() == reflect.Ptr {
+		if paramsRv.IsNil() {
+			return nil
+		}
+		paramsRv = paramsRv.Elem()
+	}
+	var items []interface{}
+	switch paramsRv.Kind() {
+	case reflect.Slice, reflect.Array:
+		items = make([]interface{}, paramsRv.Len())
+		for i := 0; i < paramsRv.Len(); i++ {
+			items[i] = paramsRv.Index(i).Interface()
+		}
+	default:
+		items = []interface{}{params}
+	}
+	var (
+		itemType = sliceRv.Type().Elem()
+		array    = reflect.MakeSlice(sliceRv.Type(), len(items), len(items))
+	)
+	for i, item := range items {
+		itemRv := array.Index(i)
+		if itemType.Kind() == reflect.Ptr {
+			itemRv.Set(reflect.New(itemType.Elem()))
+			itemRv = itemRv.Elem()
+		}
+		if err := doScanMap(item, itemRv.Addr(), mapping...); err != nil {
+			return err
+		}
+	}
+	sliceRv.Set(array)
+	return nil
 }
@@ -0,0 +1,75 @@
+package gconv
+
+import (
+	"context"
+	"github.com/ilylx/gconv/internal/gerror"
+	"reflect"
+)
+
+const (
+	// ctxCheckInterval is the count of items converted between two cancellation checks.
+	ctxCheckInterval = 256
+)
+
+// ScanCtx does the converting like Scan, but it can be canceled by <ctx>.
+// If <pointer> is type of pointer to slice, the items of slice <params> are converted one by one,
+// and the cancellation of <ctx> is checked periodically, which returns the error wrapping
+// ctx.Err() with the index of the item being converted.
+// Note that the pointed slice is left unchanged if the converting is canceled.
+//
+// Eg:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	err := gconv.ScanCtx(ctx, rows, &users)
+func ScanCtx(ctx context.Context, params interface{}, pointer interface{}, mapping ...map[string]string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	pointerRv := reflect.ValueOf(pointer)
+	if pointerRv.Kind() != reflect.Ptr || pointerRv.IsNil() || pointerRv.Elem().Kind() != reflect.Slice {
+		return Scan(params, pointer, mapping...)
+	}
+	paramsRv := reflect.ValueOf(params)
+	for paramsRv.Kind() == reflect.Ptr && !paramsRv.IsNil() {
+		paramsRv = paramsRv.Elem()
+	}
+	sliceRv := pointerRv.Elem()
+	if paramsRv.Kind() != reflect.Slice && paramsRv.Kind() != reflect.Array {
+		return Scan(params, pointer, mapping...)
+	}
+	// The same type or custom converter does the converting as a whole.
+	if paramsRv.Type().AssignableTo(sliceRv.Type()) {
+		return Scan(params, pointer, mapping...)
+	}
+	if _, ok := getConverter(reflect.TypeOf(params), sliceRv.Type()); ok {
+		return Scan(params, pointer, mapping...)
+	}
+	var (
+		length   = paramsRv.Len()
+		itemType = sliceRv.Type().Elem()
+		array    = reflect.MakeSlice(sliceRv.Type(), length, length)
+	)
+	for i := 0; i < length; i++ {
+		if i%ctxCheckInterval == 0 {
+			select {
+			case <-ctx.Done():
+				return gerror.Wrapf(ctx.Err(), `converting canceled at index %d`, i)
+			default:
+			}
+		}
+		item := reflect.New(itemType)
+		if err := Scan(paramsRv.Index(i).Interface(), item.Interface(), mapping...); err != nil {
+			return gerror.Wrapf(err, `converting item at index %d failed`, i)
+		}
+		array.Index(i).Set(item.Elem())
+	}
+	sliceRv.Set(array)
+	return nil
+}
+
+// StructsCtx converts any slice to given struct slice like Structs, but it can be canceled by <ctx>.
+// See ScanCtx.
+func StructsCtx(ctx context.Context, params interface{}, pointer interface{}, mapping ...map[string]string) error {
+	return ScanCtx(ctx, params, pointer, mapping...)
+}
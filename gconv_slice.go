@@ -1,7 +1,5 @@
 package gconv
 
-import "github.com/ilylx/gconv/internal/json"
-
 // SliceMap is alias of Maps.
 func SliceMap(i interface{}) []map[string]interface{} {
 	return Maps(i)
@@ -27,7 +25,7 @@ func Maps(value interface{}, tags ...string) []map[string]interface{} {
 	case string:
 		list := make([]map[string]interface{}, 0)
 		if len(r) > 0 && r[0] == '[' && r[len(r)-1] == ']' {
-			if err := json.Unmarshal([]byte(r), &list); err != nil {
+			if err := jsonUnmarshal([]byte(r), &list); err != nil {
 				return nil
 			}
 			return list
@@ -38,7 +36,7 @@ func Maps(value interface{}, tags ...string) []map[string]interface{} {
 	case []byte:
 		list := make([]map[string]interface{}, 0)
 		if len(r) > 0 && r[0] == '[' && r[len(r)-1] == ']' {
-			if err := json.Unmarshal(r, &list); err != nil {
+			if err := jsonUnmarshal(r, &list); err != nil {
 				return nil
 			}
 			return list
@@ -73,7 +71,7 @@ func MapsDeep(value interface{}, tags ...string) []map[string]interface{} {
 	case string:
 		list := make([]map[string]interface{}, 0)
 		if len(r) > 0 && r[0] == '[' && r[len(r)-1] == ']' {
-			if err := json.Unmarshal([]byte(r), &list); err != nil {
+			if err := jsonUnmarshal([]byte(r), &list); err != nil {
 				return nil
 			}
 			return list
@@ -84,7 +82,7 @@ func MapsDeep(value interface{}, tags ...string) []map[string]interface{} {
 	case []byte:
 		list := make([]map[string]interface{}, 0)
 		if len(r) > 0 && r[0] == '[' && r[len(r)-1] == ']' {
-			if err := json.Unmarshal(r, &list); err != nil {
+			if err := jsonUnmarshal(r, &list); err != nil {
 				return nil
 			}
 			return list
@@ -0,0 +1,98 @@
+package gconv
+
+import (
+	"github.com/ilylx/gconv/internal/gerror"
+	"reflect"
+)
+
+// IntsE converts <i> to []int, it returns error naming the index of the first element
+// that cannot be converted. See IntE.
+func IntsE(i interface{}, option ...ConvertOption) ([]int, error) {
+	return doSliceE(i, IntE, option)
+}
+
+// Int32sE converts <i> to []int32, it returns error naming the index of the first element
+// that cannot be converted. See Int32E.
+func Int32sE(i interface{}, option ...ConvertOption) ([]int32, error) {
+	return doSliceE(i, Int32E, option)
+}
+
+// Int64sE converts <i> to []int64, it returns error naming the index of the first element
+// that cannot be converted. See Int64E.
+func Int64sE(i interface{}, option ...ConvertOption) ([]int64, error) {
+	return doSliceE(i, Int64E, option)
+}
+
+// UintsE converts <i> to []uint, it returns error naming the index of the first element
+// that cannot be converted. See UintE.
+func UintsE(i interface{}, option ...ConvertOption) ([]uint, error) {
+	return doSliceE(i, UintE, option)
+}
+
+// Uint32sE converts <i> to []uint32, it returns error naming the index of the first element
+// that cannot be converted. See Uint32E.
+func Uint32sE(i interface{}, option ...ConvertOption) ([]uint32, error) {
+	return doSliceE(i, Uint32E, option)
+}
+
+// Uint64sE converts <i> to []uint64, it returns error naming the index of the first element
+// that cannot be converted. See Uint64E.
+func Uint64sE(i interface{}, option ...ConvertOption) ([]uint64, error) {
+	return doSliceE(i, Uint64E, option)
+}
+
+// Float32sE converts <i> to []float32, it returns error naming the index of the first element
+// that cannot be converted. See Float32E.
+func Float32sE(i interface{}, option ...ConvertOption) ([]float32, error) {
+	return doSliceE(i, Float32E, option)
+}
+
+// Float64sE converts <i> to []float64, it returns error naming the index of the first element
+// that cannot be converted. See Float64E.
+func Float64sE(i interface{}, option ...ConvertOption) ([]float64, error) {
+	return doSliceE(i, Float64E, option)
+}
+
+// StringsE converts <i> to []string, it returns error naming the index of the first element
+// that cannot be converted. See StringE.
+func StringsE(i interface{}, option ...ConvertOption) ([]string, error) {
+	return doSliceE(i, StringE, option)
+}
+
+// doSliceE converts each element of <i> using <convert>, and wraps the error of the
+// first failed element with its index.
+// The <i> which is not slice or array is converted as a slice of single element.
+func doSliceE[T any](i interface{}, convert func(interface{}, ...ConvertOption) (T, error), option []ConvertOption) ([]T, error) {
+	if i == nil {
+		return nil, nil
+	}
+	if v, ok := i.([]T); ok {
+		return v, nil
+	}
+	var elements []interface{}
+	if v, ok := i.([]interface{}); ok {
+		elements = v
+	} else if v, ok := i.(apiInterfaces); ok {
+		elements = v.Interfaces()
+	} else {
+		rv := reflect.ValueOf(i)
+		switch rv.Kind() {
+		case reflect.Slice, reflect.Array:
+			elements = make([]interface{}, rv.Len())
+			for n := 0; n < len(elements); n++ {
+				elements[n] = rv.Index(n).Interface()
+			}
+		default:
+			elements = []interface{}{i}
+		}
+	}
+	array := make([]T, len(elements))
+	for k, v := range elements {
+		r, err := convert(v, option...)
+		if err != nil {
+			return nil, gerror.Wrapf(err, "converting element at index %d failed", k)
+		}
+		array[k] = r
+	}
+	return array, nil
+}
@@ -1,6 +1,8 @@
 package gconv
 
-import "reflect"
+import (
+	"reflect"
+)
 
 // SliceFloat is alias of Floats.
 func SliceFloat(i interface{}) []float64 {
@@ -32,56 +34,57 @@ func Float32s(i interface{}) []float32 {
 	case []string:
 		array = make([]float32, len(value))
 		for k, v := range value {
-			array[k] = Float32(v)
+			array[k] = float32(stringToFloat64(v))
 		}
 	case []int:
 		array = make([]float32, len(value))
 		for k, v := range value {
-			array[k] = Float32(v)
+			array[k] = float32(v)
 		}
 	case []int8:
 		array = make([]float32, len(value))
 		for k, v := range value {
-			array[k] = Float32(v)
+			array[k] = float32(v)
 		}
 	case []int16:
 		array = make([]float32, len(value))
 		for k, v := range value {
-			array[k] = Float32(v)
+			array[k] = float32(v)
 		}
 	case []int32:
 		array = make([]float32, len(value))
 		for k, v := range value {
-			array[k] = Float32(v)
+			array[k] = float32(v)
 		}
 	case []int64:
 		array = make([]float32, len(value))
 		for k, v := range value {
-			array[k] = Float32(v)
+			array[k] = float32(v)
 		}
 	case []uint:
-		for _, v := range value {
-			array = append(array, Float32(v))
+		array = make([]float32, len(value))
+		for k, v := range value {
+			array[k] = float32(v)
 		}
 	case []uint8:
 		array = make([]float32, len(value))
 		for k, v := range value {
-			array[k] = Float32(v)
+			array[k] = float32(v)
 		}
 	case []uint16:
 		array = make([]float32, len(value))
 		for k, v := range value {
-			array[k] = Float32(v)
+			array[k] = float32(v)
 		}
 	case []uint32:
 		array = make([]float32, len(value))
 		for k, v := range value {
-			array[k] = Float32(v)
+			array[k] = float32(v)
 		}
 	case []uint64:
 		array = make([]float32, len(value))
 		for k, v := range value {
-			array[k] = Float32(v)
+			array[k] = float32(v)
 		}
 	case []bool:
 		array = make([]float32, len(value))
@@ -93,7 +96,7 @@ func Float32s(i interface{}) []float32 {
 	case []float64:
 		array = make([]float32, len(value))
 		for k, v := range value {
-			array[k] = Float32(v)
+			array[k] = float32(v)
 		}
 	case []interface{}:
 		array = make([]float32, len(value))
@@ -133,56 +136,57 @@ func Float64s(i interface{}) []float64 {
 	case []string:
 		array = make([]float64, len(value))
 		for k, v := range value {
-			array[k] = Float64(v)
+			array[k] = stringToFloat64(v)
 		}
 	case []int:
 		array = make([]float64, len(value))
 		for k, v := range value {
-			array[k] = Float64(v)
+			array[k] = float64(v)
 		}
 	case []int8:
 		array = make([]float64, len(value))
 		for k, v := range value {
-			array[k] = Float64(v)
+			array[k] = float64(v)
 		}
 	case []int16:
 		array = make([]float64, len(value))
 		for k, v := range value {
-			array[k] = Float64(v)
+			array[k] = float64(v)
 		}
 	case []int32:
 		array = make([]float64, len(value))
 		for k, v := range value {
-			array[k] = Float64(v)
+			array[k] = float64(v)
 		}
 	case []int64:
 		array = make([]float64, len(value))
 		for k, v := range value {
-			array[k] = Float64(v)
+			array[k] = float64(v)
 		}
 	case []uint:
-		for _, v := range value {
-			array = append(array, Float64(v))
+		array = make([]float64, len(value))
+		for k, v := range value {
+			array[k] = float64(v)
 		}
 	case []uint8:
 		array = make([]float64, len(value))
 		for k, v := range value {
-			array[k] = Float64(v)
+			array[k] = float64(v)
 		}
 	case []uint16:
 		array = make([]float64, len(value))
 		for k, v := range value {
-			array[k] = Float64(v)
+			array[k] = float64(v)
 		}
 	case []uint32:
 		array = make([]float64, len(value))
 		for k, v := range value {
-			array[k] = Float64(v)
+			array[k] = float64(v)
 		}
 	case []uint64:
 		array = make([]float64, len(value))
 		for k, v := range value {
-			array[k] = Float64(v)
+			array[k] = float64(v)
 		}
 	case []bool:
 		array = make([]float64, len(value))
@@ -192,7 +196,7 @@ func Float64s(i interface{}) []float64 {
 	case []float32:
 		array = make([]float64, len(value))
 		for k, v := range value {
-			array[k] = Float64(v)
+			array[k] = float64(v)
 		}
 	case []float64:
 		array = value
@@ -1,6 +1,9 @@
 package gconv
 
-import "reflect"
+import (
+	"math"
+	"reflect"
+)
 
 // SliceInt is alias of Ints.
 func SliceInt(i interface{}) []int {
@@ -27,7 +30,7 @@ func Ints(i interface{}) []int {
 	case []string:
 		array = make([]int, len(value))
 		for k, v := range value {
-			array[k] = Int(v)
+			array[k] = int(boundInt64(stringToInt64(v), math.MinInt, math.MaxInt))
 		}
 	case []int:
 		array = value
@@ -88,12 +91,12 @@ func Ints(i interface{}) []int {
 	case []float32:
 		array = make([]int, len(value))
 		for k, v := range value {
-			array[k] = Int(v)
+			array[k] = int(boundInt64(float64ToInt64(float64(v)), math.MinInt, math.MaxInt))
 		}
 	case []float64:
 		array = make([]int, len(value))
 		for k, v := range value {
-			array[k] = Int(v)
+			array[k] = int(boundInt64(float64ToInt64(float64(v)), math.MinInt, math.MaxInt))
 		}
 	case []interface{}:
 		array = make([]int, len(value))
@@ -138,7 +141,7 @@ func Int32s(i interface{}) []int32 {
 	case []string:
 		array = make([]int32, len(value))
 		for k, v := range value {
-			array[k] = Int32(v)
+			array[k] = int32(boundInt64(stringToInt64(v), math.MinInt32, math.MaxInt32))
 		}
 	case []int:
 		array = make([]int32, len(value))
@@ -199,12 +202,12 @@ func Int32s(i interface{}) []int32 {
 	case []float32:
 		array = make([]int32, len(value))
 		for k, v := range value {
-			array[k] = Int32(v)
+			array[k] = int32(boundInt64(float64ToInt64(float64(v)), math.MinInt32, math.MaxInt32))
 		}
 	case []float64:
 		array = make([]int32, len(value))
 		for k, v := range value {
-			array[k] = Int32(v)
+			array[k] = int32(boundInt64(float64ToInt64(float64(v)), math.MinInt32, math.MaxInt32))
 		}
 	case []interface{}:
 		array = make([]int32, len(value))
@@ -238,7 +241,7 @@ func Int64s(i interface{}) []int64 {
 	case []string:
 		array = make([]int64, len(value))
 		for k, v := range value {
-			array[k] = Int64(v)
+			array[k] = stringToInt64(v)
 		}
 	case []int:
 		array = make([]int64, len(value))
@@ -299,12 +302,12 @@ func Int64s(i interface{}) []int64 {
 	case []float32:
 		array = make([]int64, len(value))
 		for k, v := range value {
-			array[k] = Int64(v)
+			array[k] = float64ToInt64(float64(v))
 		}
 	case []float64:
 		array = make([]int64, len(value))
 		for k, v := range value {
-			array[k] = Int64(v)
+			array[k] = float64ToInt64(float64(v))
 		}
 	case []interface{}:
 		array = make([]int64, len(value))
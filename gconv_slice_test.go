@@ -0,0 +1,170 @@
+package gconv
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var (
+	benchmarkInts    = make([]int, 1000)
+	benchmarkStrings = make([]string, 1000)
+	benchmarkFloats  = make([]float64, 1000)
+)
+
+func init() {
+	for i := range benchmarkInts {
+		benchmarkInts[i] = i * 1000
+		benchmarkStrings[i] = strconv.Itoa(i * 1000)
+		benchmarkFloats[i] = float64(i) * 1.5
+	}
+}
+
+func Test_ToE_Slices(t *testing.T) {
+	tests := []struct {
+		name    string
+		convert func() (interface{}, error)
+		expect  interface{}
+		errText string
+	}{
+		{"ints", func() (interface{}, error) { return ToE[[]int]([]string{"1", "2"}) }, []int{1, 2}, ""},
+		{"ints invalid", func() (interface{}, error) { return ToE[[]int]([]string{"1", "x"}) }, nil, "index 1"},
+		{"int32s invalid", func() (interface{}, error) { return ToE[[]int32]([]interface{}{1, "x"}) }, nil, "index 1"},
+		{"int64s invalid", func() (interface{}, error) { return ToE[[]int64]([]string{"x"}) }, nil, "index 0"},
+		{"uints negative", func() (interface{}, error) { return ToE[[]uint]([]int{1, -1}) }, nil, "index 1"},
+		{"uint32s invalid", func() (interface{}, error) { return ToE[[]uint32]([]string{"1", "x"}) }, nil, "index 1"},
+		{"uint64s", func() (interface{}, error) { return ToE[[]uint64]([]string{"1"}) }, []uint64{1}, ""},
+		{"float32s invalid", func() (interface{}, error) { return ToE[[]float32]([]string{"1.5", "x"}) }, nil, "index 1"},
+		{"float64s invalid", func() (interface{}, error) { return ToE[[]float64]([]string{"x"}) }, nil, "index 0"},
+		{"strings", func() (interface{}, error) { return ToE[[]string]([]int{1, 2}) }, []string{"1", "2"}, ""},
+		{"strict", func() (interface{}, error) { return ToE[[]int]([]float64{1.5}, ConvertOption{Strict: true}) }, nil, "index 0"},
+	}
+	for _, test := range tests {
+		result, err := test.convert()
+		if test.errText != "" {
+			if err == nil || !strings.Contains(err.Error(), test.errText) {
+				t.Errorf("%s: expected error containing %q, got %v", test.name, test.errText, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(result, test.expect) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expect, result)
+		}
+	}
+}
+
+func Test_Slices_ElementConverting(t *testing.T) {
+	var (
+		strs   = []string{"1", "-1", "1.5", "abc", "", "0x10", "99999999999999999999", "-99999999999999999999", "300"}
+		floats = []float64{1.5, -1.5, 300, -300, 1e30, -1e30, 70000}
+		ints   = []int64{1, -1, 300, -300, 70000, math.MaxInt64, math.MinInt64}
+	)
+	// The slice converting gets the same result as converting the elements one by one.
+	check := func(name string, values interface{}, elements []interface{}) {
+		tests := []struct {
+			slice   func(interface{}) interface{}
+			element func(interface{}) interface{}
+		}{
+			{func(i interface{}) interface{} { return Ints(i) }, func(i interface{}) interface{} { return Int(i) }},
+			{func(i interface{}) interface{} { return Int32s(i) }, func(i interface{}) interface{} { return Int32(i) }},
+			{func(i interface{}) interface{} { return Int64s(i) }, func(i interface{}) interface{} { return Int64(i) }},
+			{func(i interface{}) interface{} { return Uints(i) }, func(i interface{}) interface{} { return Uint(i) }},
+			{func(i interface{}) interface{} { return Uint32s(i) }, func(i interface{}) interface{} { return Uint32(i) }},
+			{func(i interface{}) interface{} { return Uint64s(i) }, func(i interface{}) interface{} { return Uint64(i) }},
+			{func(i interface{}) interface{} { return Float32s(i) }, func(i interface{}) interface{} { return Float32(i) }},
+			{func(i interface{}) interface{} { return Float64s(i) }, func(i interface{}) interface{} { return Float64(i) }},
+		}
+		for _, test := range tests {
+			result := reflect.ValueOf(test.slice(values))
+			if result.Len() != len(elements) {
+				t.Errorf("%s: expected %d elements, got %d", name, len(elements), result.Len())
+				continue
+			}
+			for k, v := range elements {
+				if expect := test.element(v); result.Index(k).Interface() != expect {
+					t.Errorf("%s: element %v expected %v, got %v", name, v, expect, result.Index(k).Interface())
+				}
+			}
+		}
+	}
+	for _, mode := range []OverflowMode{OVERFLOW_WRAP, OVERFLOW_SATURATE} {
+		SetOverflowMode(mode)
+		elements := make([]interface{}, 0, len(strs))
+		for _, v := range strs {
+			elements = append(elements, v)
+		}
+		check("strings", strs, elements)
+		elements, float32s := elements[:0], make([]float32, len(floats))
+		for k, v := range floats {
+			elements = append(elements, v)
+			float32s[k] = float32(v)
+		}
+		check("float64s", floats, elements)
+		elements = elements[:0]
+		for _, v := range float32s {
+			elements = append(elements, v)
+		}
+		check("float32s", float32s, elements)
+	}
+	SetOverflowMode(OVERFLOW_WRAP)
+	elements := make([]interface{}, 0, len(ints))
+	for _, v := range ints {
+		elements = append(elements, v)
+	}
+	check("int64s", ints, elements)
+
+	// The strings are parsed in the number locale.
+	SetNumberLocale(LocaleDE)
+	defer SetNumberLocale(NumberLocale{})
+	strs = []string{"1,5", "-1.234,5"}
+	elements = elements[:0]
+	for _, v := range strs {
+		elements = append(elements, v)
+	}
+	check("locale strings", strs, elements)
+	if v := Float32s(strs); !reflect.DeepEqual(v, []float32{1.5, -1234.5}) {
+		t.Errorf("expected [1.5 -1234.5], got %v", v)
+	}
+}
+
+func Benchmark_Ints_Strings(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Ints(benchmarkStrings)
+	}
+}
+
+func Benchmark_Int64s_Ints(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Int64s(benchmarkInts)
+	}
+}
+
+func Benchmark_Int64s_Floats(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Int64s(benchmarkFloats)
+	}
+}
+
+func Benchmark_Uint64s_Strings(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Uint64s(benchmarkStrings)
+	}
+}
+
+func Benchmark_Float64s_Strings(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Float64s(benchmarkStrings)
+	}
+}
+
+func Benchmark_Float64s_Ints(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Float64s(benchmarkInts)
+	}
+}
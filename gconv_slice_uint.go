@@ -1,6 +1,9 @@
 package gconv
 
-import "reflect"
+import (
+	"math"
+	"reflect"
+)
 
 // SliceUint is alias of Uints.
 func SliceUint(i interface{}) []uint {
@@ -28,7 +31,12 @@ func Uints(i interface{}) []uint {
 	case []string:
 		array = make([]uint, len(value))
 		for k, v := range value {
-			array[k] = Uint(v)
+			array[k] = uint(boundUint64(stringToUint64(v), math.MaxUint))
+		}
+	case []int:
+		array = make([]uint, len(value))
+		for k, v := range value {
+			array[k] = uint(v)
 		}
 	case []int8:
 		array = make([]uint, len(value))
@@ -84,12 +92,12 @@ func Uints(i interface{}) []uint {
 	case []float32:
 		array = make([]uint, len(value))
 		for k, v := range value {
-			array[k] = Uint(v)
+			array[k] = uint(boundUint64(float64ToUint64(float64(v)), math.MaxUint))
 		}
 	case []float64:
 		array = make([]uint, len(value))
 		for k, v := range value {
-			array[k] = Uint(v)
+			array[k] = uint(boundUint64(float64ToUint64(float64(v)), math.MaxUint))
 		}
 	case []interface{}:
 		array = make([]uint, len(value))
@@ -134,7 +142,12 @@ func Uint32s(i interface{}) []uint32 {
 	case []string:
 		array = make([]uint32, len(value))
 		for k, v := range value {
-			array[k] = Uint32(v)
+			array[k] = uint32(boundUint64(stringToUint64(v), math.MaxUint32))
+		}
+	case []int:
+		array = make([]uint32, len(value))
+		for k, v := range value {
+			array[k] = uint32(v)
 		}
 	case []int8:
 		array = make([]uint32, len(value))
@@ -190,12 +203,12 @@ func Uint32s(i interface{}) []uint32 {
 	case []float32:
 		array = make([]uint32, len(value))
 		for k, v := range value {
-			array[k] = Uint32(v)
+			array[k] = uint32(boundUint64(float64ToUint64(float64(v)), math.MaxUint32))
 		}
 	case []float64:
 		array = make([]uint32, len(value))
 		for k, v := range value {
-			array[k] = Uint32(v)
+			array[k] = uint32(boundUint64(float64ToUint64(float64(v)), math.MaxUint32))
 		}
 	case []interface{}:
 		array = make([]uint32, len(value))
@@ -240,7 +253,12 @@ func Uint64s(i interface{}) []uint64 {
 	case []string:
 		array = make([]uint64, len(value))
 		for k, v := range value {
-			array[k] = Uint64(v)
+			array[k] = stringToUint64(v)
+		}
+	case []int:
+		array = make([]uint64, len(value))
+		for k, v := range value {
+			array[k] = uint64(v)
 		}
 	case []int8:
 		array = make([]uint64, len(value))
@@ -296,12 +314,12 @@ func Uint64s(i interface{}) []uint64 {
 	case []float32:
 		array = make([]uint64, len(value))
 		for k, v := range value {
-			array[k] = Uint64(v)
+			array[k] = float64ToUint64(float64(v))
 		}
 	case []float64:
 		array = make([]uint64, len(value))
 		for k, v := range value {
-			array[k] = Uint64(v)
+			array[k] = float64ToUint64(float64(v))
 		}
 	case []interface{}:
 		array = make([]uint64, len(value))
@@ -0,0 +1,31 @@
+package gconv
+
+import (
+	"github.com/ilylx/gconv/internal/utils"
+	"strings"
+)
+
+// NormalizeKey returns the normalized <key> for matching the struct attribute names and tags,
+// which is lower case and without chars like '-'/'_'/'.'/' '. Two keys match each other in
+// struct converting if their normalized keys are the same.
+//
+// It is mainly used by the static converters generated by gconvgen, which implement the
+// reflection-free methods FromMap and MapStrAny that are used by Struct/Scan and Map.
+//
+// Eg:
+//
+//	gconv.NormalizeKey("User_Name") // username
+func NormalizeKey(key string) string {
+	// Fast path for the normalized key, which needs no allocation.
+	normalized := true
+	for i := 0; i < len(key); i++ {
+		if c := key[i]; !((c >= '0' && c <= '9') || (c >= 'a' && c <= 'z')) {
+			normalized = false
+			break
+		}
+	}
+	if normalized {
+		return key
+	}
+	return strings.ToLower(utils.RemoveSymbols(key))
+}
@@ -0,0 +1,38 @@
+package gconv
+
+// ConvertOption is the option for the error-returning converting functions like IntE/ToE.
+type ConvertOption struct {
+	// Strict enables the strict mode, which returns error instead of truncated value
+	// for the lossy converting, like: 3.7 to int, true to int, or 1<<53+1 to float64.
+	// Note that the invalid or overflowing converting, like: "abc" to int, or -1 to uint,
+	// always returns error no matter in strict mode or not.
+	Strict bool
+
+	// Locale specifies the separators for parsing numeric strings, like "1.234,56".
+	// The package-level locale set by SetNumberLocale is used if it's not specified.
+	Locale NumberLocale
+}
+
+// Strict returns the ConvertOption with strict mode enabled.
+//
+// Eg:
+//
+//	gconv.IntE("3.7", gconv.Strict())       // 0, error
+//	gconv.ToE[uint]("-1", gconv.Strict())   // 0, error
+func Strict() ConvertOption {
+	return ConvertOption{Strict: true}
+}
+
+// isStrict checks whether strict mode is enabled in given <option>.
+func isStrict(option []ConvertOption) bool {
+	return len(option) > 0 && option[0].Strict
+}
+
+// Locale returns the ConvertOption parsing numeric strings in <locale>.
+//
+// Eg:
+//
+//	gconv.Float64E("1.234,56", gconv.Locale(gconv.LocaleDE)) // 1234.56, nil
+func Locale(locale NumberLocale) ConvertOption {
+	return ConvertOption{Locale: locale}
+}
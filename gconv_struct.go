@@ -5,11 +5,9 @@ import (
 	"github.com/ilylx/gconv/empty"
 	"github.com/ilylx/gconv/internal/gerror"
 	"github.com/ilylx/gconv/internal/json"
-	"github.com/ilylx/gconv/internal/structs"
-	"github.com/ilylx/gconv/internal/utils"
 
 	"reflect"
-	"strings"
+	"strconv"
 )
 
 // Struct maps the params key-value pairs to the corresponding struct object's attributes.
@@ -18,6 +16,8 @@ import (
 //
 // Note:
 //  1. The <params> can be any type of map/struct, usually a map.
+//     The <params> of type url.Values/http.Header has its single values unwrapped from slices,
+//     so they can be converted to both the scalar and slice attributes.
 //  2. The <pointer> should be type of *struct/**struct, which is a pointer to struct object
 //     or struct pointer.
 //  3. Only the public attributes of struct object can be mapped.
@@ -25,12 +25,42 @@ import (
 //     It will automatically convert the first letter of the key to uppercase
 //     in mapping procedure to do the matching.
 //     It ignores the map key, if it does not match.
+//  5. If the struct implements PostConverter, its AfterConvert is called after any successful
+//     converting. If the struct implements PreConverter, its BeforeConvert is called before
+//     the attribute mapping, which is skipped if <params> is JSON, the same type as the struct,
+//     or converted by the interfaces like UnmarshalValue and sql.Scanner.
+//  6. The attribute with tag "default" or "d" is assigned with the tag value, if it's missing
+//     or empty in <params>, like: Age int `d:"18"`.
+//  7. The attribute with tag "valid" or "v" is validated after the attribute mapping, and it
+//     returns *ValidationError of all the failed attributes, like: Age int `v:"required|min:1"`.
+//     The builtin rules are: required, min, max, length, in, see RegisterValidRule.
 func Struct(params interface{}, pointer interface{}, mapping ...map[string]string) (err error) {
 	return doStruct(params, pointer, mapping...)
 }
 
+// StructWithOptions maps the params key-value pairs to the corresponding struct object's
+// attributes like Struct, but with custom matching behavior specified by <options>.
+// See Struct and StructOptions.
+//
+// Eg:
+//
+//	gconv.StructWithOptions(params, &user, gconv.StructOptions{
+//		Tags:          []string{"json", "gconv"},
+//		Deep:          true,
+//		CaseSensitive: true,
+//	})
+func StructWithOptions(params interface{}, pointer interface{}, options StructOptions, mapping ...map[string]string) (err error) {
+	return doStructWithOptions(params, pointer, &options, mapping...)
+}
+
 // doStruct is the core internal converting function for any data to struct.
 func doStruct(params interface{}, pointer interface{}, mapping ...map[string]string) (err error) {
+	return doStructWithOptions(params, pointer, nil, mapping...)
+}
+
+// doStructWithOptions converts any data to struct with given <options>,
+// it uses the default matching behavior if <options> is nil.
+func doStructWithOptions(params interface{}, pointer interface{}, options *StructOptions, mapping ...map[string]string) (err error) {
 	if params == nil {
 		// If <params> is nil, no conversion.
 		return nil
@@ -39,6 +69,12 @@ func doStruct(params interface{}, pointer interface{}, mapping ...map[string]str
 		return gerror.New("object pointer cannot be nil")
 	}
 
+	defer func() {
+		// The AfterConvert hook is called for all the successful converting paths.
+		if err == nil {
+			err = doAfterConvert(pointer)
+		}
+	}()
 	defer func() {
 		// Catch the panic, especially the reflect operation panics.
 		if e := recover(); e != nil {
@@ -52,20 +88,20 @@ func doStruct(params interface{}, pointer interface{}, mapping ...map[string]str
 		if json.Valid(r) {
 			if rv, ok := pointer.(reflect.Value); ok {
 				if rv.Kind() == reflect.Ptr {
-					return json.Unmarshal(r, rv.Interface())
+					return jsonUnmarshal(r, rv.Interface())
 				}
 			} else {
-				return json.Unmarshal(r, pointer)
+				return jsonUnmarshal(r, pointer)
 			}
 		}
 	case string:
 		if paramsBytes := []byte(r); json.Valid(paramsBytes) {
 			if rv, ok := pointer.(reflect.Value); ok {
 				if rv.Kind() == reflect.Ptr {
-					return json.Unmarshal(paramsBytes, rv.Interface())
+					return jsonUnmarshal(paramsBytes, rv.Interface())
 				}
 			} else {
-				return json.Unmarshal(paramsBytes, pointer)
+				return jsonUnmarshal(paramsBytes, pointer)
 			}
 		}
 	}
@@ -110,6 +146,14 @@ func doStruct(params interface{}, pointer interface{}, mapping ...map[string]str
 		return v.UnmarshalValue(params)
 	}
 
+	// Scan.
+	// Assign value with interface sql.Scanner, like the database null types.
+	if v, ok := pointerReflectValue.Interface().(apiScan); ok {
+		if !paramsReflectValue.Type().AssignableTo(pointerElemReflectValue.Type()) {
+			return v.Scan(getScanValue(paramsReflectValue.Interface()))
+		}
+	}
+
 	// It automatically creates struct object if necessary.
 	// For example, if <pointer> is **User, then <elem> is *User, which is a pointer to User.
 	if pointerElemReflectValue.Kind() == reflect.Ptr {
@@ -125,69 +169,123 @@ func doStruct(params interface{}, pointer interface{}, mapping ...map[string]str
 	}
 
 	// paramsMap is the map[string]interface{} type variable for params.
-	// DO NOT use MapDeep here.
-	paramsMap := Map(params)
+	// DO NOT use MapDeep here unless it's specified by options.
+	// The url.Values and http.Header have the single values unwrapped from slices.
+	// The map[string]json.RawMessage has the values decoded lazily for the matched attributes.
+	paramsMap, ok := valuesToMap(params)
+	if !ok {
+		paramsMap, ok = rawMessagesToMap(params)
+	}
+	if !ok {
+		if options != nil && options.Deep {
+			paramsMap = MapDeep(params)
+		} else {
+			paramsMap = Map(params)
+		}
+	}
 	if paramsMap == nil {
 		return gerror.Newf("convert params to map failed: %v", params)
 	}
 
-	// It only performs one converting to the same attribute.
-	// doneMap is used to check repeated converting, its key is the real attribute name
-	// of the struct.
-	doneMap := make(map[string]struct{})
+	// The attribute descriptor of the struct type is cached for performance purpose.
+	info, err := getStructInfo(pointerElemReflectValue.Type(), options)
+	if err != nil {
+		return err
+	}
 
-	// The key of the attrMap is the attribute name of the struct,
-	// and the value is its replaced name for later comparison to improve performance.
-	var (
-		tempName       string
-		elemFieldType  reflect.StructField
-		elemFieldValue reflect.Value
-		elemType       = pointerElemReflectValue.Type()
-		attrMap        = make(map[string]string)
-	)
-	for i := 0; i < pointerElemReflectValue.NumField(); i++ {
-		elemFieldType = elemType.Field(i)
-		// Only do converting to public attributes.
-		if !utils.IsLetterUpper(elemFieldType.Name[0]) {
-			continue
+	// BeforeConvert hook of the struct, the AfterConvert hook is called in the deferred function.
+	// Note that only pointer can implement interface PreConverter.
+	var pointerInterface interface{}
+	if pointerElemReflectValue.CanAddr() {
+		pointerInterface = pointerElemReflectValue.Addr().Interface()
+	}
+	if v, ok := pointerInterface.(PreConverter); ok {
+		// It copies the map as <paramsMap> may be the one passed by caller.
+		newParamsMap := make(map[string]interface{}, len(paramsMap))
+		for k, value := range paramsMap {
+			newParamsMap[k] = value
 		}
-		// Maybe it's struct/*struct embedded.
-		if elemFieldType.Anonymous {
-			elemFieldValue = pointerElemReflectValue.Field(i)
-			// Ignore the interface attribute if it's nil.
-			if elemFieldValue.Kind() == reflect.Interface {
-				elemFieldValue = elemFieldValue.Elem()
-				if !elemFieldValue.IsValid() {
-					continue
-				}
-			}
-			if err = doStruct(paramsMap, elemFieldValue, mapping...); err != nil {
-				return err
-			}
-		} else {
-			tempName = elemFieldType.Name
-			attrMap[tempName] = utils.RemoveSymbols(tempName)
+		paramsMap = newParamsMap
+		if err = v.BeforeConvert(paramsMap); err != nil {
+			return err
 		}
 	}
-	if len(attrMap) == 0 {
+	// Default values from tag for the missing or empty attributes.
+	paramsMap = info.withDefaults(paramsMap, options, mapping...)
+	if err = doStructWithParamsMap(paramsMap, pointerElemReflectValue, info, options, mapping...); err != nil {
+		return err
+	}
+	// Validation with rules from tag.
+	return info.validate(pointerElemReflectValue)
+}
+
+// doAfterConvert calls AfterConvert of the struct if it implements PostConverter,
+// in which <pointer> can be *struct/**struct, or the reflect.Value of them or of the struct.
+func doAfterConvert(pointer interface{}) error {
+	rv, ok := pointer.(reflect.Value)
+	if !ok {
+		rv = reflect.ValueOf(pointer)
+	}
+	if rv.Kind() != reflect.Ptr {
+		if !rv.CanAddr() {
+			return nil
+		}
+		rv = rv.Addr()
+	}
+	// Retrieving *struct from **struct.
+	for !rv.IsNil() && rv.Elem().Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.IsNil() {
 		return nil
 	}
+	if v, ok := rv.Interface().(PostConverter); ok {
+		return v.AfterConvert()
+	}
+	return nil
+}
 
-	// The key of the tagMap is the attribute name of the struct,
-	// and the value is its replaced tag name for later comparison to improve performance.
-	tagMap := make(map[string]string)
-	tagToNameMap, err := structs.TagMapName(pointerElemReflectValue, StructTagPriority)
-	if err != nil {
-		return err
+// doStructWithParamsMap converts <paramsMap> to the attributes of struct <pointerElemReflectValue>,
+// of which the attribute descriptor is <info>.
+func doStructWithParamsMap(paramsMap map[string]interface{}, pointerElemReflectValue reflect.Value, info *structInfo, options *StructOptions, mapping ...map[string]string) (err error) {
+	// FromMap.
+	// The reflection-free converting, which is usually generated by gconvgen.
+	// It is used only if no custom matching rules are given.
+	if options == nil && (len(mapping) == 0 || len(mapping[0]) == 0) && pointerElemReflectValue.CanAddr() {
+		if v, ok := pointerElemReflectValue.Addr().Interface().(apiFromMap); ok {
+			// The json.RawMessage values are decoded as FromMap expects the decoded values.
+			if paramsMap, err = decodeRawMessages(paramsMap); err != nil {
+				return err
+			}
+			return v.FromMap(paramsMap)
+		}
+	}
+
+	// Maybe it's struct/*struct embedded.
+	var elemFieldValue reflect.Value
+	for _, index := range info.embeddedIndexes {
+		elemFieldValue = pointerElemReflectValue.Field(index)
+		// Ignore the interface attribute if it's nil.
+		if elemFieldValue.Kind() == reflect.Interface {
+			elemFieldValue = elemFieldValue.Elem()
+			if !elemFieldValue.IsValid() {
+				continue
+			}
+		}
+		if err = doStructWithOptions(paramsMap, elemFieldValue, options, mapping...); err != nil {
+			return err
+		}
 	}
-	for k, v := range tagToNameMap {
-		tagMap[v] = utils.RemoveSymbols(k)
+	if info.attrCount == 0 {
+		return nil
 	}
 
-	var (
-		attrName  string
-		checkName string
-	)
+	// It only performs one converting to the same attribute.
+	// doneMap is used to check repeated converting, its key is the real attribute name
+	// of the struct.
+	doneMap := make(map[string]struct{})
+
+	var attrName string
 	for mapK, mapV := range paramsMap {
 		attrName = ""
 		// It firstly checks the passed mapping rules.
@@ -196,34 +294,15 @@ func doStruct(params interface{}, pointer interface{}, mapping ...map[string]str
 				attrName = passedAttrKey
 			}
 		}
-		// It secondly checks the predefined tags and matching rules.
+		// It secondly checks the predefined tags and matching rules,
+		// with or without string cases and chars like '-'/'_'/'.'/' '.
+		// Eg:
+		// UserName  eq user_name
+		// User-Name eq username
+		// username  eq userName
+		// etc.
 		if attrName == "" {
-			checkName = utils.RemoveSymbols(mapK)
-			// Loop to find the matched attribute name with or without
-			// string cases and chars like '-'/'_'/'.'/' '.
-
-			// Matching the parameters to struct tag names.
-			// The <tagV> is the attribute name of the struct.
-			for attrKey, cmpKey := range tagMap {
-				if strings.EqualFold(checkName, cmpKey) {
-					attrName = attrKey
-					break
-				}
-			}
-			// Matching the parameters to struct attributes.
-			if attrName == "" {
-				for attrKey, cmpKey := range attrMap {
-					// Eg:
-					// UserName  eq user_name
-					// User-Name eq username
-					// username  eq userName
-					// etc.
-					if strings.EqualFold(checkName, cmpKey) {
-						attrName = attrKey
-						break
-					}
-				}
-			}
+			attrName = info.getAttrName(options.getCompareName(mapK))
 		}
 
 		// No matching, it gives up this attribute converting.
@@ -236,16 +315,27 @@ func doStruct(params interface{}, pointer interface{}, mapping ...map[string]str
 		}
 		// Mark it done.
 		doneMap[attrName] = struct{}{}
-		if err := bindVarToStructAttr(pointerElemReflectValue, attrName, mapV, mapping...); err != nil {
+		fieldValue := info.getFieldValue(pointerElemReflectValue, attrName)
+		if options != nil && options.EmptyAsNil && mapV == "" && fieldValue.Kind() == reflect.Ptr {
+			mapV = nil
+		}
+		if err := bindVarToStructAttr(fieldValue, attrName, mapV, options.getNestedOptions(), mapping...); err != nil {
+			// The fields of the nested validation error are prefixed with the attribute.
+			if validErr, ok := err.(*ValidationError); ok {
+				if field, ok := pointerElemReflectValue.Type().FieldByName(attrName); ok {
+					return validErr.withParent(getFieldKeyName(field))
+				}
+			}
 			return err
 		}
 	}
 	return nil
 }
 
-// bindVarToStructAttr sets value to struct object attribute by name.
-func bindVarToStructAttr(elem reflect.Value, name string, value interface{}, mapping ...map[string]string) (err error) {
-	structFieldValue := elem.FieldByName(name)
+// bindVarToStructAttr sets value to struct object attribute <structFieldValue>,
+// the parameter <name> is the attribute name for error message.
+// The parameter <options> is used for converting the nested struct attribute, which can be nil.
+func bindVarToStructAttr(structFieldValue reflect.Value, name string, value interface{}, options *StructOptions, mapping ...map[string]string) (err error) {
 	if !structFieldValue.IsValid() {
 		return nil
 	}
@@ -255,11 +345,17 @@ func bindVarToStructAttr(elem reflect.Value, name string, value interface{}, map
 	}
 	defer func() {
 		if e := recover(); e != nil {
-			if err = bindVarToReflectValue(structFieldValue, value, mapping...); err != nil {
-				err = gerror.Wrapf(err, `error binding value to attribute "%s"`, name)
+			if err = bindVarToReflectValueWithOptions(structFieldValue, value, options, mapping...); err != nil {
+				if _, ok := err.(*ValidationError); !ok {
+					err = gerror.Wrapf(err, `error binding value to attribute "%s"`, name)
+				}
 			}
 		}
 	}()
+	// Decoding the raw JSON directly to the attribute.
+	if err, ok := bindVarToStructAttrWithRawMessage(structFieldValue, name, value, options, mapping...); ok {
+		return err
+	}
 	// Directly converting.
 	if empty.IsNil(value) {
 		structFieldValue.Set(reflect.Zero(structFieldValue.Type()))
@@ -276,6 +372,11 @@ func bindVarToReflectValueWithInterfaceCheck(structFieldValue reflect.Value, val
 		if v, ok := pointer.(apiUnmarshalValue); ok {
 			return v.UnmarshalValue(value), ok
 		}
+		if v, ok := pointer.(apiScan); ok {
+			if value == nil || !reflect.TypeOf(value).AssignableTo(structFieldValue.Type()) {
+				return v.Scan(getScanValue(value)), ok
+			}
+		}
 		if v, ok := pointer.(apiUnmarshalText); ok {
 			if s, ok := value.(string); ok {
 				return v.UnmarshalText([]byte(s)), ok
@@ -283,6 +384,9 @@ func bindVarToReflectValueWithInterfaceCheck(structFieldValue reflect.Value, val
 			if b, ok := value.([]byte); ok {
 				return v.UnmarshalText(b), ok
 			}
+			if text, ok := getUnmarshalText(structFieldValue.Type(), value); ok {
+				return v.UnmarshalText([]byte(text)), ok
+			}
 		}
 		if v, ok := pointer.(apiSet); ok {
 			v.Set(value)
@@ -292,8 +396,49 @@ func bindVarToReflectValueWithInterfaceCheck(structFieldValue reflect.Value, val
 	return nil, false
 }
 
+// getScanValue returns the value of <value> for Scan of sql.Scanner,
+// which retrieves the driver value if <value> implements driver.Valuer.
+func getScanValue(value interface{}) interface{} {
+	if v, ok := driverValue(value); ok {
+		return v
+	}
+	return value
+}
+
+// getUnmarshalText returns the text of <value> for UnmarshalText of type <fieldType>.
+// It returns false if <value> is assignable to <fieldType>, or it cannot be represented as text.
+func getUnmarshalText(fieldType reflect.Type, value interface{}) (text string, ok bool) {
+	if value == nil {
+		return "", false
+	}
+	valueType := reflect.TypeOf(value)
+	if valueType.AssignableTo(fieldType) {
+		return "", false
+	}
+	if text, ok = marshalText(value); ok {
+		return text, ok
+	}
+	switch valueType.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return String(value), true
+	}
+	return "", false
+}
+
 // bindVarToReflectValue sets <value> to reflect value object <structFieldValue>.
 func bindVarToReflectValue(structFieldValue reflect.Value, value interface{}, mapping ...map[string]string) (err error) {
+	return bindVarToReflectValueWithOptions(structFieldValue, value, nil, mapping...)
+}
+
+// bindVarToReflectValueWithOptions sets <value> to reflect value object <structFieldValue>,
+// the parameter <options> is used for converting the struct value, which can be nil.
+func bindVarToReflectValueWithOptions(structFieldValue reflect.Value, value interface{}, options *StructOptions, mapping ...map[string]string) (err error) {
+	if err, ok := bindVarToReflectValueWithConverter(structFieldValue, value); ok {
+		return err
+	}
 	if err, ok := bindVarToReflectValueWithInterfaceCheck(structFieldValue, value); ok {
 		return err
 	}
@@ -313,7 +458,11 @@ func bindVarToReflectValue(structFieldValue reflect.Value, value interface{}, ma
 	switch kind {
 	case reflect.Struct:
 		// Recursively converting for struct attribute.
-		if err := doStruct(value, structFieldValue); err != nil {
+		if err := doStructWithOptions(value, structFieldValue, options); err != nil {
+			// The validation error of the nested struct is returned directly.
+			if validErr, ok := err.(*ValidationError); ok {
+				return validErr
+			}
 			// Note there's reflect conversion mechanism here.
 			structFieldValue.Set(reflect.ValueOf(value).Convert(structFieldValue.Type()))
 		}
@@ -330,14 +479,20 @@ func bindVarToReflectValue(structFieldValue reflect.Value, value interface{}, ma
 				for i := 0; i < v.Len(); i++ {
 					if t.Kind() == reflect.Ptr {
 						e := reflect.New(t.Elem()).Elem()
-						if err := doStruct(v.Index(i).Interface(), e); err != nil {
+						if err := doStructWithOptions(v.Index(i).Interface(), e, options); err != nil {
+							if validErr, ok := err.(*ValidationError); ok {
+								return validErr.withParent(strconv.Itoa(i))
+							}
 							// Note there's reflect conversion mechanism here.
 							e.Set(reflect.ValueOf(v.Index(i).Interface()).Convert(t))
 						}
 						a.Index(i).Set(e.Addr())
 					} else {
 						e := reflect.New(t).Elem()
-						if err := doStruct(v.Index(i).Interface(), e); err != nil {
+						if err := doStructWithOptions(v.Index(i).Interface(), e, options); err != nil {
+							if validErr, ok := err.(*ValidationError); ok {
+								return validErr.withParent(strconv.Itoa(i))
+							}
 							// Note there's reflect conversion mechanism here.
 							e.Set(reflect.ValueOf(v.Index(i).Interface()).Convert(t))
 						}
@@ -350,14 +505,14 @@ func bindVarToReflectValue(structFieldValue reflect.Value, value interface{}, ma
 			t := a.Index(0).Type()
 			if t.Kind() == reflect.Ptr {
 				e := reflect.New(t.Elem()).Elem()
-				if err := doStruct(value, e); err != nil {
+				if err := doStructWithOptions(value, e, options); err != nil {
 					// Note there's reflect conversion mechanism here.
 					e.Set(reflect.ValueOf(value).Convert(t))
 				}
 				a.Index(0).Set(e.Addr())
 			} else {
 				e := reflect.New(t).Elem()
-				if err := doStruct(value, e); err != nil {
+				if err := doStructWithOptions(value, e, options); err != nil {
 					// Note there's reflect conversion mechanism here.
 					e.Set(reflect.ValueOf(value).Convert(t))
 				}
@@ -373,9 +528,10 @@ func bindVarToReflectValue(structFieldValue reflect.Value, value interface{}, ma
 			return err
 		}
 		elem := item.Elem()
-		if err = bindVarToReflectValue(elem, value, mapping...); err == nil {
-			structFieldValue.Set(elem.Addr())
+		if err = bindVarToReflectValueWithOptions(elem, value, options, mapping...); err != nil {
+			return err
 		}
+		structFieldValue.Set(elem.Addr())
 
 	// It mainly and specially handles the interface of nil value.
 	case reflect.Interface:
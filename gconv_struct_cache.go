@@ -0,0 +1,270 @@
+package gconv
+
+import (
+	"github.com/ilylx/gconv/empty"
+	"github.com/ilylx/gconv/internal/structs"
+	"github.com/ilylx/gconv/internal/utils"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// structInfo is the cached attribute descriptor of struct type for struct converting,
+// which avoids walking the struct attributes and tags via reflection on every converting.
+type structInfo struct {
+	embeddedIndexes []int             // Indexes of the exported embedded attributes.
+	attrCount       int               // Count of the exported non-embedded attributes.
+	tagToAttr       map[string]string // Comparison tag name to attribute name.
+	nameToAttr      map[string]string // Comparison attribute name to attribute name.
+	fieldIndexes    map[string][]int  // Attribute name to its field index sequence.
+	defaults        map[string]string // Attribute name to its default value from tag.
+	validAttrs      []validAttr       // Attributes having validation rules from tag.
+}
+
+// structInfoKey is the key of cached structInfo.
+type structInfoKey struct {
+	structType     reflect.Type
+	tags           string
+	matcher        FieldMatcher
+	embeddedNested bool
+}
+
+// mapFieldInfo is the cached attribute descriptor of struct type for map converting.
+type mapFieldInfo struct {
+	index     int    // Field index of the attribute.
+	fieldName string // Attribute name.
+	name      string // Map key name, which may be the tag name or the attribute name.
+	omitempty bool   // Whether the attribute is ignored if it's empty.
+}
+
+// mapFieldsKey is the key of cached mapFieldInfo slice.
+type mapFieldsKey struct {
+	structType reflect.Type
+	tags       string
+}
+
+var (
+	// structInfoCache caches the structInfo of struct types, which maps structInfoKey to *structInfo.
+	structInfoCache = sync.Map{}
+	// mapFieldsCache caches the map converting attributes of struct types,
+	// which maps mapFieldsKey to []mapFieldInfo.
+	mapFieldsCache = sync.Map{}
+)
+
+// getStructInfo retrieves and returns the cached structInfo of <structType> for struct converting,
+// it creates and caches one if it does not exist.
+func getStructInfo(structType reflect.Type, options *StructOptions) (*structInfo, error) {
+	var (
+		tags = options.getTags()
+		key  = structInfoKey{
+			structType:     structType,
+			tags:           strings.Join(tags, ","),
+			matcher:        options.getMatcher(),
+			embeddedNested: options != nil && options.EmbeddedNested,
+		}
+		// The non-comparable matcher cannot be used as the cache key.
+		cacheable = reflect.TypeOf(key.matcher).Comparable()
+	)
+	if cacheable {
+		if v, ok := structInfoCache.Load(key); ok {
+			return v.(*structInfo), nil
+		}
+	}
+	info := &structInfo{
+		tagToAttr:    make(map[string]string),
+		nameToAttr:   make(map[string]string),
+		fieldIndexes: make(map[string][]int),
+	}
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		// Only do converting to public attributes.
+		if !utils.IsLetterUpper(field.Name[0]) {
+			continue
+		}
+		// The embedded attribute is considered as a common attribute if it's kept nested.
+		if field.Anonymous && !key.embeddedNested {
+			info.embeddedIndexes = append(info.embeddedIndexes, i)
+			continue
+		}
+		info.attrCount++
+		info.addAttr(structType, field.Name)
+		for _, tag := range StructDefaultTagPriority {
+			if value, ok := field.Tag.Lookup(tag); ok {
+				if info.defaults == nil {
+					info.defaults = make(map[string]string)
+				}
+				info.defaults[field.Name] = value
+				break
+			}
+		}
+		for _, tag := range StructValidTagPriority {
+			if value := field.Tag.Get(tag); value != "" {
+				info.validAttrs = append(info.validAttrs, validAttr{
+					index: field.Index,
+					field: getFieldKeyName(field),
+					rules: strings.Split(value, "|"),
+				})
+				break
+			}
+		}
+		cmpName := options.getCompareName(field.Name)
+		if _, ok := info.nameToAttr[cmpName]; !ok {
+			info.nameToAttr[cmpName] = field.Name
+		}
+	}
+	if info.attrCount > 0 {
+		tagToNameMap, err := structs.TagMapName(reflect.New(structType).Elem(), tags)
+		if err != nil {
+			return nil, err
+		}
+		for tag, attrName := range tagToNameMap {
+			// The tags of the embedded struct attributes are ignored if it's kept nested.
+			if _, ok := info.fieldIndexes[attrName]; !ok && key.embeddedNested {
+				continue
+			}
+			info.addAttr(structType, attrName)
+			info.tagToAttr[options.getCompareName(tag)] = attrName
+		}
+	}
+	if cacheable {
+		structInfoCache.Store(key, info)
+	}
+	return info, nil
+}
+
+// getFieldKeyName returns the map key name of attribute <field>, which is the name of the first
+// priority tag in StructTagPriority, or the attribute name if it has no tag.
+func getFieldKeyName(field reflect.StructField) string {
+	for _, tag := range StructTagPriority {
+		if value := field.Tag.Get(tag); value != "" {
+			if name := strings.TrimSpace(strings.Split(value, ",")[0]); name != "" && name != "-" {
+				return name
+			}
+			break
+		}
+	}
+	return field.Name
+}
+
+// addAttr caches the field index sequence of attribute <name>.
+func (info *structInfo) addAttr(structType reflect.Type, name string) {
+	if _, ok := info.fieldIndexes[name]; ok {
+		return
+	}
+	if field, ok := structType.FieldByName(name); ok {
+		info.fieldIndexes[name] = field.Index
+	}
+}
+
+// getAttrName returns the attribute name matching the parameter key <cmpName>,
+// which is the comparison name of the key. It firstly matches the tag names,
+// and secondly the attribute names.
+func (info *structInfo) getAttrName(cmpName string) string {
+	if attrName, ok := info.tagToAttr[cmpName]; ok {
+		return attrName
+	}
+	return info.nameToAttr[cmpName]
+}
+
+// withDefaults returns a copy of <paramsMap> filled with the default values of the attributes,
+// which are missing or empty in <paramsMap>. The empty value is nil, empty string, empty []byte
+// or null json.RawMessage.
+// It returns <paramsMap> directly if the struct has no default values.
+func (info *structInfo) withDefaults(paramsMap map[string]interface{}, options *StructOptions, mapping ...map[string]string) map[string]interface{} {
+	if len(info.defaults) == 0 {
+		return paramsMap
+	}
+	var (
+		attrName  string
+		givenMap  = make(map[string]struct{})
+		newParams = make(map[string]interface{}, len(paramsMap)+len(info.defaults))
+	)
+	for k, v := range paramsMap {
+		attrName = ""
+		if len(mapping) > 0 && len(mapping[0]) > 0 {
+			attrName = mapping[0][k]
+		}
+		if attrName == "" {
+			attrName = info.getAttrName(options.getCompareName(k))
+		}
+		if _, ok := info.defaults[attrName]; ok {
+			// The empty value is replaced by the default value.
+			if isEmptyDefaultValue(v) {
+				continue
+			}
+			givenMap[attrName] = struct{}{}
+		}
+		newParams[k] = v
+	}
+	for name, value := range info.defaults {
+		if _, ok := givenMap[name]; !ok {
+			newParams[name] = value
+		}
+	}
+	return newParams
+}
+
+// isEmptyDefaultValue checks whether <value> is empty for applying the default value.
+func isEmptyDefaultValue(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return v == ""
+	case []byte:
+		return len(v) == 0
+	}
+	return empty.IsNil(value) || isNullRawMessage(value)
+}
+
+// getFieldValue returns the attribute value object of <elem> by attribute <name>.
+func (info *structInfo) getFieldValue(elem reflect.Value, name string) reflect.Value {
+	if index, ok := info.fieldIndexes[name]; ok {
+		return elem.FieldByIndex(index)
+	}
+	return elem.FieldByName(name)
+}
+
+// getMapFields retrieves and returns the cached attributes of <structType> for map converting,
+// it creates and caches them if they do not exist.
+func getMapFields(structType reflect.Type, tags []string) []mapFieldInfo {
+	key := mapFieldsKey{
+		structType: structType,
+		tags:       strings.Join(tags, ","),
+	}
+	if v, ok := mapFieldsCache.Load(key); ok {
+		return v.([]mapFieldInfo)
+	}
+	fields := make([]mapFieldInfo, 0, structType.NumField())
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		// Only convert the public attributes.
+		if !utils.IsLetterUpper(field.Name[0]) {
+			continue
+		}
+		info := mapFieldInfo{
+			index:     i,
+			fieldName: field.Name,
+		}
+		for _, tag := range tags {
+			if info.name = field.Tag.Get(tag); info.name != "" {
+				break
+			}
+		}
+		if info.name == "" {
+			info.name = field.Name
+		} else {
+			// Support json tag feature: -, omitempty
+			info.name = strings.TrimSpace(info.name)
+			if info.name == "-" {
+				continue
+			}
+			array := strings.Split(info.name, ",")
+			if len(array) > 1 {
+				info.name = strings.TrimSpace(array[0])
+				info.omitempty = strings.TrimSpace(array[1]) == "omitempty"
+			}
+		}
+		fields = append(fields, info)
+	}
+	mapFieldsCache.Store(key, fields)
+	return fields
+}
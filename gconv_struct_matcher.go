@@ -0,0 +1,82 @@
+package gconv
+
+// FieldMatcher is the strategy for matching the parameter keys with the struct attribute
+// and tag names in struct converting. A key matches an attribute if the comparison names
+// of the key and the attribute name or tag name are the same.
+//
+// Note that the struct attribute descriptors are cached by the FieldMatcher for performance,
+// so the FieldMatcher should be comparable, like a pointer or a struct value without
+// slice/map/func attributes, and its Normalize should always return the same result for
+// the same name. The non-comparable FieldMatcher works but disables the cache.
+type FieldMatcher interface {
+	// Normalize returns the comparison name of <name>, which is a parameter key,
+	// an attribute name or a tag name.
+	Normalize(name string) string
+}
+
+var (
+	// FieldMatcherFuzzy matches the names ignoring the case and chars like '-'/'_'/'.'/' ',
+	// like "UserName", "user_name" and "User-Name", which is the default FieldMatcher.
+	FieldMatcherFuzzy FieldMatcher = fuzzyFieldMatcher{}
+
+	// FieldMatcherExact matches the names exactly.
+	FieldMatcherExact FieldMatcher = exactFieldMatcher{}
+)
+
+// fuzzyFieldMatcher implements FieldMatcherFuzzy.
+type fuzzyFieldMatcher struct{}
+
+// Normalize implements FieldMatcher.
+func (fuzzyFieldMatcher) Normalize(name string) string {
+	return NormalizeKey(name)
+}
+
+// exactFieldMatcher implements FieldMatcherExact.
+type exactFieldMatcher struct{}
+
+// Normalize implements FieldMatcher.
+func (exactFieldMatcher) Normalize(name string) string {
+	return name
+}
+
+// aliasFieldMatcher implements the FieldMatcher created by NewAliasFieldMatcher.
+type aliasFieldMatcher struct {
+	base    FieldMatcher      // Matcher for the names which are not aliases.
+	aliases map[string]string // Comparison name of alias to comparison name of attribute name.
+}
+
+// NewAliasFieldMatcher creates and returns a FieldMatcher resolving the aliases by <aliases>,
+// which maps the alias keys to the attribute or tag names. The names are compared using
+// <base> matcher, which is FieldMatcherFuzzy if it is nil.
+//
+// The returned FieldMatcher should be created once and reused, as the attribute descriptors
+// are cached by each FieldMatcher.
+//
+// Eg:
+//
+//	matcher := gconv.NewAliasFieldMatcher(gconv.FieldMatcherExact, map[string]string{
+//		"surname": "LastName",
+//	})
+//	gconv.StructWithOptions(params, &user, gconv.StructOptions{Matcher: matcher})
+func NewAliasFieldMatcher(base FieldMatcher, aliases map[string]string) FieldMatcher {
+	if base == nil {
+		base = FieldMatcherFuzzy
+	}
+	matcher := &aliasFieldMatcher{
+		base:    base,
+		aliases: make(map[string]string, len(aliases)),
+	}
+	for alias, name := range aliases {
+		matcher.aliases[base.Normalize(alias)] = base.Normalize(name)
+	}
+	return matcher
+}
+
+// Normalize implements FieldMatcher.
+func (m *aliasFieldMatcher) Normalize(name string) string {
+	name = m.base.Normalize(name)
+	if v, ok := m.aliases[name]; ok {
+		return v
+	}
+	return name
+}
@@ -0,0 +1,72 @@
+package gconv
+
+// StructOptions is the options for StructWithOptions and StructsWithOptions.
+type StructOptions struct {
+	// Tags specifies the honored tag names in priority order for attribute matching,
+	// it uses StructTagPriority if it is empty.
+	Tags []string
+
+	// Deep specifies whether the options also apply to the nested struct attributes.
+	// It also converts <params> recursively using MapDeep if true.
+	Deep bool
+
+	// CaseSensitive specifies whether matching the keys with the attribute and tag names
+	// exactly, which means the case and chars like '-'/'_'/'.'/' ' are not ignored.
+	// It is the same as using FieldMatcherExact as Matcher.
+	CaseSensitive bool
+
+	// Matcher specifies the FieldMatcher for matching the keys with the attribute and tag names,
+	// which has priority over CaseSensitive. It uses FieldMatcherFuzzy if it is nil and
+	// CaseSensitive is false.
+	Matcher FieldMatcher
+
+	// EmptyAsNil specifies whether converting the empty string to nil for the pointer attributes,
+	// like *int and *string, or else it's converted to the pointer to zero value.
+	// It makes "absent" distinguishable from "zero" along with the missing keys and nil values,
+	// which always leave the pointer attributes nil for the newly created struct.
+	EmptyAsNil bool
+
+	// EmbeddedNested specifies whether the attributes of embedded struct are matched with the
+	// nested value under the embedded type name or tag name, like {"Base": {"Id": 1}},
+	// or else they are matched with the keys of the parent params, like {"Id": 1}.
+	EmbeddedNested bool
+}
+
+// getTags returns the honored tag names in priority order.
+func (options *StructOptions) getTags() []string {
+	if options == nil || len(options.Tags) == 0 {
+		return StructTagPriority
+	}
+	return options.Tags
+}
+
+// getMatcher returns the FieldMatcher for matching the keys with the attribute and tag names.
+func (options *StructOptions) getMatcher() FieldMatcher {
+	switch {
+	case options == nil:
+		return FieldMatcherFuzzy
+	case options.Matcher != nil:
+		return options.Matcher
+	case options.CaseSensitive:
+		return FieldMatcherExact
+	}
+	return FieldMatcherFuzzy
+}
+
+// getCompareName returns the name for matching comparison using the FieldMatcher of options,
+// which is the lower case name without symbols by default.
+func (options *StructOptions) getCompareName(name string) string {
+	if options == nil {
+		return NormalizeKey(name)
+	}
+	return options.getMatcher().Normalize(name)
+}
+
+// getNestedOptions returns the options for the nested struct attributes,
+// which is nil if it's not deep converting.
+func (options *StructOptions) getNestedOptions() *StructOptions {
+	if options != nil && options.Deep {
+		return options
+	}
+	return nil
+}
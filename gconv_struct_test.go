@@ -0,0 +1,545 @@
+package gconv
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type benchmarkUser struct {
+	Id       int    `json:"id"`
+	UserName string `json:"user_name"`
+	Nickname string `json:"nickname,omitempty"`
+	Password string `json:"-"`
+	Email    string
+	Age      int
+	Score    float64
+	Tags     []string
+}
+
+var (
+	benchmarkUserMap = map[string]interface{}{
+		"id":        1,
+		"user_name": "john",
+		"nickname":  "johnny",
+		"password":  "123456",
+		"email":     "john@example.com",
+		"age":       18,
+		"score":     99.5,
+		"tags":      []string{"a", "b"},
+	}
+	benchmarkUserObject = benchmarkUser{
+		Id:       1,
+		UserName: "john",
+		Nickname: "johnny",
+		Email:    "john@example.com",
+		Age:      18,
+		Score:    99.5,
+		Tags:     []string{"a", "b"},
+	}
+)
+
+func Test_StructInfoCache(t *testing.T) {
+	type user struct {
+		Id   int    `json:"id" param:"uid"`
+		Name string `json:"name"`
+	}
+	var (
+		userType = reflect.TypeOf(user{})
+		options  = []*StructOptions{
+			nil,
+			{Tags: []string{"json"}},
+			{CaseSensitive: true},
+			{Matcher: NewAliasFieldMatcher(nil, map[string]string{"login": "Name"})},
+		}
+		infos = make([]*structInfo, len(options))
+	)
+	for i, option := range options {
+		info, err := getStructInfo(userType, option)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The same options retrieve the same cached descriptor.
+		if cached, _ := getStructInfo(userType, option); cached != info {
+			t.Errorf("options %d: expected cached struct info", i)
+		}
+		for j := 0; j < i; j++ {
+			if infos[j] == info {
+				t.Errorf("options %d and %d: expected different struct info", j, i)
+			}
+		}
+		infos[i] = info
+	}
+	// The cached descriptors keep the matching behaviors of their tags and matchers.
+	tests := []struct {
+		params  map[string]interface{}
+		options *StructOptions
+		expect  user
+	}{
+		{map[string]interface{}{"uid": 1, "NAME": "john"}, options[0], user{Id: 1, Name: "john"}},
+		{map[string]interface{}{"uid": 1, "id": 2}, options[1], user{Id: 2}},
+		{map[string]interface{}{"Id": 1, "NAME": "john", "name": "jack"}, options[2], user{Id: 1, Name: "jack"}},
+		{map[string]interface{}{"Login": "john"}, options[3], user{Name: "john"}},
+		{map[string]interface{}{"Login": "john"}, options[0], user{}},
+	}
+	for i, test := range tests {
+		var u user
+		if err := doStructWithOptions(test.params, &u, test.options); err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+		} else if u != test.expect {
+			t.Errorf("test %d: expected %+v, got %+v", i, test.expect, u)
+		}
+	}
+}
+
+// testNonComparableMatcher is a FieldMatcher which is not comparable.
+type testNonComparableMatcher struct {
+	names map[string]string
+}
+
+func (m testNonComparableMatcher) Normalize(name string) string {
+	if v, ok := m.names[name]; ok {
+		return v
+	}
+	return name
+}
+
+func Test_StructInfoCache_NonComparableMatcher(t *testing.T) {
+	type user struct {
+		Name string
+	}
+	options := StructOptions{Matcher: testNonComparableMatcher{names: map[string]string{"login": "Name"}}}
+	for i := 0; i < 2; i++ {
+		var u user
+		if err := StructWithOptions(map[string]interface{}{"login": "john"}, &u, options); err != nil {
+			t.Fatal(err)
+		}
+		if u.Name != "john" {
+			t.Fatalf("expected john, got %q", u.Name)
+		}
+	}
+	structInfoCache.Range(func(key, value interface{}) bool {
+		if key.(structInfoKey).structType == reflect.TypeOf(user{}) {
+			t.Error("expected no cached struct info for non-comparable matcher")
+		}
+		return true
+	})
+}
+
+func Test_StructWithOptions(t *testing.T) {
+	type profile struct {
+		NickName string `gconv:"nick" json:"nick_name"`
+	}
+	type user struct {
+		UserName string `gconv:"login" json:"user_name"`
+		Age      *int
+		Profile  profile
+	}
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		options StructOptions
+		expect  func(u user) bool
+	}{
+		{
+			"tag priority",
+			map[string]interface{}{"login": "a", "user_name": "b"},
+			StructOptions{Tags: []string{"json"}},
+			func(u user) bool { return u.UserName == "b" },
+		},
+		{
+			"default tags",
+			map[string]interface{}{"login": "a"},
+			StructOptions{},
+			func(u user) bool { return u.UserName == "a" },
+		},
+		{
+			"case insensitive",
+			map[string]interface{}{"USER-NAME": "a"},
+			StructOptions{},
+			func(u user) bool { return u.UserName == "a" },
+		},
+		{
+			"case sensitive",
+			map[string]interface{}{"USER-NAME": "a", "age": 1},
+			StructOptions{CaseSensitive: true},
+			func(u user) bool { return u.UserName == "" && u.Age == nil },
+		},
+		{
+			"case sensitive exact",
+			map[string]interface{}{"UserName": "a", "Age": 1},
+			StructOptions{CaseSensitive: true},
+			func(u user) bool { return u.UserName == "a" && u.Age != nil && *u.Age == 1 },
+		},
+		{
+			"deep",
+			map[string]interface{}{"profile": map[string]interface{}{"nick": "a"}},
+			StructOptions{Tags: []string{"json"}, Deep: true},
+			func(u user) bool { return u.Profile.NickName == "" },
+		},
+		{
+			"not deep",
+			map[string]interface{}{"profile": map[string]interface{}{"nick": "a"}},
+			StructOptions{Tags: []string{"json"}},
+			func(u user) bool { return u.Profile.NickName == "a" },
+		},
+		{
+			"empty as nil",
+			map[string]interface{}{"age": ""},
+			StructOptions{EmptyAsNil: true},
+			func(u user) bool { return u.Age == nil },
+		},
+		{
+			"empty as zero",
+			map[string]interface{}{"age": ""},
+			StructOptions{},
+			func(u user) bool { return u.Age != nil && *u.Age == 0 },
+		},
+	}
+	for _, test := range tests {
+		var u user
+		if err := StructWithOptions(test.params, &u, test.options); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		} else if !test.expect(u) {
+			t.Errorf("%s: unexpected result: %+v", test.name, u)
+		}
+	}
+}
+
+func Test_Struct_Default(t *testing.T) {
+	type user struct {
+		Name   string  `json:"name" default:"guest"`
+		Age    int     `d:"18"`
+		Score  float64 `default:"1.5"`
+		Remark string
+	}
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+		expect user
+	}{
+		{"missing", map[string]interface{}{"remark": "r"}, user{Name: "guest", Age: 18, Score: 1.5, Remark: "r"}},
+		{"empty", map[string]interface{}{"name": "", "age": nil}, user{Name: "guest", Age: 18, Score: 1.5}},
+		{"given", map[string]interface{}{"NAME": "john", "age": 0, "score": 2}, user{Name: "john", Age: 0, Score: 2}},
+	}
+	for _, test := range tests {
+		var u user
+		if err := Struct(test.params, &u); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		} else if !reflect.DeepEqual(u, test.expect) {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.expect, u)
+		}
+	}
+	// The mapping keys are also recognized as given values.
+	var u user
+	if err := Struct(map[string]interface{}{"nick": "john"}, &u, map[string]string{"nick": "Name"}); err != nil {
+		t.Fatal(err)
+	}
+	if u.Name != "john" {
+		t.Errorf("expected john, got %q", u.Name)
+	}
+}
+
+func Test_Struct_Valid(t *testing.T) {
+	type user struct {
+		Name   string `json:"name" valid:"required|length:2,8"`
+		Age    int    `json:"age" v:"min:1|max:150"`
+		Gender string `valid:"in:male,female"`
+		Remark string
+	}
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+		errors map[string]string
+	}{
+		{"valid", map[string]interface{}{"name": "john", "age": 18, "gender": "male"}, nil},
+		{"empty optional", map[string]interface{}{"name": "john"}, nil},
+		{"required", map[string]interface{}{"age": 18}, map[string]string{"name": "is required"}},
+		{"multiple", map[string]interface{}{"name": "j", "age": 200, "gender": "x"}, map[string]string{
+			"name":   "length must be between 2 and 8",
+			"age":    "must be at most 150",
+			"Gender": "must be in male,female",
+		}},
+	}
+	for _, test := range tests {
+		var u user
+		err := Struct(test.params, &u)
+		if test.errors == nil {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", test.name, err)
+			}
+			continue
+		}
+		var validErr *ValidationError
+		if !errors.As(err, &validErr) {
+			t.Errorf("%s: expected *ValidationError, got %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(validErr.Map(), test.errors) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.errors, validErr.Map())
+		}
+	}
+}
+
+func Test_Struct_ValidNested(t *testing.T) {
+	type addr struct {
+		City string `json:"city" valid:"required"`
+	}
+	type user struct {
+		Name string `json:"name" valid:"required"`
+		Addr addr   `json:"addr"`
+		Home *addr  `json:"home"`
+		More []addr `json:"more"`
+	}
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+		errors map[string]string
+	}{
+		{"valid", map[string]interface{}{"name": "john", "addr": map[string]interface{}{"city": "x"}}, nil},
+		{"struct", map[string]interface{}{"name": "john", "addr": map[string]interface{}{"zip": "1"}}, map[string]string{
+			"addr.city": "is required",
+		}},
+		{"pointer", map[string]interface{}{"name": "john", "home": map[string]interface{}{"zip": "1"}}, map[string]string{
+			"home.city": "is required",
+		}},
+		{"slice", map[string]interface{}{"name": "john", "more": []interface{}{
+			map[string]interface{}{"city": "x"},
+			map[string]interface{}{"zip": "1"},
+		}}, map[string]string{
+			"more.1.city": "is required",
+		}},
+	}
+	for _, test := range tests {
+		var u user
+		err := Struct(test.params, &u)
+		if test.errors == nil {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", test.name, err)
+			}
+			continue
+		}
+		var validErr *ValidationError
+		if !errors.As(err, &validErr) {
+			t.Errorf("%s: expected *ValidationError, got %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(validErr.Map(), test.errors) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.errors, validErr.Map())
+		}
+	}
+}
+
+func Test_Struct_ValidCustomRule(t *testing.T) {
+	type order struct {
+		Count int    `valid:"test_even"`
+		Note  string `valid:"test_unknown"`
+	}
+	RegisterValidRule("test_even", func(value interface{}, param string) string {
+		if Int(value)%2 != 0 {
+			return "must be even"
+		}
+		return ""
+	})
+	var o order
+	err := Struct(map[string]interface{}{"count": 3}, &o)
+	var validErr *ValidationError
+	if !errors.As(err, &validErr) || len(validErr.Errors) != 1 || validErr.Errors[0].Rule != "test_even" {
+		t.Fatalf("expected test_even error, got %v", err)
+	}
+	// The unknown rule is reported only for non-empty attribute.
+	if err = Struct(map[string]interface{}{"count": 2, "note": "n"}, &o); err == nil || !strings.Contains(err.Error(), "test_unknown") {
+		t.Fatalf("expected unknown rule error, got %v", err)
+	}
+}
+
+// testFromMapUser implements FromMap like the code generated by gconvgen.
+type testFromMapUser struct {
+	Name string
+	Age  int
+	Tags []string
+}
+
+func (u *testFromMapUser) FromMap(m map[string]interface{}) error {
+	for k, v := range m {
+		switch NormalizeKey(k) {
+		case "name":
+			u.Name = String(v)
+		case "age":
+			u.Age = Int(v)
+		case "tags":
+			u.Tags = Strings(v)
+		}
+	}
+	return nil
+}
+
+func Test_Struct_RawMessage_FromMap(t *testing.T) {
+	var (
+		params = map[string]json.RawMessage{
+			"name": json.RawMessage(`"bob"`),
+			"age":  json.RawMessage(`18`),
+			"tags": json.RawMessage(`["a","b"]`),
+		}
+		fromMap    testFromMapUser
+		reflection struct {
+			Name string
+			Age  int
+			Tags []string
+		}
+	)
+	if err := Struct(params, &fromMap); err != nil {
+		t.Fatal(err)
+	}
+	if err := Struct(params, &reflection); err != nil {
+		t.Fatal(err)
+	}
+	// FromMap receives the decoded values, which gets the same result as the reflection converting.
+	expect := testFromMapUser{Name: "bob", Age: 18, Tags: []string{"a", "b"}}
+	if !reflect.DeepEqual(fromMap, expect) {
+		t.Errorf("expected %+v, got %+v", expect, fromMap)
+	}
+	if !reflect.DeepEqual(testFromMapUser(reflection), expect) {
+		t.Errorf("expected %+v, got %+v", expect, reflection)
+	}
+	// The params map of caller is not changed.
+	if string(params["name"]) != `"bob"` {
+		t.Errorf("expected params unchanged, got %s", params["name"])
+	}
+	// The invalid JSON value is reported.
+	params["age"] = json.RawMessage(`{`)
+	if err := Struct(params, &fromMap); err == nil {
+		t.Error("expected error for invalid JSON value")
+	}
+}
+
+// testHookUser implements PreConverter and PostConverter, which records the hook calls.
+type testHookUser struct {
+	Name   string
+	Before int `json:"-"`
+	After  int `json:"-"`
+}
+
+func (u *testHookUser) BeforeConvert(params map[string]interface{}) error {
+	u.Before++
+	return nil
+}
+
+func (u *testHookUser) AfterConvert() error {
+	u.After++
+	return nil
+}
+
+func Test_Struct_Hooks(t *testing.T) {
+	tests := []struct {
+		name   string
+		params interface{}
+		before int
+	}{
+		{"map", map[string]interface{}{"name": "john"}, 1},
+		{"json", `{"name":"john"}`, 0},
+		{"json bytes", []byte(`{"name":"john"}`), 0},
+		{"same type", testHookUser{Name: "john"}, 0},
+	}
+	for _, test := range tests {
+		var u testHookUser
+		if err := Struct(test.params, &u); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if u.Name != "john" || u.Before != test.before || u.After != 1 {
+			t.Errorf("%s: unexpected result %+v", test.name, u)
+		}
+		// The hooks are also called for the pointer to struct pointer.
+		var p *testHookUser
+		if err := Struct(test.params, &p); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if p == nil || p.Name != "john" || p.After != 1 {
+			t.Errorf("%s: unexpected result %+v", test.name, p)
+		}
+	}
+	// AfterConvert is not called if the converting fails.
+	var u testHookUser
+	if err := Struct(1, &u); err == nil || u.After != 0 {
+		t.Errorf("expected error without AfterConvert, got %v, %+v", err, u)
+	}
+}
+
+func Test_Scan_Map(t *testing.T) {
+	var m map[string]int
+	if err := Scan(map[string]interface{}{"a": "1", "b": 2}, &m); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(m, map[string]int{"a": 1, "b": 2}) {
+		t.Errorf("unexpected result: %v", m)
+	}
+	// The struct params is converted to map, and the pointed map is allocated for **map.
+	var pm *map[string]string
+	if err := Scan(struct{ Name string }{"john"}, &pm); err != nil {
+		t.Fatal(err)
+	}
+	if pm == nil || !reflect.DeepEqual(*pm, map[string]string{"Name": "john"}) {
+		t.Errorf("unexpected result: %v", pm)
+	}
+	var ms []map[string]int
+	if err := Scan([]map[string]string{{"a": "1"}, {"b": "2"}}, &ms); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ms, []map[string]int{{"a": 1}, {"b": 2}}) {
+		t.Errorf("unexpected result: %v", ms)
+	}
+	var pms []*map[string]int
+	if err := Scan(`[{"a":1}]`, &pms); err != nil {
+		t.Fatal(err)
+	}
+	if len(pms) != 1 || !reflect.DeepEqual(*pms[0], map[string]int{"a": 1}) {
+		t.Errorf("unexpected result: %v", pms)
+	}
+}
+
+func Benchmark_Struct(b *testing.B) {
+	var user benchmarkUser
+	for i := 0; i < b.N; i++ {
+		if err := Struct(benchmarkUserMap, &user); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_StructWithOptions(b *testing.B) {
+	var (
+		user    benchmarkUser
+		options = StructOptions{Tags: []string{"json"}, CaseSensitive: true}
+	)
+	for i := 0; i < b.N; i++ {
+		if err := StructWithOptions(benchmarkUserMap, &user, options); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_Structs(b *testing.B) {
+	var (
+		users  []benchmarkUser
+		params = []interface{}{benchmarkUserMap, benchmarkUserMap, benchmarkUserMap}
+	)
+	for i := 0; i < b.N; i++ {
+		if err := Structs(params, &users); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_Map(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Map(benchmarkUserObject)
+	}
+}
+
+func Benchmark_MapDeep(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		MapDeep(benchmarkUserObject)
+	}
+}
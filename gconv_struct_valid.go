@@ -0,0 +1,208 @@
+package gconv
+
+import (
+	"fmt"
+	"github.com/ilylx/gconv/empty"
+	"github.com/ilylx/gconv/internal/gerror"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// ValidRuleFunc is the function checking attribute value <value> with rule parameter <param>,
+// which is the text after ':' of the rule, like "1" of rule "min:1".
+// It returns the error message if the checking fails, or else an empty string.
+type ValidRuleFunc func(value interface{}, param string) string
+
+// FieldError is the validation error of a struct attribute.
+type FieldError struct {
+	Field   string // Field name, which is the tag name or the attribute name.
+	Rule    string // Failed rule name, like "required", "min".
+	Message string // Error message.
+}
+
+// ValidationError is the error of struct converting validation,
+// which contains all the failed attributes in attribute order.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+// validAttr is the cached validation rules of struct attribute.
+type validAttr struct {
+	index []int    // Field index sequence of the attribute.
+	field string   // Field name for error message.
+	rules []string // Rules like "required", "min:1".
+}
+
+var (
+	// validRuleMu ensures the concurrent safety of validRuleMap.
+	validRuleMu sync.RWMutex
+	// validRuleMap stores the validation rules, which maps rule name to its checking function.
+	validRuleMap = map[string]ValidRuleFunc{
+		"min":    validRuleMin,
+		"max":    validRuleMax,
+		"length": validRuleLength,
+		"in":     validRuleIn,
+	}
+)
+
+// RegisterValidRule registers custom validation rule <name> with checking function <fn>,
+// which can be used in tag "valid" of struct attributes, like `valid:"required|phone"`.
+// Registering an existing rule name overwrites the previous one.
+//
+// Eg:
+//
+//	gconv.RegisterValidRule("even", func(value interface{}, param string) string {
+//		if gconv.Int(value)%2 != 0 {
+//			return "must be even"
+//		}
+//		return ""
+//	})
+func RegisterValidRule(name string, fn ValidRuleFunc) {
+	validRuleMu.Lock()
+	validRuleMap[name] = fn
+	validRuleMu.Unlock()
+}
+
+// Error implements the interface error, which joins all the field errors.
+func (err *ValidationError) Error() string {
+	messages := make([]string, len(err.Errors))
+	for i, e := range err.Errors {
+		messages[i] = e.Field + " " + e.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Map returns the error messages of the failed fields, which maps field name to error message.
+func (err *ValidationError) Map() map[string]string {
+	m := make(map[string]string, len(err.Errors))
+	for _, e := range err.Errors {
+		if _, ok := m[e.Field]; !ok {
+			m[e.Field] = e.Message
+		}
+	}
+	return m
+}
+
+// withParent returns a copy of <err> with the fields prefixed with <parent>,
+// which is used for the validation error of the nested struct attribute, like "addr.city".
+func (err *ValidationError) withParent(parent string) *ValidationError {
+	errs := make([]FieldError, len(err.Errors))
+	for i, e := range err.Errors {
+		e.Field = parent + "." + e.Field
+		errs[i] = e
+	}
+	return &ValidationError{Errors: errs}
+}
+
+// validate checks the attributes of struct <elem> with the cached rules of <info>,
+// and returns *ValidationError if any attribute fails.
+// The rules except "required" are ignored for empty attribute value.
+func (info *structInfo) validate(elem reflect.Value) error {
+	if len(info.validAttrs) == 0 {
+		return nil
+	}
+	var errs []FieldError
+	for _, attr := range info.validAttrs {
+		value := elem.FieldByIndex(attr.index).Interface()
+		isEmpty := empty.IsEmpty(value)
+		for _, rule := range attr.rules {
+			var (
+				name  = rule
+				param = ""
+			)
+			if index := strings.IndexByte(rule, ':'); index >= 0 {
+				name, param = rule[:index], rule[index+1:]
+			}
+			if name == "required" {
+				if isEmpty {
+					errs = append(errs, FieldError{Field: attr.field, Rule: name, Message: "is required"})
+					break
+				}
+				continue
+			}
+			if isEmpty {
+				continue
+			}
+			validRuleMu.RLock()
+			fn, ok := validRuleMap[name]
+			validRuleMu.RUnlock()
+			if !ok {
+				return gerror.Newf(`unknown validation rule "%s" of attribute "%s"`, name, attr.field)
+			}
+			if message := fn(value, param); message != "" {
+				errs = append(errs, FieldError{Field: attr.field, Rule: name, Message: message})
+				break
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+	return nil
+}
+
+// validRuleMin checks the minimum of number value, or the minimum length of string/slice/map value.
+func validRuleMin(value interface{}, param string) string {
+	if n, isLen := validValueSize(value); n < Float64(param) {
+		if isLen {
+			return fmt.Sprintf("length must be at least %s", param)
+		}
+		return fmt.Sprintf("must be at least %s", param)
+	}
+	return ""
+}
+
+// validRuleMax checks the maximum of number value, or the maximum length of string/slice/map value.
+func validRuleMax(value interface{}, param string) string {
+	if n, isLen := validValueSize(value); n > Float64(param) {
+		if isLen {
+			return fmt.Sprintf("length must be at most %s", param)
+		}
+		return fmt.Sprintf("must be at most %s", param)
+	}
+	return ""
+}
+
+// validRuleLength checks the length range of string/slice/map value, like "length:6,16".
+func validRuleLength(value interface{}, param string) string {
+	var (
+		n, _             = validValueSize(value)
+		minSize, maxSize = param, param
+	)
+	if index := strings.IndexByte(param, ','); index >= 0 {
+		minSize, maxSize = param[:index], param[index+1:]
+	}
+	if n < Float64(minSize) || n > Float64(maxSize) {
+		return fmt.Sprintf("length must be between %s and %s", minSize, maxSize)
+	}
+	return ""
+}
+
+// validRuleIn checks whether the value is in the comma-separated items, like "in:male,female".
+func validRuleIn(value interface{}, param string) string {
+	s := String(value)
+	for _, item := range strings.Split(param, ",") {
+		if strings.TrimSpace(item) == s {
+			return ""
+		}
+	}
+	return fmt.Sprintf("must be in %s", param)
+}
+
+// validValueSize returns the size of <value> for rule checking, which is the length for
+// string/slice/array/map value and the number for others.
+func validValueSize(value interface{}) (size float64, isLen bool) {
+	if s, ok := value.(string); ok {
+		return float64(utf8.RuneCountInString(s)), true
+	}
+	rv := reflect.Indirect(reflect.ValueOf(value))
+	switch rv.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(rv.String())), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(rv.Len()), true
+	}
+	return Float64(value), false
+}
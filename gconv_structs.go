@@ -11,14 +11,34 @@ func Structs(params interface{}, pointer interface{}, mapping ...map[string]stri
 	return doStructs(params, pointer, mapping...)
 }
 
+// StructsWithOptions converts any slice to given struct slice like Structs,
+// but with custom matching behavior specified by <options>.
+// See Structs and StructOptions.
+//
+// Eg:
+//
+//	gconv.StructsWithOptions(params, &users, gconv.StructOptions{
+//		EmptyAsNil: true,
+//	})
+func StructsWithOptions(params interface{}, pointer interface{}, options StructOptions, mapping ...map[string]string) (err error) {
+	return doStructsWithOptions(params, pointer, &options, mapping...)
+}
+
 // doStructs converts any slice to given struct slice.
+// See doStructsWithOptions.
+func doStructs(params interface{}, pointer interface{}, mapping ...map[string]string) (err error) {
+	return doStructsWithOptions(params, pointer, nil, mapping...)
+}
+
+// doStructsWithOptions converts any slice to given struct slice.
 //
 // It automatically checks and converts json string to []map if <params> is string/[]byte.
 //
 // The parameter <pointer> should be type of pointer to slice of struct.
 // Note that if <pointer> is a pointer to another pointer of type of slice of struct,
 // it will create the struct/pointer internally.
-func doStructs(params interface{}, pointer interface{}, mapping ...map[string]string) (err error) {
+// The parameter <options> specifies the matching behavior of the items, which can be nil.
+func doStructsWithOptions(params interface{}, pointer interface{}, options *StructOptions, mapping ...map[string]string) (err error) {
 	if params == nil {
 		// If <params> is nil, no conversion.
 		return nil
@@ -43,20 +63,20 @@ func doStructs(params interface{}, pointer interface{}, mapping ...map[string]st
 		if json.Valid(r) {
 			if rv, ok := pointer.(reflect.Value); ok {
 				if rv.Kind() == reflect.Ptr {
-					return json.Unmarshal(r, rv.Interface())
+					return jsonUnmarshal(r, rv.Interface())
 				}
 			} else {
-				return json.Unmarshal(r, pointer)
+				return jsonUnmarshal(r, pointer)
 			}
 		}
 	case string:
 		if paramsBytes := []byte(r); json.Valid(paramsBytes) {
 			if rv, ok := pointer.(reflect.Value); ok {
 				if rv.Kind() == reflect.Ptr {
-					return json.Unmarshal(paramsBytes, rv.Interface())
+					return jsonUnmarshal(paramsBytes, rv.Interface())
 				}
 			} else {
-				return json.Unmarshal(paramsBytes, pointer)
+				return jsonUnmarshal(paramsBytes, pointer)
 			}
 		}
 	}
@@ -82,14 +102,14 @@ func doStructs(params interface{}, pointer interface{}, mapping ...map[string]st
 		if itemType.Kind() == reflect.Ptr {
 			// Slice element is type pointer.
 			e := reflect.New(itemType.Elem()).Elem()
-			if err = Struct(paramsMaps[i], e, mapping...); err != nil {
+			if err = doStructWithOptions(paramsMaps[i], e, options, mapping...); err != nil {
 				return err
 			}
 			array.Index(i).Set(e.Addr())
 		} else {
 			// Slice element is not type of pointer.
 			e := reflect.New(itemType).Elem()
-			if err = Struct(paramsMaps[i], e, mapping...); err != nil {
+			if err = doStructWithOptions(paramsMaps[i], e, options, mapping...); err != nil {
 				return err
 			}
 			array.Index(i).Set(e)
@@ -7,6 +7,8 @@ import (
 )
 
 // Time converts <i> to time.Time.
+// The optional parameter <format> is in gtime format like "d/m/Y H:i",
+// use TimeLayout for the stdlib layout like "02/01/2006 15:04".
 func Time(i interface{}, format ...string) time.Time {
 	// It's already this type.
 	if len(format) == 0 {
@@ -20,10 +22,42 @@ func Time(i interface{}, format ...string) time.Time {
 	return time.Time{}
 }
 
+// TimeLayout converts <i> to time.Time using given stdlib <layout> like "02/01/2006 15:04".
+// The <layout> are tried in order and the first matched one is used, and it uses the
+// registered layouts of RegisterTimeLayout if no <layout> given.
+// It returns zero time if <i> matches none of the layouts.
+//
+// Eg:
+//
+//	gconv.TimeLayout("25/12/2021 08:30", "02/01/2006 15:04", "02/01/2006")
+func TimeLayout(i interface{}, layout ...string) time.Time {
+	if v, ok := i.(time.Time); ok {
+		return v
+	}
+	if t := GTimeLayout(i, layout...); t != nil {
+		return t.Time
+	}
+	return time.Time{}
+}
+
+// RegisterTimeLayout registers additional stdlib <layout> like "02/01/2006 15:04" globally,
+// which are tried by Time/GTime before the standard datetime parsing if no format given.
+// It is the same as gtime.AddLayouts.
+func RegisterTimeLayout(layout ...string) {
+	gtime.AddLayouts(layout...)
+}
+
 // Duration converts <i> to time.Duration.
-// If <i> is string, then it uses time.ParseDuration to convert it.
-// If <i> is numeric, then it converts <i> as nanoseconds.
-func Duration(i interface{}) time.Duration {
+// If <i> is string, then it uses gtime.ParseDuration to convert it, which also supports
+// the day and week units like "1d", "2w" and "1d12h30m".
+// If <i> is numeric, then it converts <i> as nanoseconds, or as the optional <unit>.
+//
+// Eg:
+//
+//	gconv.Duration("1d12h")               // 36h
+//	gconv.Duration(90, time.Second)       // 1m30s
+//	gconv.Duration("1.5", time.Minute)    // 1m30s
+func Duration(i interface{}, unit ...time.Duration) time.Duration {
 	// It's already this type.
 	if v, ok := i.(time.Duration); ok {
 		return v
@@ -33,13 +67,16 @@ func Duration(i interface{}) time.Duration {
 		d, _ := gtime.ParseDuration(s)
 		return d
 	}
+	if len(unit) > 0 && unit[0] > 0 {
+		return time.Duration(Float64(i) * float64(unit[0]))
+	}
 	return time.Duration(Int64(i))
 }
 
 // GTime converts <i> to *gtime.Time.
 // The parameter <format> can be used to specify the format of <i>.
 // If no <format> given, it converts <i> using gtime.NewFromTimeStamp if <i> is numeric,
-// or using gtime.StrToTime if <i> is string.
+// or using the global layouts of gtime.SetLayouts and then gtime.StrToTime if <i> is string.
 func GTime(i interface{}, format ...string) *gtime.Time {
 	if i == nil {
 		return nil
@@ -61,8 +98,26 @@ func GTime(i interface{}, format ...string) *gtime.Time {
 	}
 	if utils.IsNumeric(s) {
 		return gtime.NewFromTimeStamp(Int64(s))
-	} else {
-		t, _ := gtime.StrToTime(s)
-		return t
 	}
+	// The global layouts have priority over the standard datetime parsing.
+	if layouts := gtime.GetLayouts(); len(layouts) > 0 {
+		if t, err := gtime.ParseLayouts(s, layouts...); err == nil {
+			return t
+		}
+	}
+	t, _ := gtime.StrToTime(s)
+	return t
+}
+
+// GTimeLayout converts <i> to *gtime.Time using given stdlib <layout> like "02/01/2006 15:04".
+// See TimeLayout.
+func GTimeLayout(i interface{}, layout ...string) *gtime.Time {
+	if i == nil {
+		return nil
+	}
+	if v, ok := i.(*gtime.Time); ok {
+		return v
+	}
+	t, _ := gtime.ParseLayouts(String(i), layout...)
+	return t
 }
@@ -0,0 +1,40 @@
+package gconv
+
+import (
+	"reflect"
+)
+
+var (
+	// stringsType is the reflect type of []string.
+	stringsType = reflect.TypeOf([]string(nil))
+)
+
+// valuesToMap converts <params> of type map[string][]string, like url.Values and http.Header,
+// to map[string]interface{} for struct converting, in which the single value is unwrapped
+// from its slice, so it can be converted to both the scalar and slice attributes.
+// It returns false if <params> is not type of map[string][]string.
+func valuesToMap(params interface{}) (map[string]interface{}, bool) {
+	values, ok := params.(map[string][]string)
+	if !ok {
+		rv, isReflectValue := params.(reflect.Value)
+		if !isReflectValue {
+			rv = reflect.ValueOf(params)
+		}
+		if rv.Kind() != reflect.Map || rv.Type().Key() != stringType || rv.Type().Elem() != stringsType {
+			return nil, false
+		}
+		values = rv.Convert(reflect.TypeOf(values)).Interface().(map[string][]string)
+	}
+	m := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		switch len(v) {
+		case 0:
+			m[k] = nil
+		case 1:
+			m[k] = v[0]
+		default:
+			m[k] = v
+		}
+	}
+	return m, true
+}
@@ -26,28 +26,50 @@ func doInit() {
 			cmdOptions[result[1]] = result[2]
 		}
 	}
+	initConfigFile()
 }
 
 // Get returns the command line argument of the specified <key>.
-// If the argument does not exist, then it returns the environment variable with specified <key>.
+// If the argument does not exist, then it returns the environment variable with specified <key>,
+// and then the value of the config file set by SetConfigFile.
 // It returns the default value <def> if none of them exists.
 //
 // Fetching Rules:
 // 1. Command line arguments are in lowercase format, eg: gf.<package name>.<variable name>;
 // 2. Environment arguments are in uppercase format, eg: GF_<package name>_<variable name>；
+// 3. Config file keys are in lowercase format, eg: gf.<package name>.<variable name>, which can be nested;
+// 4. The order of the sources can be changed by SetPrecedence.
 func Get(key string, def ...interface{}) *gvar.Var {
 	value := interface{}(nil)
 	if len(def) > 0 {
 		value = def[0]
 	}
 	cmdKey := strings.ToLower(strings.Replace(key, "_", ".", -1))
-	if v, ok := cmdOptions[cmdKey]; ok {
-		value = v
-	} else {
-		envKey := strings.ToUpper(strings.Replace(key, ".", "_", -1))
-		if v := os.Getenv(envKey); v != "" {
-			value = v
+	for _, source := range getPrecedence() {
+		switch source {
+		case SOURCE_CMD:
+			if v, ok := cmdOptions[cmdKey]; ok {
+				return gvar.New(v)
+			}
+		case SOURCE_ENV:
+			envKey := strings.ToUpper(strings.Replace(key, ".", "_", -1))
+			if v := os.Getenv(envKey); v != "" {
+				return gvar.New(v)
+			}
+		case SOURCE_FILE:
+			if v, ok := getFromConfig(cmdKey); ok {
+				return gvar.New(v)
+			}
 		}
 	}
 	return gvar.New(value)
 }
+
+// getCmdOrEnv returns the command line argument or environment variable of <key>,
+// without consulting the config file.
+func getCmdOrEnv(key string) string {
+	if v, ok := cmdOptions[strings.ToLower(strings.Replace(key, "_", ".", -1))]; ok {
+		return v
+	}
+	return os.Getenv(strings.ToUpper(strings.Replace(key, ".", "_", -1)))
+}
@@ -0,0 +1,150 @@
+package cmdenv
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ilylx/gconv"
+	"github.com/ilylx/gconv/internal/encoding/gtoml"
+	"github.com/ilylx/gconv/internal/encoding/gyaml"
+	"github.com/ilylx/gconv/internal/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	SOURCE_CMD  = iota // Command line arguments.
+	SOURCE_ENV         // Environment variables.
+	SOURCE_FILE        // Config file set by SetConfigFile.
+)
+
+const (
+	// gCONFIG_FILE_KEY is the key specifying the config file, which can be given by command
+	// line argument "gf.cmdenv.config" or environment variable "GF_CMDENV_CONFIG", so the
+	// config file is available for the packages reading their options in initialization.
+	gCONFIG_FILE_KEY = "gf.cmdenv.config"
+)
+
+var (
+	// configMu protects the config file data and the precedence.
+	configMu = sync.RWMutex{}
+	// configData is the decoded content of the config file.
+	configData map[string]interface{}
+	// precedence is the order of the sources Get consults.
+	precedence = []int{SOURCE_CMD, SOURCE_ENV, SOURCE_FILE}
+)
+
+// initConfigFile loads the config file specified by command line argument or environment variable.
+func initConfigFile() {
+	if path := getCmdOrEnv(gCONFIG_FILE_KEY); path != "" {
+		if err := SetConfigFile(path); err != nil {
+			fmt.Fprintf(os.Stderr, "cmdenv: %s\n", err.Error())
+		}
+	}
+}
+
+// SetConfigFile sets and loads the config file <path>, which is consulted by Get after command line
+// arguments and environment variables in default. The config file can be in JSON/YAML/TOML format,
+// which is checked by the file extension, and the keys can be either nested or flat, eg:
+//
+//	{"gf": {"gtimer": {"slots": 100}}}
+//	{"gf.gtimer.slots": 100}
+//
+// It clears the config file if <path> is empty.
+//
+// Note that the options read in package initialization can only be configured by the config file
+// given by command line argument "gf.cmdenv.config" or environment variable "GF_CMDENV_CONFIG".
+func SetConfigFile(path string) error {
+	if path == "" {
+		configMu.Lock()
+		configData = nil
+		configMu.Unlock()
+		return nil
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	data, err := decodeConfig(content, filepath.Ext(path))
+	if err != nil {
+		return errors.New(fmt.Sprintf(`decode config file "%s" failed: %s`, path, err.Error()))
+	}
+	configMu.Lock()
+	configData = data
+	configMu.Unlock()
+	return nil
+}
+
+// SetPrecedence sets the order of the sources that Get consults, which is
+// SOURCE_CMD, SOURCE_ENV, SOURCE_FILE in default. The sources not given are not consulted.
+//
+// Eg:
+//
+//	cmdenv.SetPrecedence(cmdenv.SOURCE_FILE, cmdenv.SOURCE_CMD)
+func SetPrecedence(sources ...int) {
+	configMu.Lock()
+	precedence = append([]int(nil), sources...)
+	configMu.Unlock()
+}
+
+// getPrecedence returns the order of the sources that Get consults.
+func getPrecedence() []int {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return precedence
+}
+
+// getFromConfig returns the value of <key> from the config file.
+// The <key> is in command line format, eg: gf.gtimer.slots.
+func getFromConfig(key string) (interface{}, bool) {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	if configData == nil {
+		return nil, false
+	}
+	return searchConfig(configData, key)
+}
+
+// searchConfig searches <key> in <data>, in which <key> can be a flat key or a path of nested keys
+// split by '.'. The key matching is case-insensitive.
+func searchConfig(data map[string]interface{}, key string) (interface{}, bool) {
+	for k, v := range data {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	for k, v := range data {
+		if len(key) > len(k) && key[len(k)] == '.' && strings.EqualFold(k, key[:len(k)]) {
+			if m, ok := v.(map[string]interface{}); ok {
+				if value, ok := searchConfig(m, key[len(k)+1:]); ok {
+					return value, true
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
+// decodeConfig decodes config <content> by file extension <ext>.
+func decodeConfig(content []byte, ext string) (map[string]interface{}, error) {
+	var (
+		data interface{}
+		err  error
+	)
+	switch strings.ToLower(ext) {
+	case ".yaml", ".yml":
+		data, err = gyaml.Decode(content)
+	case ".toml":
+		data, err = gtoml.Decode(content)
+	case ".json":
+		err = json.Unmarshal(content, &data)
+	default:
+		return nil, errors.New(fmt.Sprintf(`unsupported config file type "%s"`, ext))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return gconv.MapDeep(data), nil
+}
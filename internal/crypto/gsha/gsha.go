@@ -0,0 +1,146 @@
+// Package gsha provides useful API for SHA-1/SHA-2 and HMAC encryption algorithms.
+package gsha
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"github.com/ilylx/gconv"
+	"hash"
+	"io"
+	"os"
+)
+
+const (
+	SHA1   = "sha1"
+	SHA256 = "sha256"
+	SHA512 = "sha512"
+)
+
+// New creates and returns a new hash.Hash for given <algorithm>,
+// which can be SHA1, SHA256 or SHA512.
+func New(algorithm string) (hash.Hash, error) {
+	if f := getHashFunc(algorithm); f != nil {
+		return f(), nil
+	}
+	return nil, errors.New(fmt.Sprintf(`unsupported hash algorithm "%s"`, algorithm))
+}
+
+// Encrypt encrypts any type of variable using given <algorithm>.
+// It uses gconv package to convert <data> to its bytes type.
+func Encrypt(algorithm string, data interface{}) (encrypt string, err error) {
+	h, err := New(algorithm)
+	if err != nil {
+		return "", err
+	}
+	if _, err = h.Write(gconv.Bytes(data)); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// EncryptFile encrypts file content of <path> using given <algorithm>.
+func EncryptFile(algorithm string, path string) (encrypt string, err error) {
+	h, err := New(algorithm)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// Hmac calculates the HMAC of <data> with <key> using given <algorithm>.
+// It uses gconv package to convert <key> and <data> to their bytes type.
+func Hmac(algorithm string, key interface{}, data interface{}) (encrypt string, err error) {
+	f := getHashFunc(algorithm)
+	if f == nil {
+		return "", errors.New(fmt.Sprintf(`unsupported hash algorithm "%s"`, algorithm))
+	}
+	h := hmac.New(f, gconv.Bytes(key))
+	if _, err = h.Write(gconv.Bytes(data)); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// Sha1 encrypts any type of variable using SHA-1 algorithm.
+func Sha1(data interface{}) string {
+	result, _ := Encrypt(SHA1, data)
+	return result
+}
+
+// Sha256 encrypts any type of variable using SHA-256 algorithm.
+func Sha256(data interface{}) string {
+	result, _ := Encrypt(SHA256, data)
+	return result
+}
+
+// Sha512 encrypts any type of variable using SHA-512 algorithm.
+func Sha512(data interface{}) string {
+	result, _ := Encrypt(SHA512, data)
+	return result
+}
+
+// Sha1File encrypts file content of <path> using SHA-1 algorithm.
+func Sha1File(path string) (encrypt string, err error) {
+	return EncryptFile(SHA1, path)
+}
+
+// Sha256File encrypts file content of <path> using SHA-256 algorithm.
+func Sha256File(path string) (encrypt string, err error) {
+	return EncryptFile(SHA256, path)
+}
+
+// Sha512File encrypts file content of <path> using SHA-512 algorithm.
+func Sha512File(path string) (encrypt string, err error) {
+	return EncryptFile(SHA512, path)
+}
+
+// HmacSha1 calculates the HMAC-SHA1 of <data> with <key>.
+func HmacSha1(key interface{}, data interface{}) string {
+	result, _ := Hmac(SHA1, key, data)
+	return result
+}
+
+// HmacSha256 calculates the HMAC-SHA256 of <data> with <key>.
+func HmacSha256(key interface{}, data interface{}) string {
+	result, _ := Hmac(SHA256, key, data)
+	return result
+}
+
+// HmacSha512 calculates the HMAC-SHA512 of <data> with <key>.
+func HmacSha512(key interface{}, data interface{}) string {
+	result, _ := Hmac(SHA512, key, data)
+	return result
+}
+
+// Equal compares the two digests <a> and <b> in constant time,
+// which should be used for verifying signatures against timing attacks.
+func Equal(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// getHashFunc returns the hash creating function for given <algorithm>,
+// or nil if the algorithm is not supported.
+func getHashFunc(algorithm string) func() hash.Hash {
+	switch algorithm {
+	case SHA1:
+		return sha1.New
+	case SHA256:
+		return sha256.New
+	case SHA512:
+		return sha512.New
+	}
+	return nil
+}
@@ -11,8 +11,10 @@ import (
 )
 
 const (
-	gMaxDepth  = 1000
-	gFilterKey = "/debug/gdebug/gdebug"
+	gMaxDepth = 1000
+	// gFilterKey filters the frames of this package from the stack,
+	// which matches the files of the package located at "internal/gdebug".
+	gFilterKey = "/gdebug/gdebug"
 )
 
 var (
@@ -27,7 +29,8 @@ func init() {
 		goRootForFilter = strings.Replace(goRootForFilter, "\\", "/", -1)
 	}
 	// Initialize internal package variable: selfPath.
-	selfPath, _ := exec.LookPath(os.Args[0])
+	// Note that it assigns the package variable rather than declaring a local one shadowing it.
+	selfPath, _ = exec.LookPath(os.Args[0])
 	if selfPath != "" {
 		selfPath, _ = filepath.Abs(selfPath)
 	}
@@ -0,0 +1,102 @@
+package gdebug
+
+import (
+	"bytes"
+	"io"
+	"runtime"
+	"strings"
+)
+
+// Frame is a structured stack frame, which is machine-readable.
+type Frame struct {
+	Function string `json:"function"` // Full function name, eg: github.com/ilylx/gconv/internal/gdebug.StackFrames.
+	File     string `json:"file"`     // Absolute file path.
+	Line     int    `json:"line"`     // Line number in the file.
+}
+
+// StackFrames returns the structured stack frames of the goroutine that calls it,
+// from the caller to the outermost function. The frames of this package and GOROOT are excluded.
+// The optional parameter <skip> specifies the count of the frames skipped from the caller.
+func StackFrames(skip ...int) []Frame {
+	return StackFramesWithFilters(nil, skip...)
+}
+
+// StackFramesWithFilters returns the structured stack frames of the goroutine that calls it.
+// The parameter <filters> is a slice of strings, which are used to filter the path of the frames.
+func StackFramesWithFilters(filters []string, skip ...int) []Frame {
+	number := 0
+	if len(skip) > 0 {
+		number = skip[0]
+	}
+	var (
+		pcs    = make([]uintptr, gMaxDepth)
+		frames = runtime.CallersFrames(pcs[:runtime.Callers(1, pcs)])
+		result = make([]Frame, 0)
+	)
+	for {
+		frame, more := frames.Next()
+		if isFrameFiltered(frame.File, filters) {
+			if !more {
+				break
+			}
+			continue
+		}
+		if number > 0 {
+			number--
+		} else {
+			result = append(result, Frame{
+				Function: frame.Function,
+				File:     frame.File,
+				Line:     frame.Line,
+			})
+		}
+		if !more {
+			break
+		}
+	}
+	return result
+}
+
+// DumpGoroutines writes the stacks of all goroutines to <w>, which is in the format of runtime.Stack.
+// The parameter <filter> is used to filter the goroutines, only the goroutines whose stack contains
+// <filter> are written, eg: a function name or a file path. It writes all goroutines if <filter> is empty.
+func DumpGoroutines(w io.Writer, filter string) error {
+	buf := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	// The goroutines are separated by blank lines.
+	for _, goroutine := range bytes.Split(buf, []byte("\n\n")) {
+		if goroutine = bytes.TrimRight(goroutine, "\n"); len(goroutine) == 0 {
+			continue
+		}
+		if filter != "" && !bytes.Contains(goroutine, []byte(filter)) {
+			continue
+		}
+		if _, err := w.Write(append(goroutine, '\n', '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isFrameFiltered checks whether the frame of <file> should be filtered.
+func isFrameFiltered(file string, filters []string) bool {
+	if file == "" || strings.Contains(file, gFilterKey) {
+		return true
+	}
+	if goRootForFilter != "" && strings.HasPrefix(file, goRootForFilter) {
+		return true
+	}
+	for _, filter := range filters {
+		if filter != "" && strings.Contains(file, filter) {
+			return true
+		}
+	}
+	return false
+}
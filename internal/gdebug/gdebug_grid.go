@@ -12,12 +12,21 @@ var (
 )
 
 // GoroutineId retrieves and returns the current goroutine id from stack information.
+// Deprecated, use GoroutineID instead.
+func GoroutineId() int {
+	return GoroutineID()
+}
+
+// GoroutineID retrieves and returns the current goroutine id from stack information.
 // Be very aware that, it is with low performance as it uses runtime.Stack function.
 // It is commonly used for debugging purpose.
-func GoroutineId() int {
-	buf := make([]byte, 26)
-	runtime.Stack(buf, false)
+func GoroutineID() int {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
 	match := gridRegex.FindSubmatch(buf)
+	if len(match) < 2 {
+		return 0
+	}
 	id, _ := strconv.Atoi(string(match[1]))
 	return id
 }
@@ -2,9 +2,24 @@ package gdebug
 
 import (
 	"github.com/ilylx/gconv/internal/crypto/gmd5"
+	"github.com/ilylx/gconv/internal/crypto/gsha"
 	"github.com/ilylx/gconv/internal/encoding/ghash"
 	"io/ioutil"
 	"strconv"
+	"sync"
+)
+
+const (
+	ALGORITHM_MD5    = "md5"
+	ALGORITHM_SHA1   = gsha.SHA1
+	ALGORITHM_SHA256 = gsha.SHA256
+	ALGORITHM_SHA512 = gsha.SHA512
+)
+
+var (
+	// binaryVersionHashes caches the binary versions calculated by BinVersionHash,
+	// which maps algorithm to its version string.
+	binaryVersionHashes = sync.Map{}
 )
 
 // BinVersion returns the version of current running binary.
@@ -28,3 +43,26 @@ func BinVersionMd5() string {
 	}
 	return binaryVersionMd5
 }
+
+// BinVersionHash returns the version of current running binary using given <algorithm>,
+// which can be ALGORITHM_MD5, ALGORITHM_SHA1, ALGORITHM_SHA256 or ALGORITHM_SHA512.
+// The result is cached for each algorithm.
+func BinVersionHash(algorithm string) (string, error) {
+	if v, ok := binaryVersionHashes.Load(algorithm); ok {
+		return v.(string), nil
+	}
+	var (
+		version string
+		err     error
+	)
+	if algorithm == ALGORITHM_MD5 {
+		version, err = gmd5.EncryptFile(selfPath)
+	} else {
+		version, err = gsha.EncryptFile(algorithm, selfPath)
+	}
+	if err != nil {
+		return "", err
+	}
+	binaryVersionHashes.Store(algorithm, version)
+	return version, nil
+}
@@ -5,6 +5,7 @@
 package gerror
 
 import (
+	"errors"
 	"fmt"
 )
 
@@ -20,6 +21,12 @@ type ApiCause interface {
 	Cause() error
 }
 
+// ApiUnwrap is the interface for Unwrap feature, which is compatible with stdlib errors.
+type ApiUnwrap interface {
+	Error() string // It should be an error.
+	Unwrap() error
+}
+
 // ApiCurrent is the interface for Current feature.
 type ApiCurrent interface {
 	Error() string // It should be an error.
@@ -105,14 +112,49 @@ func Wrapf(err error, format string, args ...interface{}) error {
 	}
 }
 
-// Cause returns the root cause error of <err>.
+// Cause returns the root cause error of <err>, which is the innermost error of the wrapping chain.
+// It follows the chain of this package, the errors implementing ApiCause like the ones of
+// github.com/pkg/errors, and the errors implementing Unwrap like the ones of fmt.Errorf with "%w",
+// so the sentinel errors are returned as they are and can be compared directly.
 func Cause(err error) error {
-	if err != nil {
-		if e, ok := err.(ApiCause); ok {
+	for err != nil {
+		switch e := err.(type) {
+		case *Error:
+			if e.error == nil {
+				return e
+			}
+			err = e.error
+		case ApiCause:
 			return e.Cause()
+		case ApiUnwrap:
+			next := e.Unwrap()
+			if next == nil {
+				return err
+			}
+			err = next
+		default:
+			return err
 		}
 	}
-	return err
+	return nil
+}
+
+// Unwrap returns the next level error of <err> by calling its Unwrap method,
+// which is the same as errors.Unwrap of stdlib.
+func Unwrap(err error) error {
+	return errors.Unwrap(err)
+}
+
+// Is reports whether any error in <err>'s chain matches <target>,
+// which is the same as errors.Is of stdlib.
+func Is(err, target error) bool {
+	return errors.Is(err, target)
+}
+
+// As finds the first error in <err>'s chain that matches <target>, and if so, sets
+// <target> to that error value and returns true, which is the same as errors.As of stdlib.
+func As(err error, target interface{}) bool {
+	return errors.As(err, target)
 }
 
 // Stack returns the stack callers as string.
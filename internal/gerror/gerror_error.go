@@ -2,7 +2,6 @@ package gerror
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 	"io"
 	"runtime"
@@ -46,30 +45,21 @@ func (err *Error) Error() string {
 	return err.error.Error()
 }
 
-// Cause returns the root cause error.
+// Cause returns the root cause error, which is the innermost error of the wrapping chain.
 func (err *Error) Cause() error {
 	if err == nil {
 		return nil
 	}
-	loop := err
-	for loop != nil {
-		if loop.error != nil {
-			if e, ok := loop.error.(*Error); ok {
-				// Internal Error struct.
-				loop = e
-			} else if e, ok := loop.error.(ApiCause); ok {
-				// Other Error that implements ApiCause interface.
-				return e.Cause()
-			} else {
-				return loop.error
-			}
-		} else {
-			// return loop
-			// To be compatible with Case of https://github.com/pkg/errors.
-			return errors.New(loop.text)
-		}
+	return Cause(err)
+}
+
+// Unwrap returns the wrapped error, which makes it work with errors.Is/As/Unwrap of stdlib.
+// It returns nil if there's no wrapped error.
+func (err *Error) Unwrap() error {
+	if err == nil {
+		return nil
 	}
-	return nil
+	return err.error
 }
 
 // Format formats the frame according to the fmt.Formatter interface.
@@ -23,6 +23,9 @@ func Intn(max int) int {
 	if max <= 0 {
 		return max
 	}
+	if IsSecure() {
+		return SecureIntn(max)
+	}
 	n := int(binary.LittleEndian.Uint32(<-bufferChan)) % max
 	if (max > 0 && n < 0) || (max < 0 && n > 0) {
 		return -n
@@ -35,6 +38,9 @@ func B(n int) []byte {
 	if n <= 0 {
 		return nil
 	}
+	if IsSecure() {
+		return SecureB(n)
+	}
 	i := 0
 	b := make([]byte, n)
 	for {
@@ -77,6 +83,9 @@ func S(n int, symbols ...bool) string {
 	if n <= 0 {
 		return ""
 	}
+	if IsSecure() {
+		return SecureS(n, symbols...)
+	}
 	var (
 		b           = make([]byte, n)
 		numberBytes = B(n)
@@ -97,6 +106,9 @@ func Str(s string, n int) string {
 	if n <= 0 {
 		return ""
 	}
+	if IsSecure() {
+		return SecureStr(s, n)
+	}
 	var (
 		b     = make([]rune, n)
 		runes = []rune(s)
@@ -119,6 +131,9 @@ func Digits(n int) string {
 	if n <= 0 {
 		return ""
 	}
+	if IsSecure() {
+		return secureChars(digits, n)
+	}
 	var (
 		b           = make([]byte, n)
 		numberBytes = B(n)
@@ -134,6 +149,9 @@ func Letters(n int) string {
 	if n <= 0 {
 		return ""
 	}
+	if IsSecure() {
+		return secureChars(letters, n)
+	}
 	var (
 		b           = make([]byte, n)
 		numberBytes = B(n)
@@ -149,6 +167,9 @@ func Symbols(n int) string {
 	if n <= 0 {
 		return ""
 	}
+	if IsSecure() {
+		return secureChars(symbols, n)
+	}
 	var (
 		b           = make([]byte, n)
 		numberBytes = B(n)
@@ -171,6 +192,14 @@ func Perm(n int) []int {
 	return m
 }
 
+// Shuffle randomizes the order of elements using <swap>,
+// <n> is the number of elements and <swap> swaps the elements with indexes <i> and <j>.
+func Shuffle(n int, swap func(i, j int)) {
+	for i := n - 1; i > 0; i-- {
+		swap(i, Intn(i+1))
+	}
+}
+
 // Meet randomly calculate whether the given probability <num>/<total> is met.
 func Meet(num, total int) bool {
 	return Intn(total) < num
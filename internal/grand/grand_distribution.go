@@ -0,0 +1,90 @@
+package grand
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+)
+
+// Float64 returns a uniformly distributed float64 number which is between 0 and 1: [0.0, 1.0).
+func Float64() float64 {
+	// It uses the high 53 bits of a random uint64, which is the precision of float64.
+	return float64(binary.LittleEndian.Uint64(B(8))>>11) / (1 << 53)
+}
+
+// NormFloat64 returns a normally distributed float64 number with given mean <mean>
+// and standard deviation <stddev>, using the Box-Muller transform.
+//
+// Eg:
+//
+//	// Response time of about 100ms, which mostly lies in [70ms, 130ms].
+//	ms := grand.NormFloat64(100, 10)
+func NormFloat64(mean, stddev float64) float64 {
+	// The <u1> should not be 0, as log(0) is -Inf.
+	u1 := 1 - Float64()
+	u2 := Float64()
+	return mean + stddev*math.Sqrt(-2*math.Log(u1))*math.Cos(2*math.Pi*u2)
+}
+
+// ExpFloat64 returns an exponentially distributed float64 number in range (0, +math.MaxFloat64]
+// with given rate parameter <rate>, whose mean is 1/<rate>.
+// The <rate> can only be greater than 0, or else it returns 0.
+//
+// It is usually used for producing the intervals between independent events,
+// like the arrivals of requests in load testing.
+func ExpFloat64(rate float64) float64 {
+	if rate <= 0 {
+		return 0
+	}
+	return -math.Log(1-Float64()) / rate
+}
+
+// D returns a uniformly distributed random duration between min and max: [min, max].
+func D(min, max time.Duration) time.Duration {
+	if min >= max {
+		return min
+	}
+	return min + time.Duration(Float64()*float64(max-min+1))
+}
+
+// Jitter returns a random duration which is uniformly distributed in
+// [d - d*factor, d + d*factor]. The <factor> is usually between 0 and 1,
+// and the result is never less than 0.
+//
+// Eg:
+//
+//	// A duration between 0.8s and 1.2s.
+//	d := grand.Jitter(time.Second, 0.2)
+func Jitter(d time.Duration, factor float64) time.Duration {
+	if factor <= 0 || d <= 0 {
+		return d
+	}
+	delta := float64(d) * factor
+	return clampDuration(float64(d) - delta + Float64()*2*delta)
+}
+
+// NormDuration returns a normally distributed random duration with given mean <mean>
+// and standard deviation <stddev>. The result is never less than 0.
+func NormDuration(mean, stddev time.Duration) time.Duration {
+	return clampDuration(NormFloat64(float64(mean), float64(stddev)))
+}
+
+// ExpDuration returns an exponentially distributed random duration with given mean <mean>.
+// The <mean> can only be greater than 0, or else it returns 0.
+func ExpDuration(mean time.Duration) time.Duration {
+	if mean <= 0 {
+		return 0
+	}
+	return clampDuration(ExpFloat64(1) * float64(mean))
+}
+
+// clampDuration converts float64 <f> to duration in range [0, math.MaxInt64].
+func clampDuration(f float64) time.Duration {
+	if f <= 0 {
+		return 0
+	}
+	if f >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(f)
+}
@@ -0,0 +1,55 @@
+package grand
+
+// StrFrom randomly picks and returns <n> count of chars from given character set <charset>.
+// It also supports unicode character set like Chinese/Russian/Japanese, etc.
+// The repeated chars in <charset> have higher probability to be picked.
+//
+// Eg:
+//
+//	code := grand.StrFrom("ABCDEFGHJKLMNPQRSTUVWXYZ23456789", 8)
+func StrFrom(charset string, n int) string {
+	return Str(charset, n)
+}
+
+// StrPattern returns a random string generated by given <pattern>,
+// in which the placeholders are replaced with random chars as follows:
+// A: an upper case letter;
+// a: a lower case letter;
+// #: a digit;
+// *: a letter or digit;
+// ?: a symbol;
+// \: escapes the next char, which makes it a literal char.
+// The other chars in <pattern> are kept as they are.
+//
+// Eg:
+//
+//	grand.StrPattern("AA-####-aa") // "QK-2046-xe"
+//	grand.StrPattern(`\A#-***`)    // "A7-b2Y"
+func StrPattern(pattern string) string {
+	var (
+		runes  = []rune(pattern)
+		result = make([]rune, 0, len(runes))
+	)
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case 'A':
+			result = append(result, rune(letters[26+Intn(26)]))
+		case 'a':
+			result = append(result, rune(letters[Intn(26)]))
+		case '#':
+			result = append(result, rune(digits[Intn(len(digits))]))
+		case '*':
+			result = append(result, rune(characters[Intn(62)]))
+		case '?':
+			result = append(result, rune(symbols[Intn(len(symbols))]))
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+			}
+			result = append(result, runes[i])
+		default:
+			result = append(result, runes[i])
+		}
+	}
+	return string(result)
+}
@@ -0,0 +1,129 @@
+package grand
+
+import (
+	"crypto/rand"
+	"math/big"
+	"sync/atomic"
+	"unsafe"
+)
+
+var (
+	// secureEnabled marks whether the secure mode is enabled, which is 1 if enabled.
+	secureEnabled int32
+)
+
+// SetSecure enables or disables the secure mode of the package. In secure mode, all the
+// functions of the package read random bytes directly from crypto/rand instead of the
+// shared buffer, and produce uniformly distributed results without modulo bias, which is
+// suitable for security-relevant values like tokens and passwords.
+//
+// Note that the secure mode has lower performance. The Secure* functions can be used for
+// the secure random values without enabling the secure mode of the package.
+func SetSecure(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&secureEnabled, 1)
+	} else {
+		atomic.StoreInt32(&secureEnabled, 0)
+	}
+}
+
+// IsSecure checks and returns whether the secure mode is enabled.
+func IsSecure() bool {
+	return atomic.LoadInt32(&secureEnabled) == 1
+}
+
+// SecureIntn returns a uniformly distributed int number which is between 0 and max: [0, max),
+// which is generated using crypto/rand.
+// The <max> can only be greater than 0, or else it returns <max> directly.
+func SecureIntn(max int) int {
+	if max <= 0 {
+		return max
+	}
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(max)))
+	if err != nil {
+		panic(err)
+	}
+	return int(n.Int64())
+}
+
+// SecureB retrieves and returns random bytes of given length <n> using crypto/rand.
+func SecureB(n int) []byte {
+	if n <= 0 {
+		return nil
+	}
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// SecureS returns a random string which contains digits and letters, and its length is <n>,
+// which is generated using crypto/rand without modulo bias.
+// The optional parameter <symbols> specifies whether the result could contain symbols,
+// which is false in default.
+func SecureS(n int, symbols ...bool) string {
+	if len(symbols) > 0 && symbols[0] {
+		return secureChars(characters, n)
+	}
+	return secureChars(characters[:62], n)
+}
+
+// SecureStr randomly picks and returns <n> count of chars from given string <s>
+// using crypto/rand without modulo bias.
+// It also supports unicode string like Chinese/Russian/Japanese, etc.
+func SecureStr(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	var (
+		b     = make([]rune, n)
+		runes = []rune(s)
+	)
+	if len(runes) == 0 {
+		return ""
+	}
+	for i, index := range secureIndexes(len(runes), n) {
+		b[i] = runes[index]
+	}
+	return string(b)
+}
+
+// secureChars randomly picks and returns <n> count of bytes from given ASCII string <chars>
+// using crypto/rand without modulo bias.
+func secureChars(chars string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	b := make([]byte, n)
+	for i, index := range secureIndexes(len(chars), n) {
+		b[i] = chars[index]
+	}
+	return *(*string)(unsafe.Pointer(&b))
+}
+
+// secureIndexes returns <n> uniformly distributed random indexes between 0 and size: [0, size),
+// using crypto/rand. It rejects the random bytes causing modulo bias.
+func secureIndexes(size int, n int) []int {
+	indexes := make([]int, 0, n)
+	if size > 256 {
+		for len(indexes) < n {
+			indexes = append(indexes, SecureIntn(size))
+		}
+		return indexes
+	}
+	// The bytes not less than <limit> are rejected,
+	// so every index has the same probability.
+	limit := 256 - 256%size
+	for len(indexes) < n {
+		for _, v := range SecureB(n - len(indexes) + 8) {
+			if int(v) < limit {
+				indexes = append(indexes, int(v)%size)
+				if len(indexes) == n {
+					break
+				}
+			}
+		}
+	}
+	return indexes
+}
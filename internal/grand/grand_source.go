@@ -0,0 +1,169 @@
+package grand
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// Source is an isolated pseudo-random generator with given seed, which produces the same
+// sequence of random values for the same seed. It is usually used in testing for the
+// reproducible results, and it should not be used for security-relevant values.
+//
+// It is concurrent-safe.
+type Source struct {
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// NewSource creates and returns an isolated pseudo-random generator with given <seed>.
+//
+// Eg:
+//
+//	source := grand.NewSource(1)
+//	array.Shuffle(source)
+func NewSource(seed int64) *Source {
+	return &Source{
+		rand: rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Intn returns a int number which is between 0 and max: [0, max).
+// The <max> can only be greater than 0, or else it returns <max> directly.
+func (s *Source) Intn(max int) int {
+	if max <= 0 {
+		return max
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rand.Intn(max)
+}
+
+// B retrieves and returns random bytes of given length <n>.
+func (s *Source) B(n int) []byte {
+	if n <= 0 {
+		return nil
+	}
+	b := make([]byte, n)
+	s.mu.Lock()
+	s.rand.Read(b)
+	s.mu.Unlock()
+	return b
+}
+
+// N returns a random int between min and max: [min, max].
+// The <min> and <max> also support negative numbers.
+func (s *Source) N(min, max int) int {
+	if min >= max {
+		return min
+	}
+	return s.Intn(max-min+1) + min
+}
+
+// S returns a random string which contains digits and letters, and its length is <n>.
+// The optional parameter <symbols> specifies whether the result could contain symbols,
+// which is false in default.
+func (s *Source) S(n int, symbols ...bool) string {
+	if len(symbols) > 0 && symbols[0] {
+		return s.chars(characters, n)
+	}
+	return s.chars(characters[:62], n)
+}
+
+// Str randomly picks and returns <n> count of chars from given string <str>.
+// It also supports unicode string like Chinese/Russian/Japanese, etc.
+func (s *Source) Str(str string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	runes := []rune(str)
+	if len(runes) == 0 {
+		return ""
+	}
+	b := make([]rune, n)
+	s.mu.Lock()
+	for i := range b {
+		b[i] = runes[s.rand.Intn(len(runes))]
+	}
+	s.mu.Unlock()
+	return string(b)
+}
+
+// Digits returns a random string which contains only digits, and its length is <n>.
+func (s *Source) Digits(n int) string {
+	return s.chars(digits, n)
+}
+
+// Letters returns a random string which contains only letters, and its length is <n>.
+func (s *Source) Letters(n int) string {
+	return s.chars(letters, n)
+}
+
+// Symbols returns a random string which contains only symbols, and its length is <n>.
+func (s *Source) Symbols(n int) string {
+	return s.chars(symbols, n)
+}
+
+// Perm returns, as a slice of n int numbers, a pseudo-random permutation of the integers [0,n).
+func (s *Source) Perm(n int) []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rand.Perm(n)
+}
+
+// Shuffle pseudo-randomizes the order of elements using <swap>,
+// <n> is the number of elements and <swap> swaps the elements with indexes <i> and <j>.
+func (s *Source) Shuffle(n int, swap func(i, j int)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rand.Shuffle(n, swap)
+}
+
+// Meet randomly calculate whether the given probability <num>/<total> is met.
+func (s *Source) Meet(num, total int) bool {
+	return s.Intn(total) < num
+}
+
+// MeetProb randomly calculate whether the given probability is met.
+func (s *Source) MeetProb(prob float32) bool {
+	return s.Intn(1e7) < int(prob*1e7)
+}
+
+// Float64 returns a uniformly distributed float64 number which is between 0 and 1: [0.0, 1.0).
+func (s *Source) Float64() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rand.Float64()
+}
+
+// NormFloat64 returns a normally distributed float64 number with given mean <mean>
+// and standard deviation <stddev>.
+func (s *Source) NormFloat64(mean, stddev float64) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return mean + stddev*s.rand.NormFloat64()
+}
+
+// ExpFloat64 returns an exponentially distributed float64 number with given rate parameter
+// <rate>, whose mean is 1/<rate>. The <rate> can only be greater than 0, or else it returns 0.
+func (s *Source) ExpFloat64(rate float64) float64 {
+	if rate <= 0 {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rand.ExpFloat64() / rate
+}
+
+// chars randomly picks and returns <n> count of bytes from given ASCII string <chars>.
+func (s *Source) chars(chars string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	b := make([]byte, n)
+	s.mu.Lock()
+	for i := range b {
+		b[i] = chars[s.rand.Intn(len(chars))]
+	}
+	s.mu.Unlock()
+	return string(b)
+}
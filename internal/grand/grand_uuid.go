@@ -0,0 +1,92 @@
+package grand
+
+import (
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+var (
+	// uuid7Mu protects the fields for generating monotonic UUID version 7.
+	uuid7Mu sync.Mutex
+	// uuid7LastMilli is the unix timestamp in milliseconds of the last generated UUID version 7.
+	uuid7LastMilli int64
+	// uuid7Sequence is the 12 bits sequence in the same millisecond of the last generated UUID version 7.
+	uuid7Sequence uint16
+)
+
+// UUID4 returns a random UUID version 4 string as RFC 4122 specifies,
+// eg: 7c9e6679-7425-40de-944b-e07fc1f90ae7.
+func UUID4() string {
+	return formatUUID(UUID4Bytes())
+}
+
+// UUID4Bytes returns a random UUID version 4 in 16 bytes as RFC 4122 specifies.
+func UUID4Bytes() (uuid [16]byte) {
+	copy(uuid[:], B(16))
+	uuid[6] = (uuid[6] & 0x0f) | 0x40 // Version 4.
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // Variant RFC 4122.
+	return uuid
+}
+
+// UUID7 returns a time-ordered UUID version 7 string as RFC 9562 specifies,
+// eg: 01890a5d-ac96-774b-bcce-b302099a8057.
+//
+// The UUIDs generated by the same process are monotonically increasing,
+// which makes it suitable for database keys and request IDs.
+func UUID7() string {
+	return formatUUID(UUID7Bytes())
+}
+
+// UUID7Bytes returns a time-ordered UUID version 7 in 16 bytes as RFC 9562 specifies.
+func UUID7Bytes() (uuid [16]byte) {
+	milli, sequence := nextUUID7Sequence()
+	copy(uuid[8:], B(8))
+	uuid[0] = byte(milli >> 40)
+	uuid[1] = byte(milli >> 32)
+	uuid[2] = byte(milli >> 24)
+	uuid[3] = byte(milli >> 16)
+	uuid[4] = byte(milli >> 8)
+	uuid[5] = byte(milli)
+	uuid[6] = 0x70 | byte(sequence>>8) // Version 7.
+	uuid[7] = byte(sequence)
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // Variant RFC 9562.
+	return uuid
+}
+
+// nextUUID7Sequence returns the unix timestamp in milliseconds and the 12 bits sequence
+// for the next UUID version 7. The sequence starts from a random value in each millisecond
+// and increases in the same millisecond, and the timestamp is moved forward if the sequence
+// overflows or the clock goes backwards, so the UUIDs are always increasing.
+func nextUUID7Sequence() (int64, uint16) {
+	uuid7Mu.Lock()
+	defer uuid7Mu.Unlock()
+	milli := time.Now().UnixNano() / 1e6
+	if milli > uuid7LastMilli {
+		uuid7LastMilli = milli
+		// The highest bit is left 0 for more increasing space in the same millisecond.
+		uuid7Sequence = uint16(Intn(0x800))
+	} else {
+		uuid7Sequence++
+		if uuid7Sequence > 0xfff {
+			uuid7LastMilli++
+			uuid7Sequence = 0
+		}
+	}
+	return uuid7LastMilli, uuid7Sequence
+}
+
+// formatUUID formats <uuid> to string in form of xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx.
+func formatUUID(uuid [16]byte) string {
+	b := make([]byte, 36)
+	hex.Encode(b[0:8], uuid[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], uuid[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], uuid[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], uuid[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], uuid[10:])
+	return string(b)
+}
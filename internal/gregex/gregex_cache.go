@@ -1,19 +1,75 @@
 package gregex
 
 import (
+	"container/list"
 	"regexp"
 	"sync"
 )
 
+const (
+	// DEFAULT_CACHE_CAP is the default max count of the compiled regular expression objects in cache.
+	DEFAULT_CACHE_CAP = 10000
+)
+
+// CacheStats is the statistics of the cache for compiled regular expression objects.
+type CacheStats struct {
+	Size      int   // Current count of the cached objects.
+	Cap       int   // Max count of the cached objects.
+	Hits      int64 // Count of retrievals hitting the cache.
+	Misses    int64 // Count of retrievals missing the cache, which compile the pattern.
+	Evictions int64 // Count of the cached objects evicted by LRU.
+}
+
+// regexCacheItem is the item of the cache list.
+type regexCacheItem struct {
+	pattern string
+	regex   *regexp.Regexp
+}
+
 var (
-	regexMu = sync.RWMutex{}
+	regexMu = sync.Mutex{}
 	// Cache for regex object.
 	// Note that:
-	// 1. It uses sync.RWMutex ensuring the concurrent safety.
-	// 2. There's no expiring logic for this map.
-	regexMap = make(map[string]*regexp.Regexp)
+	// 1. It uses sync.Mutex ensuring the concurrent safety.
+	// 2. It is a LRU cache, the least recently used object is evicted if the cache is full.
+	regexMap  = make(map[string]*list.Element)
+	regexList = list.New()
+	// regexCacheCap is the max count of the cached objects.
+	regexCacheCap = DEFAULT_CACHE_CAP
+	// regexCacheStats is the statistics of the cache.
+	regexCacheStats CacheStats
 )
 
+// SetCacheCap sets the max count of the cached compiled regular expression objects.
+// The least recently used objects are evicted if the count exceeds <cap>.
+// The cache is disabled if <cap> <= 0.
+func SetCacheCap(cap int) {
+	if cap < 0 {
+		cap = 0
+	}
+	regexMu.Lock()
+	regexCacheCap = cap
+	evictRegexWithoutLock()
+	regexMu.Unlock()
+}
+
+// GetCacheCap returns the max count of the cached compiled regular expression objects.
+func GetCacheCap() int {
+	regexMu.Lock()
+	defer regexMu.Unlock()
+	return regexCacheCap
+}
+
+// Stats returns the statistics of the cache for compiled regular expression objects.
+func Stats() CacheStats {
+	regexMu.Lock()
+	defer regexMu.Unlock()
+	stats := regexCacheStats
+	stats.Size = regexList.Len()
+	stats.Cap = regexCacheCap
+	return stats
+}
+
 // getRegexp returns *regexp.Regexp object with given <pattern>.
 // It uses cache to enhance the performance for compiling regular expression pattern,
 // which means, it will return the same *regexp.Regexp object with the same regular
@@ -21,10 +77,16 @@ var (
 //
 // It is concurrent-safe for multiple goroutines.
 func getRegexp(pattern string) (regex *regexp.Regexp, err error) {
-	// Retrieve the regular expression object using reading lock.
-	regexMu.RLock()
-	regex = regexMap[pattern]
-	regexMu.RUnlock()
+	// Retrieve the regular expression object and mark it recently used.
+	regexMu.Lock()
+	if element, ok := regexMap[pattern]; ok {
+		regexList.MoveToFront(element)
+		regexCacheStats.Hits++
+		regex = element.Value.(*regexCacheItem).regex
+	} else {
+		regexCacheStats.Misses++
+	}
+	regexMu.Unlock()
 	if regex != nil {
 		return
 	}
@@ -34,9 +96,32 @@ func getRegexp(pattern string) (regex *regexp.Regexp, err error) {
 	if err != nil {
 		return
 	}
-	// Cache the result object using writing lock.
+	// Cache the result object.
 	regexMu.Lock()
-	regexMap[pattern] = regex
+	if regexCacheCap > 0 {
+		if element, ok := regexMap[pattern]; ok {
+			// It is cached by another goroutine in the meantime.
+			regexList.MoveToFront(element)
+			regex = element.Value.(*regexCacheItem).regex
+		} else {
+			regexMap[pattern] = regexList.PushFront(&regexCacheItem{
+				pattern: pattern,
+				regex:   regex,
+			})
+			evictRegexWithoutLock()
+		}
+	}
 	regexMu.Unlock()
 	return
 }
+
+// evictRegexWithoutLock evicts the least recently used objects until the count
+// does not exceed the cap.
+func evictRegexWithoutLock() {
+	for regexList.Len() > regexCacheCap {
+		element := regexList.Back()
+		regexList.Remove(element)
+		delete(regexMap, element.Value.(*regexCacheItem).pattern)
+		regexCacheStats.Evictions++
+	}
+}
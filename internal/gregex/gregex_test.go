@@ -79,3 +79,81 @@ func Test_MatchString(t *testing.T) {
 	_, err = gregex.MatchString(PatternErr, s)
 	assert.NotEqual(t, err, nil)
 }
+
+func Test_Cache_EvictionOrder(t *testing.T) {
+	defer gregex.SetCacheCap(gregex.DEFAULT_CACHE_CAP)
+	gregex.SetCacheCap(2)
+	assert.Equal(t, gregex.GetCacheCap(), 2)
+
+	gregex.IsMatchString(`^lru-a$`, "lru-a")
+	gregex.IsMatchString(`^lru-b$`, "lru-b")
+	// Using "a" makes "b" the least recently used one, which is evicted by "c".
+	gregex.IsMatchString(`^lru-a$`, "lru-a")
+	gregex.IsMatchString(`^lru-c$`, "lru-c")
+	assert.Equal(t, gregex.Stats().Size, 2)
+
+	before := gregex.Stats()
+	gregex.IsMatchString(`^lru-a$`, "lru-a")
+	gregex.IsMatchString(`^lru-c$`, "lru-c")
+	after := gregex.Stats()
+	assert.Equal(t, after.Hits-before.Hits, int64(2))
+	assert.Equal(t, after.Misses-before.Misses, int64(0))
+
+	before = after
+	gregex.IsMatchString(`^lru-b$`, "lru-b")
+	after = gregex.Stats()
+	assert.Equal(t, after.Misses-before.Misses, int64(1))
+	assert.Equal(t, after.Evictions-before.Evictions, int64(1))
+}
+
+func Test_Cache_Disabled(t *testing.T) {
+	defer gregex.SetCacheCap(gregex.DEFAULT_CACHE_CAP)
+	gregex.IsMatchString(`^disabled$`, "disabled")
+	gregex.SetCacheCap(0)
+	stats := gregex.Stats()
+	assert.Equal(t, stats.Size, 0)
+	assert.Equal(t, stats.Cap, 0)
+
+	before := gregex.Stats()
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, gregex.IsMatchString(`^disabled$`, "disabled"), true)
+	}
+	after := gregex.Stats()
+	assert.Equal(t, after.Size, 0)
+	assert.Equal(t, after.Hits-before.Hits, int64(0))
+	assert.Equal(t, after.Misses-before.Misses, int64(3))
+	assert.Equal(t, after.Evictions-before.Evictions, int64(0))
+
+	// Negative cap also disables the cache.
+	gregex.SetCacheCap(-1)
+	assert.Equal(t, gregex.GetCacheCap(), 0)
+}
+
+func Test_Cache_Stats(t *testing.T) {
+	defer gregex.SetCacheCap(gregex.DEFAULT_CACHE_CAP)
+	gregex.SetCacheCap(3)
+	before := gregex.Stats()
+	for i := 0; i < 4; i++ {
+		gregex.IsMatchString(`^stats-a$`, "stats-a")
+	}
+	gregex.IsMatchString(`^stats-b$`, "stats-b")
+	gregex.IsMatchString(`^stats-c$`, "stats-c")
+	gregex.IsMatchString(`^stats-d$`, "stats-d")
+	// Invalid pattern counts as a miss but is never cached.
+	gregex.IsMatchString(PatternErr, "stats")
+	after := gregex.Stats()
+	assert.Equal(t, after.Hits-before.Hits, int64(3))
+	assert.Equal(t, after.Misses-before.Misses, int64(5))
+	assert.Equal(t, after.Evictions-before.Evictions, int64(1))
+	assert.Equal(t, after.Size, 3)
+	assert.Equal(t, after.Cap, 3)
+
+	// Shrinking the cap evicts the least recently used objects.
+	gregex.SetCacheCap(1)
+	stats := gregex.Stats()
+	assert.Equal(t, stats.Size, 1)
+	assert.Equal(t, stats.Evictions-after.Evictions, int64(2))
+	before = stats
+	gregex.IsMatchString(`^stats-d$`, "stats-d")
+	assert.Equal(t, gregex.Stats().Hits-before.Hits, int64(1))
+}
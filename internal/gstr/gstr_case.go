@@ -8,34 +8,39 @@
 //   | DelimitedScreamingCase(s, '.')    | ANY.KIND.OF.STRING |
 //   | CamelCase(s)                      | AnyKindOfString    |
 //   | CamelLowerCase(s)                 | anyKindOfString    |
+//   | PascalCase(s)                     | AnyKindOfString    |
+//   | ScreamingSnakeCase(s)             | ANY_KIND_OF_STRING |
 //   | SnakeFirstUpperCase(RGBCodeMd5)   | rgb_code_md5       |
 
 package gstr
 
 import (
+	"github.com/ilylx/gconv/internal/utils"
 	"regexp"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 var (
-	numberSequence      = regexp.MustCompile(`([a-zA-Z]{0,1})(\d+)([a-zA-Z]{0,1})`)
 	firstCamelCaseStart = regexp.MustCompile(`([A-Z]+)([A-Z]?[_a-z\d]+)|$`)
 	firstCamelCaseEnd   = regexp.MustCompile(`([\w\W]*?)([_]?[A-Z]+)$`)
 )
 
-// CamelCase converts a string to CamelCase.
+// CamelCase converts a string to CamelCase, which is the same as PascalCase.
+// The acronyms are kept as they are, eg: "json_data" -> "JsonData", "JSON data" -> "JSONData".
 func CamelCase(s string) string {
 	return toCamelInitCase(s, true)
 }
 
+// PascalCase converts a string to PascalCase, eg: "any_kind_of_string" -> "AnyKindOfString".
+func PascalCase(s string) string {
+	return toCamelInitCase(s, true)
+}
+
 // CamelLowerCase converts a string to lowerCamelCase.
+// The first word is converted to lower case entirely, eg: "JSONData" -> "jsonData".
 func CamelLowerCase(s string) string {
-	if s == "" {
-		return s
-	}
-	if r := rune(s[0]); r >= 'A' && r <= 'Z' {
-		s = strings.ToLower(string(r)) + s[1:]
-	}
 	return toCamelInitCase(s, false)
 }
 
@@ -49,6 +54,12 @@ func SnakeScreamingCase(s string) string {
 	return DelimitedScreamingCase(s, '_', true)
 }
 
+// ScreamingSnakeCase converts a string to SCREAMING_SNAKE_CASE,
+// which is the same as SnakeScreamingCase.
+func ScreamingSnakeCase(s string) string {
+	return DelimitedScreamingCase(s, '_', true)
+}
+
 // SnakeFirstUpperCase converts a string from RGBCodeMd5 to rgb_code_md5.
 // The length of word should not be too long
 // TODO for efficiency should change regexp to traversing string in future
@@ -95,85 +106,32 @@ func DelimitedCase(s string, del uint8) string {
 
 // DelimitedScreamingCase converts a string to DELIMITED.SCREAMING.CASE or delimited.screaming.case.
 func DelimitedScreamingCase(s string, del uint8, screaming bool) string {
-	s = addWordBoundariesToNumbers(s)
-	s = strings.Trim(s, " ")
-	n := ""
-	for i, v := range s {
-		// treat acronyms as words, eg for JSONData -> JSON is a whole word
-		nextCaseIsChanged := false
-		if i+1 < len(s) {
-			next := s[i+1]
-			if (v >= 'A' && v <= 'Z' && next >= 'a' && next <= 'z') || (v >= 'a' && v <= 'z' && next >= 'A' && next <= 'Z') {
-				nextCaseIsChanged = true
-			}
-		}
-
-		if i > 0 && n[len(n)-1] != del && nextCaseIsChanged {
-			// add underscore if next letter case type is changed
-			if v >= 'A' && v <= 'Z' {
-				n += string(del) + string(v)
-			} else if v >= 'a' && v <= 'z' {
-				n += string(v) + string(del)
-			}
-		} else if v == ' ' || v == '_' || v == '-' || v == '.' {
-			// replace spaces/underscores with delimiters
-			n += string(del)
+	words := utils.SplitCaseWords(s)
+	for i, word := range words {
+		if screaming {
+			words[i] = strings.ToUpper(word)
 		} else {
-			n = n + string(v)
+			words[i] = strings.ToLower(word)
 		}
 	}
-
-	if screaming {
-		n = strings.ToUpper(n)
-	} else {
-		n = strings.ToLower(n)
-	}
-	return n
-}
-
-func addWordBoundariesToNumbers(s string) string {
-	r := numberSequence.ReplaceAllFunc([]byte(s), func(bytes []byte) []byte {
-		var result []byte
-		match := numberSequence.FindSubmatch(bytes)
-		if len(match[1]) > 0 {
-			result = append(result, match[1]...)
-			result = append(result, []byte(" ")...)
-		}
-		result = append(result, match[2]...)
-		if len(match[3]) > 0 {
-			result = append(result, []byte(" ")...)
-			result = append(result, match[3]...)
-		}
-		return result
-	})
-	return string(r)
+	return strings.Join(words, string(del))
 }
 
-// Converts a string to CamelCase
+// toCamelInitCase converts a string to CamelCase if <initCase> is true, or else lowerCamelCase.
 func toCamelInitCase(s string, initCase bool) string {
-	s = addWordBoundariesToNumbers(s)
-	s = strings.Trim(s, " ")
-	n := ""
-	capNext := initCase
-	for _, v := range s {
-		if v >= 'A' && v <= 'Z' {
-			n += string(v)
-		}
-		if v >= '0' && v <= '9' {
-			n += string(v)
-		}
-		if v >= 'a' && v <= 'z' {
-			if capNext {
-				n += strings.ToUpper(string(v))
-			} else {
-				n += string(v)
-			}
-		}
-		if v == '_' || v == ' ' || v == '-' || v == '.' {
-			capNext = true
-		} else {
-			capNext = false
+	var (
+		words   = utils.SplitCaseWords(s)
+		builder = strings.Builder{}
+	)
+	builder.Grow(len(s))
+	for i, word := range words {
+		if i == 0 && !initCase {
+			builder.WriteString(strings.ToLower(word))
+			continue
 		}
+		r, size := utf8.DecodeRuneInString(word)
+		builder.WriteRune(unicode.ToUpper(r))
+		builder.WriteString(word[size:])
 	}
-	return n
+	return builder.String()
 }
@@ -0,0 +1,93 @@
+package gstr
+
+// Jaro calculates the Jaro similarity of two strings, which is in range of [0, 1],
+// 1 means the two strings are the same and 0 means there's no similarity.
+// It is unicode-aware which compares the strings by runes.
+// See https://en.wikipedia.org/wiki/Jaro%E2%80%93Winkler_distance.
+func Jaro(str1, str2 string) float64 {
+	var (
+		r1 = []rune(str1)
+		r2 = []rune(str2)
+		l1 = len(r1)
+		l2 = len(r2)
+	)
+	if l1 == 0 && l2 == 0 {
+		return 1
+	}
+	if l1 == 0 || l2 == 0 {
+		return 0
+	}
+	// The matching runes should be not farther than <window>.
+	window := l1
+	if l2 > window {
+		window = l2
+	}
+	window = window/2 - 1
+	if window < 0 {
+		window = 0
+	}
+	var (
+		matched1 = make([]bool, l1)
+		matched2 = make([]bool, l2)
+		matches  = 0
+	)
+	for i := 0; i < l1; i++ {
+		start, end := i-window, i+window+1
+		if start < 0 {
+			start = 0
+		}
+		if end > l2 {
+			end = l2
+		}
+		for j := start; j < end; j++ {
+			if !matched2[j] && r1[i] == r2[j] {
+				matched1[i] = true
+				matched2[j] = true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+	// Count the transpositions, which are the matching runes in different order.
+	transpositions := 0
+	for i, j := 0, 0; i < l1; i++ {
+		if !matched1[i] {
+			continue
+		}
+		for !matched2[j] {
+			j++
+		}
+		if r1[i] != r2[j] {
+			transpositions++
+		}
+		j++
+	}
+	m := float64(matches)
+	return (m/float64(l1) + m/float64(l2) + (m-float64(transpositions/2))/m) / 3
+}
+
+// JaroWinkler calculates the Jaro-Winkler similarity of two strings, which is in range of [0, 1].
+// It gives more favorable ratings to strings with common prefix than Jaro, which makes it
+// suitable for comparing short strings like names, config keys and command arguments.
+// The optional parameter <prefixScale> specifies the scaling factor for the common prefix,
+// which is 0.1 in default and should not exceed 0.25.
+func JaroWinkler(str1, str2 string, prefixScale ...float64) float64 {
+	scale := 0.1
+	if len(prefixScale) > 0 {
+		scale = prefixScale[0]
+	}
+	similarity := Jaro(str1, str2)
+	var (
+		r1     = []rune(str1)
+		r2     = []rune(str2)
+		prefix = 0
+	)
+	// The common prefix is up to 4 runes.
+	for prefix < 4 && prefix < len(r1) && prefix < len(r2) && r1[prefix] == r2[prefix] {
+		prefix++
+	}
+	return similarity + float64(prefix)*scale*(1-similarity)
+}
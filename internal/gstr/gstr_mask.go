@@ -0,0 +1,82 @@
+package gstr
+
+import (
+	"strings"
+)
+
+const (
+	// defaultMaskChar is the default char for masking.
+	defaultMaskChar = "*"
+)
+
+// Mask replaces each char in range [<start>, <end>) of string <str> with <maskChar>,
+// which is "*" if it is empty. It considers parameter <str> as unicode string.
+// The negative <start> or <end> counts from the end of <str>, and the out of range
+// <start> or <end> is adjusted to the border of <str>.
+//
+// Eg:
+//
+//	gstr.Mask("13800138000", 3, -4, "*") // "138****8000"
+//	gstr.Mask("张三丰", 1, 3, "*")        // "张**"
+func Mask(str string, start, end int, maskChar string) string {
+	var (
+		runes  = []rune(str)
+		length = len(runes)
+	)
+	if start < 0 {
+		start += length
+	}
+	if end < 0 {
+		end += length
+	}
+	if start < 0 {
+		start = 0
+	}
+	if end > length {
+		end = length
+	}
+	if start >= end {
+		return str
+	}
+	if maskChar == "" {
+		maskChar = defaultMaskChar
+	}
+	return string(runes[:start]) + strings.Repeat(maskChar, end-start) + string(runes[end:])
+}
+
+// MaskPhone masks the middle part of phone number <phone>, which keeps the first 3 and
+// last 4 chars for phone number not shorter than 11 chars, eg: "13800138000" -> "138****8000",
+// or else keeps about the first and last quarter of it.
+func MaskPhone(phone string) string {
+	length := LenRune(phone)
+	if length >= 11 {
+		return Mask(phone, 3, -4, defaultMaskChar)
+	}
+	return Mask(phone, length/4, length-length/4, defaultMaskChar)
+}
+
+// MaskEmail masks the name part of email address <email>, which keeps the first and last
+// chars of the name part, eg: "johnsmith@example.com" -> "j*******h@example.com".
+// The name part is masked except the first char if it is not longer than 2 chars.
+func MaskEmail(email string) string {
+	pos := strings.LastIndex(email, "@")
+	if pos < 0 {
+		return MaskPhone(email)
+	}
+	name := email[:pos]
+	if LenRune(name) <= 2 {
+		return Mask(name, 1, LenRune(name), defaultMaskChar) + email[pos:]
+	}
+	return Mask(name, 1, -1, defaultMaskChar) + email[pos:]
+}
+
+// MaskIDCard masks the middle part of identity card number <id>, which keeps the first 3 and
+// last 4 chars, eg: "110101199003071234" -> "110***********1234".
+// It keeps about the first and last quarter of it if it is shorter than 10 chars.
+func MaskIDCard(id string) string {
+	length := LenRune(id)
+	if length >= 10 {
+		return Mask(id, 3, -4, defaultMaskChar)
+	}
+	return Mask(id, length/4, length-length/4, defaultMaskChar)
+}
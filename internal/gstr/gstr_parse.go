@@ -3,6 +3,8 @@ package gstr
 import (
 	"fmt"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -14,9 +16,13 @@ import (
 // v[]=m&v[]=n         -> map[v:[m n]]
 // v[a][]=m&v[a][]=n   -> map[v:map[a:[m n]]]
 // v[][]=m&v[][]=n     -> map[v:[map[]]] // Currently does not support nested slice.
+// v[0]=m&v[1]=n       -> map[v:[m n]]
+// v[a][1]=m&v[a][0]=n -> map[v:map[a:[n m]]]
 // v=m&v[a]=n          -> error
 // a .[[b=c            -> map[a___[b:c]
 //
+// The nested maps whose keys are all non-negative integers are converted to slices ordered
+// by the keys, which are compacted if the keys are not continuous, eg: v[1]=m&v[3]=n -> map[v:[m n]].
 func Parse(s string) (result map[string]interface{}, err error) {
 	if s == "" {
 		return nil, nil
@@ -32,7 +38,7 @@ func Parse(s string) (result map[string]interface{}, err error) {
 		if err != nil {
 			return nil, err
 		}
-		for key[0] == ' ' {
+		for len(key) > 0 && key[0] == ' ' {
 			key = key[1:]
 		}
 		if key == "" || key[0] == '[' {
@@ -84,9 +90,49 @@ func Parse(s string) (result map[string]interface{}, err error) {
 			return nil, err
 		}
 	}
+	for k, v := range result {
+		result[k] = convertIndexedMaps(v)
+	}
 	return result, nil
 }
 
+// convertIndexedMaps converts the nested maps in <value> whose keys are all non-negative
+// integers to slices ordered by the keys, like: map[1:m 0:n] -> [n m].
+func convertIndexedMaps(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		for i, item := range v {
+			v[i] = convertIndexedMaps(item)
+		}
+		return v
+
+	case map[string]interface{}:
+		indexes := make([]int, 0, len(v))
+		for k, item := range v {
+			v[k] = convertIndexedMaps(item)
+			if indexes == nil {
+				continue
+			}
+			// The index should be in canonical form, "01" or "+1" are not indexes.
+			if index, err := strconv.Atoi(k); err == nil && index >= 0 && strconv.Itoa(index) == k {
+				indexes = append(indexes, index)
+			} else {
+				indexes = nil
+			}
+		}
+		if len(indexes) == 0 {
+			return v
+		}
+		sort.Ints(indexes)
+		array := make([]interface{}, len(indexes))
+		for i, index := range indexes {
+			array[i] = v[strconv.Itoa(index)]
+		}
+		return array
+	}
+	return value
+}
+
 // build nested map.
 func build(result map[string]interface{}, keys []string, value interface{}) error {
 	length := len(keys)
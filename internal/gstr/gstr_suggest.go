@@ -0,0 +1,37 @@
+package gstr
+
+import (
+	"sort"
+)
+
+// Suggest returns the items of <candidates> which are similar to <word>, which is usually
+// used for the "did you mean" suggestions of misspelled config keys and command arguments.
+// The similarity is calculated using JaroWinkler, and the items whose similarity are less than
+// <threshold> are ignored. The result is sorted by similarity in descending order.
+//
+// Eg:
+//
+//	gstr.Suggest("confg", []string{"config", "cache", "conf"}, 0.8) // ["config", "conf"]
+func Suggest(word string, candidates []string, threshold float64) []string {
+	type suggestion struct {
+		value      string
+		similarity float64
+	}
+	suggestions := make([]suggestion, 0)
+	for _, candidate := range candidates {
+		if similarity := JaroWinkler(word, candidate); similarity >= threshold {
+			suggestions = append(suggestions, suggestion{
+				value:      candidate,
+				similarity: similarity,
+			})
+		}
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].similarity > suggestions[j].similarity
+	})
+	result := make([]string, len(suggestions))
+	for i, v := range suggestions {
+		result[i] = v.value
+	}
+	return result
+}
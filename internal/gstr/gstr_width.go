@@ -0,0 +1,110 @@
+package gstr
+
+import (
+	"strings"
+	"unicode"
+)
+
+// wideRuneRanges are the ranges of East Asian Wide and Fullwidth runes,
+// which are displayed in two columns in terminal.
+var wideRuneRanges = [][2]rune{
+	{0x1100, 0x115F},   // Hangul Jamo.
+	{0x2329, 0x232A},   // Angle brackets.
+	{0x2E80, 0x303E},   // CJK Radicals, Kangxi Radicals, CJK Symbols and Punctuation.
+	{0x3041, 0x33FF},   // Hiragana, Katakana, Bopomofo, CJK Compatibility.
+	{0x3400, 0x4DBF},   // CJK Unified Ideographs Extension A.
+	{0x4E00, 0x9FFF},   // CJK Unified Ideographs.
+	{0xA000, 0xA4CF},   // Yi Syllables and Radicals.
+	{0xA960, 0xA97F},   // Hangul Jamo Extended-A.
+	{0xAC00, 0xD7A3},   // Hangul Syllables.
+	{0xF900, 0xFAFF},   // CJK Compatibility Ideographs.
+	{0xFE10, 0xFE19},   // Vertical Forms.
+	{0xFE30, 0xFE6F},   // CJK Compatibility Forms, Small Form Variants.
+	{0xFF00, 0xFF60},   // Fullwidth Forms.
+	{0xFFE0, 0xFFE6},   // Fullwidth Signs.
+	{0x1F300, 0x1F64F}, // Miscellaneous Symbols and Pictographs, Emoticons.
+	{0x1F680, 0x1F6FF}, // Transport and Map Symbols.
+	{0x1F900, 0x1F9FF}, // Supplemental Symbols and Pictographs.
+	{0x20000, 0x2FFFD}, // CJK Unified Ideographs Extension B-F.
+	{0x30000, 0x3FFFD}, // CJK Unified Ideographs Extension G.
+}
+
+// RuneWidth returns the display width of rune <r> in terminal, which is:
+// 0 for control chars and combining marks;
+// 2 for East Asian Wide and Fullwidth runes, like Chinese/Japanese/Korean chars;
+// 1 for the others.
+func RuneWidth(r rune) int {
+	if r < 0x20 || (r >= 0x7F && r < 0xA0) {
+		return 0
+	}
+	if r < 0x1100 {
+		if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+			return 0
+		}
+		return 1
+	}
+	for _, v := range wideRuneRanges {
+		if r < v[0] {
+			break
+		}
+		if r <= v[1] {
+			return 2
+		}
+	}
+	if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return 0
+	}
+	return 1
+}
+
+// Width returns the display width of string <str> in terminal,
+// in which the East Asian Wide runes are counted as 2 columns.
+//
+// Eg:
+//
+//	gstr.Width("abc")   // 3
+//	gstr.Width("中文a") // 5
+func Width(str string) int {
+	width := 0
+	for _, r := range str {
+		width += RuneWidth(r)
+	}
+	return width
+}
+
+// TruncateWidth truncates string <str> to the display width not greater than <maxWidth>,
+// and appends <ellipsis> to the result if <str> is truncated, which is "..." in default.
+// The width of <ellipsis> is counted in <maxWidth>, and it never splits a multi-byte char.
+//
+// Eg:
+//
+//	gstr.TruncateWidth("中文字符串", 7)      // "中文..."
+//	gstr.TruncateWidth("中文字符串", 7, "…") // "中文字…"
+func TruncateWidth(str string, maxWidth int, ellipsis ...string) string {
+	if Width(str) <= maxWidth {
+		return str
+	}
+	suffix := "..."
+	if len(ellipsis) > 0 {
+		suffix = ellipsis[0]
+	}
+	suffixWidth := Width(suffix)
+	if suffixWidth > maxWidth {
+		// The ellipsis is ignored as there's no enough space for it.
+		suffix, suffixWidth = "", 0
+	}
+	var (
+		width   = 0
+		builder = strings.Builder{}
+	)
+	for _, r := range str {
+		w := RuneWidth(r)
+		if width+w > maxWidth-suffixWidth {
+			break
+		}
+		width += w
+		builder.WriteRune(r)
+	}
+	builder.WriteString(suffix)
+	return builder.String()
+}
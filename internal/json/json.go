@@ -1,6 +1,7 @@
 package json
 
 import (
+	"bytes"
 	json2 "encoding/json"
 	"github.com/json-iterator/go"
 	"io"
@@ -42,6 +43,14 @@ func Unmarshal(data []byte, v interface{}) error {
 	return json.Unmarshal(data, v)
 }
 
+// UnmarshalUseNumber is the same as Unmarshal, but it decodes the numbers into interface{}
+// as json.Number instead of float64, which keeps the precision of integers above 2^53.
+func UnmarshalUseNumber(data []byte, v interface{}) error {
+	decoder := json2.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
 // NewEncoder same as json.NewEncoder
 func NewEncoder(writer io.Writer) *json2.Encoder {
 	return json2.NewEncoder(writer)
@@ -10,6 +10,12 @@ import (
 // Default cache object.
 var defaultCache = New()
 
+// SetCap sets the max item count of the default cache, and the least recently used items
+// are evicted if the size of the cache exceeds <cap>. There's no limit if <cap> <= 0.
+func SetCap(cap int) error {
+	return defaultCache.SetCap(cap)
+}
+
 // Set sets cache with <key>-<value> pair, which is expired after <duration>.
 // It does not expire if <duration> == 0.
 func Set(key interface{}, value interface{}, duration time.Duration) {
@@ -29,6 +35,18 @@ func Sets(data map[interface{}]interface{}, duration time.Duration) error {
 	return defaultCache.Sets(data, duration)
 }
 
+// GetMap retrieves and returns the values of given <keys> as map.
+// The keys that do not exist are not contained in the result.
+func GetMap(keys []interface{}) (map[interface{}]interface{}, error) {
+	return defaultCache.GetMap(keys)
+}
+
+// SetMap batch sets cache with key-value pairs by <data>, which is expired after <duration>.
+// It does not expire if <duration> == 0.
+func SetMap(data map[interface{}]interface{}, duration time.Duration) error {
+	return defaultCache.SetMap(data, duration)
+}
+
 // Get returns the value of <key>.
 // It returns nil if it does not exist or its value is nil.
 func Get(key interface{}) (interface{}, error) {
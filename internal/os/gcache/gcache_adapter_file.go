@@ -0,0 +1,367 @@
+package gcache
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/ilylx/gconv"
+	"github.com/ilylx/gconv/internal/intlog"
+	"github.com/ilylx/gconv/internal/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// adapterFile is the file-backed persistent cache adapter.
+// It keeps all data in memory using memory adapter for retrieving,
+// and appends every writing operation to a log file, which is compacted if it
+// contains too many stale records. The surviving and unexpired items are reloaded
+// from the log file when the adapter is created.
+type adapterFile struct {
+	*adapterMemory            // Underlying memory adapter for data retrieving.
+	mu             sync.Mutex // Mutex for concurrent safety of log file writing.
+	path           string     // Path of the log file.
+	file           *os.File   // Opened log file for appending.
+	records        int        // Record count in the log file.
+}
+
+// adapterFileRecord is the record of writing operation in the log file.
+type adapterFileRecord struct {
+	K string      `json:"k"`           // Key.
+	V interface{} `json:"v,omitempty"` // Value.
+	E int64       `json:"e,omitempty"` // Expire timestamp in milliseconds.
+	D bool        `json:"d,omitempty"` // Whether the key is deleted.
+}
+
+const (
+	// gFILE_COMPACT_MIN_RECORDS is the min record count of the log file
+	// before it can be compacted.
+	gFILE_COMPACT_MIN_RECORDS = 1000
+
+	// gFILE_COMPACT_RATIO is the ratio of record count to item count,
+	// the log file is compacted if its records exceed the ratio.
+	gFILE_COMPACT_RATIO = 2
+)
+
+// NewAdapterFile creates and returns a file-backed persistent cache adapter,
+// which persists the items to the log file <path> and reloads the unexpired
+// items from it if the file exists. The directory of <path> is created if
+// it does not exist.
+//
+// Note that the keys are persisted as string and the values are persisted as JSON,
+// which means the keys are restored as string and the values are restored as their
+// JSON decoded forms, eg: map[string]interface{}, []interface{} and json.Number.
+// It's so recommended using string keys and converting the values using gvar.Var.
+//
+// The items evicted by the cap or the max cost are also persisted as deleted,
+// so they are not restored when reloading.
+//
+// Eg:
+// cache := gcache.New()
+// adapter, err := gcache.NewAdapterFile("/tmp/cache.log")
+// cache.SetAdapter(adapter)
+func NewAdapterFile(path string) (Adapter, error) {
+	c := &adapterFile{
+		adapterMemory: newAdapterMemory(),
+		path:          path,
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	// Rewriting the log file to drop the stale records.
+	if err := c.compact(); err != nil {
+		return nil, err
+	}
+	c.adapterMemory.evictHook = c.persistEvicted
+	c.adapterMemory.startJanitor()
+	return c, nil
+}
+
+// Set sets cache with <key>-<value> pair, which is expired after <duration>.
+//
+// It does not expire if <duration> == 0.
+// It deletes the <key> if <duration> < 0.
+func (c *adapterFile) Set(key interface{}, value interface{}, duration time.Duration) error {
+	if err := c.adapterMemory.Set(key, value, duration); err != nil {
+		return err
+	}
+	return c.persist(key)
+}
+
+// Sets batch sets cache with key-value pairs by <data>, which is expired after <duration>.
+//
+// It does not expire if <duration> == 0.
+// It deletes the keys of <data> if <duration> < 0 or given <value> is nil.
+func (c *adapterFile) Sets(data map[interface{}]interface{}, duration time.Duration) error {
+	if err := c.adapterMemory.Sets(data, duration); err != nil {
+		return err
+	}
+	keys := make([]interface{}, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	return c.persist(keys...)
+}
+
+// SetIfNotExist sets cache with <key>-<value> pair which is expired after <duration>
+// if <key> does not exist in the cache. It returns true the <key> dose not exist in the
+// cache and it sets <value> successfully to the cache, or else it returns false.
+func (c *adapterFile) SetIfNotExist(key interface{}, value interface{}, duration time.Duration) (bool, error) {
+	ok, err := c.adapterMemory.SetIfNotExist(key, value, duration)
+	if err != nil || !ok {
+		return ok, err
+	}
+	return ok, c.persist(key)
+}
+
+// GetOrSet retrieves and returns the value of <key>, or sets <key>-<value> pair and
+// returns <value> if <key> does not exist in the cache.
+func (c *adapterFile) GetOrSet(key interface{}, value interface{}, duration time.Duration) (interface{}, error) {
+	if v, err := c.adapterMemory.Get(key); err != nil || v != nil {
+		return v, err
+	}
+	v, err := c.adapterMemory.GetOrSet(key, value, duration)
+	if err != nil {
+		return nil, err
+	}
+	return v, c.persist(key)
+}
+
+// GetOrSetFunc retrieves and returns the value of <key>, or sets <key> with result of
+// function <f> and returns its result if <key> does not exist in the cache.
+func (c *adapterFile) GetOrSetFunc(key interface{}, f func() (interface{}, error), duration time.Duration) (interface{}, error) {
+	if v, err := c.adapterMemory.Get(key); err != nil || v != nil {
+		return v, err
+	}
+	v, err := c.adapterMemory.GetOrSetFunc(key, f, duration)
+	if err != nil {
+		return nil, err
+	}
+	return v, c.persist(key)
+}
+
+// GetOrSetFuncLock retrieves and returns the value of <key>, or sets <key> with result of
+// function <f> and returns its result if <key> does not exist in the cache.
+func (c *adapterFile) GetOrSetFuncLock(key interface{}, f func() (interface{}, error), duration time.Duration) (interface{}, error) {
+	if v, err := c.adapterMemory.Get(key); err != nil || v != nil {
+		return v, err
+	}
+	v, err := c.adapterMemory.GetOrSetFuncLock(key, f, duration)
+	if err != nil {
+		return nil, err
+	}
+	return v, c.persist(key)
+}
+
+// Remove deletes the one or more keys from cache, and returns its value.
+// If multiple keys are given, it returns the value of the deleted last item.
+func (c *adapterFile) Remove(keys ...interface{}) (value interface{}, err error) {
+	if value, err = c.adapterMemory.Remove(keys...); err != nil {
+		return nil, err
+	}
+	return value, c.persist(keys...)
+}
+
+// Update updates the value of <key> without changing its expiration and returns the old value.
+// The returned value <exist> is false if the <key> does not exist in the cache.
+func (c *adapterFile) Update(key interface{}, value interface{}) (oldValue interface{}, exist bool, err error) {
+	if oldValue, exist, err = c.adapterMemory.Update(key, value); err != nil || !exist {
+		return
+	}
+	return oldValue, exist, c.persist(key)
+}
+
+// UpdateExpire updates the expiration of <key> and returns the old expiration duration value.
+//
+// It returns -1 and does nothing if the <key> does not exist in the cache.
+// It deletes the <key> if <duration> < 0.
+func (c *adapterFile) UpdateExpire(key interface{}, duration time.Duration) (oldDuration time.Duration, err error) {
+	if oldDuration, err = c.adapterMemory.UpdateExpire(key, duration); err != nil || oldDuration == -1 {
+		return
+	}
+	return oldDuration, c.persist(key)
+}
+
+// Clear clears all data of the cache and truncates the log file.
+// Note that this function is sensitive and should be carefully used.
+func (c *adapterFile) Clear() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.adapterMemory.Clear(); err != nil {
+		return err
+	}
+	return c.doCompact()
+}
+
+// Close compacts and closes the log file, and then closes the cache.
+func (c *adapterFile) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.file == nil {
+		return nil
+	}
+	err := c.doCompact()
+	if closeErr := c.file.Close(); err == nil {
+		err = closeErr
+	}
+	c.file = nil
+	c.adapterMemory.Close()
+	return err
+}
+
+// load reads the records from the log file and restores the unexpired items to memory.
+// It ignores the invalid records, which might be partially written before crash.
+func (c *adapterFile) load() error {
+	file, err := os.Open(c.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+	var (
+		reader = bufio.NewReader(file)
+		data   = make(map[string]adapterMemoryItem)
+	)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			if record, ok := c.decodeRecord(line); ok {
+				if record.D {
+					delete(data, record.K)
+				} else {
+					data[record.K] = adapterMemoryItem{v: record.V, e: record.E}
+				}
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	c.adapterMemory.dataMu.Lock()
+	for k, item := range data {
+		if item.IsExpired() {
+			continue
+		}
+		item.c = c.adapterMemory.getCost(k, item.v)
+		c.adapterMemory.doSetItem(k, item)
+		c.adapterMemory.eventList.PushBack(&adapterMemoryEvent{k: k, e: item.e})
+	}
+	c.adapterMemory.dataMu.Unlock()
+	return nil
+}
+
+// decodeRecord decodes and returns the record from <line>.
+func (c *adapterFile) decodeRecord(line []byte) (record *adapterFileRecord, ok bool) {
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+	if err := decoder.Decode(&record); err != nil || record == nil {
+		return nil, false
+	}
+	return record, true
+}
+
+// persist appends the current states of <keys> to the log file,
+// which deletes the key from the log file if it does not exist in memory.
+func (c *adapterFile) persist(keys ...interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.file == nil {
+		return errors.New(fmt.Sprintf(`cache file "%s" is closed`, c.path))
+	}
+	buffer := bytes.NewBuffer(nil)
+	for _, key := range keys {
+		// The state is retrieved within file mutex lock,
+		// so the last record of the key is always its latest state.
+		record := &adapterFileRecord{K: gconv.String(key)}
+		c.adapterMemory.dataMu.RLock()
+		item, ok := c.adapterMemory.data[key]
+		c.adapterMemory.dataMu.RUnlock()
+		if ok {
+			record.V = item.v
+			record.E = item.e
+		} else {
+			record.D = true
+		}
+		b, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		buffer.Write(b)
+		buffer.WriteByte('\n')
+	}
+	if _, err := c.file.Write(buffer.Bytes()); err != nil {
+		return err
+	}
+	c.records += len(keys)
+	if size, _ := c.adapterMemory.Size(); c.records > gFILE_COMPACT_MIN_RECORDS && c.records > size*gFILE_COMPACT_RATIO {
+		return c.doCompact()
+	}
+	return nil
+}
+
+// persistEvicted persists the deletion of <key> which is evicted by the cap or the max cost.
+func (c *adapterFile) persistEvicted(key interface{}) {
+	if err := c.persist(key); err != nil {
+		intlog.Error(err)
+	}
+}
+
+// compact rewrites the log file with the unexpired items in memory.
+func (c *adapterFile) compact() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.doCompact()
+}
+
+// doCompact rewrites the log file with the unexpired items in memory,
+// and reopens it for appending. It should be called within file mutex lock.
+func (c *adapterFile) doCompact() error {
+	var (
+		tmpPath = c.path + ".tmp"
+		buffer  = bytes.NewBuffer(nil)
+		records = 0
+	)
+	c.adapterMemory.dataMu.RLock()
+	for k, item := range c.adapterMemory.data {
+		if item.IsExpired() {
+			continue
+		}
+		b, err := json.Marshal(&adapterFileRecord{K: gconv.String(k), V: item.v, E: item.e})
+		if err != nil {
+			c.adapterMemory.dataMu.RUnlock()
+			return err
+		}
+		buffer.Write(b)
+		buffer.WriteByte('\n')
+		records++
+	}
+	c.adapterMemory.dataMu.RUnlock()
+	if err := os.WriteFile(tmpPath, buffer.Bytes(), 0644); err != nil {
+		return err
+	}
+	if c.file != nil {
+		c.file.Close()
+		c.file = nil
+	}
+	if err := os.Rename(tmpPath, c.path); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(c.path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	c.file = file
+	c.records = records
+	return nil
+}
@@ -0,0 +1,190 @@
+package gcache
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTestAdapterFile creates a file adapter with the log file in a temporary directory.
+func newTestAdapterFile(t *testing.T, path string) *adapterFile {
+	adapter, err := NewAdapterFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return adapter.(*adapterFile)
+}
+
+// crash closes the log file and the memory cache of <c> without compacting,
+// which simulates the process exiting unexpectedly.
+func crash(c *adapterFile) {
+	c.mu.Lock()
+	c.file.Close()
+	c.file = nil
+	c.mu.Unlock()
+	c.adapterMemory.Close()
+}
+
+// countLines returns the line count of file <path>.
+func countLines(t *testing.T, path string) int {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bytes.Count(content, []byte{'\n'})
+}
+
+func Test_AdapterFile_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.log")
+	c := newTestAdapterFile(t, path)
+	if err := c.Set("k1", "v1", 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Sets(map[interface{}]interface{}{"k2": 2, "k3": "v3"}, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	c = newTestAdapterFile(t, path)
+	defer c.Close()
+	if size, _ := c.Size(); size != 3 {
+		t.Fatalf("expected 3 items, got %d", size)
+	}
+	if v, _ := c.Get("k1"); v != "v1" {
+		t.Errorf("expected v1, got %v", v)
+	}
+	if v, _ := c.Get("k2"); v == nil || v.(interface{ String() string }).String() != "2" {
+		t.Errorf("expected 2, got %v", v)
+	}
+	if d, _ := c.GetExpire("k3"); d <= 0 || d > time.Hour {
+		t.Errorf("expected expiration within an hour, got %v", d)
+	}
+}
+
+func Test_AdapterFile_SkipExpired(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.log")
+	c := newTestAdapterFile(t, path)
+	c.Set("short", 1, 50*time.Millisecond)
+	c.Set("long", 2, time.Hour)
+	crash(c)
+	time.Sleep(100 * time.Millisecond)
+
+	c = newTestAdapterFile(t, path)
+	defer c.Close()
+	if ok, _ := c.Contains("short"); ok {
+		t.Error("expected expired item not to be restored")
+	}
+	if ok, _ := c.Contains("long"); !ok {
+		t.Error("expected unexpired item to be restored")
+	}
+	// The expired item is also dropped from the log file by the compaction.
+	if n := countLines(t, path); n != 1 {
+		t.Errorf("expected 1 record after reloading, got %d", n)
+	}
+}
+
+func Test_AdapterFile_DeleteTombstone(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.log")
+	c := newTestAdapterFile(t, path)
+	c.Set("k1", 1, 0)
+	c.Set("k2", 2, 0)
+	c.Remove("k1")
+	c.Set("k3", 3, -time.Second)
+	crash(c)
+
+	c = newTestAdapterFile(t, path)
+	defer c.Close()
+	if ok, _ := c.Contains("k1"); ok {
+		t.Error("expected removed item not to be restored")
+	}
+	if ok, _ := c.Contains("k3"); ok {
+		t.Error("expected item deleted by negative duration not to be restored")
+	}
+	if ok, _ := c.Contains("k2"); !ok {
+		t.Error("expected k2 to be restored")
+	}
+}
+
+func Test_AdapterFile_Compact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.log")
+	c := newTestAdapterFile(t, path)
+	defer c.Close()
+	for i := 0; i < gFILE_COMPACT_MIN_RECORDS; i++ {
+		c.Set("k", i, 0)
+	}
+	if n := countLines(t, path); n != gFILE_COMPACT_MIN_RECORDS {
+		t.Fatalf("expected %d records before compacting, got %d", gFILE_COMPACT_MIN_RECORDS, n)
+	}
+	c.Set("k", "last", 0)
+	if n := countLines(t, path); n != 1 {
+		t.Errorf("expected 1 record after compacting, got %d", n)
+	}
+	c.mu.Lock()
+	records := c.records
+	c.mu.Unlock()
+	if records != 1 {
+		t.Errorf("expected record count 1, got %d", records)
+	}
+	// Appending continues after compacting.
+	c.Set("k2", 2, 0)
+	if n := countLines(t, path); n != 2 {
+		t.Errorf("expected 2 records, got %d", n)
+	}
+}
+
+func Test_AdapterFile_TruncatedLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.log")
+	content := fmt.Sprintf("{\"k\":\"a\",\"v\":\"1\",\"e\":%d}\n{\"k\":\"b\",\"v\":", int64(gDEFAULT_MAX_EXPIRE))
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c := newTestAdapterFile(t, path)
+	defer c.Close()
+	if v, _ := c.Get("a"); v != "1" {
+		t.Errorf("expected 1, got %v", v)
+	}
+	if ok, _ := c.Contains("b"); ok {
+		t.Error("expected truncated record to be ignored")
+	}
+	// The truncated line is dropped by the compaction, so appending starts at a new line.
+	c.Set("c", "3", 0)
+	crash(c)
+	c = newTestAdapterFile(t, path)
+	defer c.Close()
+	if v, _ := c.Get("c"); v != "3" {
+		t.Errorf("expected 3, got %v", v)
+	}
+}
+
+func Test_AdapterFile_Evicted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.log")
+	c := newTestAdapterFile(t, path)
+	c.SetCap(1)
+	c.Set("k1", 1, 0)
+	c.Set("k2", 2, 0)
+	// Waiting for the janitor and LRU to evict the item exceeding the cap.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if size, _ := c.Size(); size == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected one item to be evicted")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	keys, _ := c.Keys()
+	crash(c)
+
+	c = newTestAdapterFile(t, path)
+	defer c.Close()
+	restored, _ := c.Keys()
+	if len(restored) != 1 || restored[0] != keys[0] {
+		t.Errorf("expected restored keys %v, got %v", keys, restored)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/ilylx/gconv/container/glist"
 	"github.com/ilylx/gconv/container/gset"
 	"github.com/ilylx/gconv/container/gtype"
+	"github.com/ilylx/gconv/internal/grand"
 	"github.com/ilylx/gconv/internal/os/gtime"
 	"github.com/ilylx/gconv/internal/os/gtimer"
 	"math"
@@ -22,11 +23,36 @@ type adapterMemory struct {
 	// expireSetMu ensures the concurrent safety of expireSets map.
 	expireSetMu sync.RWMutex
 
+	// lruMu ensures the concurrent safety of lru creating and closing.
+	lruMu sync.Mutex
+
 	// cap limits the size of the cache pool.
 	// If the size of the cache exceeds the cap,
 	// the cache expiration process performs according to the LRU algorithm.
 	// It is 0 in default which means no limits.
-	cap int
+	cap *gtype.Int
+
+	// maxCost limits the total cost of the items in the cache.
+	// If the total cost exceeds the maxCost, the items are evicted the same as the cap.
+	// It is 0 in default which means no limits.
+	maxCost *gtype.Int64
+
+	// ttlJitter is the max ratio of TTL that the expiration is randomly brought forward,
+	// which spreads the expirations of items set together. It is 0 in default.
+	ttlJitter *gtype.Float64
+
+	// ttlJitterSource is the random generator of the TTL jitter, which is *grand.Source.
+	// It uses the default random generator of grand if it is not set.
+	ttlJitterSource *gtype.Interface
+
+	// totalCost is the total cost of the items in the cache.
+	totalCost *gtype.Int64
+
+	// costMu ensures the concurrent safety of costFunc.
+	costMu sync.RWMutex
+
+	// costFunc calculates the cost of the items, which is used if no cost is specified.
+	costFunc CostFunc
 
 	// data is the underlying cache data which is stored in a hash table.
 	data map[interface{}]adapterMemoryItem
@@ -42,6 +68,9 @@ type adapterMemory struct {
 	// lru is the LRU manager, which is enabled when attribute cap > 0.
 	lru *adapterMemoryLru
 
+	// policy is the eviction policy for LRU manager, which is LRU in default.
+	policy EvictionPolicy
+
 	// lruGetList is the LRU history according with Get function.
 	lruGetList *glist.List
 
@@ -50,14 +79,55 @@ type adapterMemory struct {
 
 	// closed controls the cache closed or not.
 	closed *gtype.Bool
+
+	// tagMu ensures the concurrent safety of tagKeys and keyTags maps.
+	tagMu sync.RWMutex
+
+	// tagKeys is the tag to its key set mapping, which is used for invalidation by tag.
+	tagKeys map[string]map[interface{}]struct{}
+
+	// keyTags is the key to its tags mapping, which is used for untagging deleted keys.
+	keyTags map[interface{}][]string
+
+	// janitorMu ensures the concurrent safety of janitor and janitorConfig.
+	janitorMu sync.RWMutex
+
+	// janitor is the timer entry of the background task synchronizing events
+	// and sweeping expired items.
+	janitor *gtimer.Entry
+
+	// janitorConfig is the configuration of janitor.
+	janitorConfig JanitorConfig
+
+	// janitorStats is the statistics of janitor sweeping.
+	janitorStats *adapterMemoryJanitorStats
+
+	// callbackMu ensures the concurrent safety of callbacks.
+	callbackMu sync.RWMutex
+
+	// onEvicted is the callback for items removed from the cache except expiration.
+	onEvicted CallbackFunc
+
+	// onExpired is the callback for expired items.
+	onExpired CallbackFunc
+
+	// evictHook is the internal hook for items evicted by the cap or the max cost,
+	// which is used by the persistent adapters for persisting the eviction.
+	// It is set before the janitor starts and never changed.
+	evictHook func(key interface{})
 }
 
 // Internal cache item.
 type adapterMemoryItem struct {
 	v interface{} // Value.
 	e int64       // Expire timestamp in milliseconds.
+	c int64       // Cost.
+	i int64       // Idle TTL in milliseconds for sliding expiration, 0 means no sliding.
 }
 
+// CallbackFunc is the callback function for items leaving the cache.
+type CallbackFunc = func(key, value interface{})
+
 // Internal event item.
 type adapterMemoryEvent struct {
 	k interface{} // Key.
@@ -73,31 +143,109 @@ const (
 // newAdapterMemory creates and returns a new memory cache object.
 func newAdapterMemory(lruCap ...int) *adapterMemory {
 	c := &adapterMemory{
-		lruGetList:  glist.New(true),
-		data:        make(map[interface{}]adapterMemoryItem),
-		expireTimes: make(map[interface{}]int64),
-		expireSets:  make(map[int64]*gset.Set),
-		eventList:   glist.New(true),
-		closed:      gtype.NewBool(),
+		lruGetList:      glist.New(true),
+		data:            make(map[interface{}]adapterMemoryItem),
+		expireTimes:     make(map[interface{}]int64),
+		expireSets:      make(map[int64]*gset.Set),
+		tagKeys:         make(map[string]map[interface{}]struct{}),
+		keyTags:         make(map[interface{}][]string),
+		eventList:       glist.New(true),
+		closed:          gtype.NewBool(),
+		cap:             gtype.NewInt(),
+		maxCost:         gtype.NewInt64(),
+		totalCost:       gtype.NewInt64(),
+		ttlJitter:       gtype.NewFloat64(),
+		ttlJitterSource: gtype.NewInterface(),
+		janitorStats: &adapterMemoryJanitorStats{
+			sweeps:        gtype.NewInt64(),
+			reclaimed:     gtype.NewInt64(),
+			lastReclaimed: gtype.NewInt(),
+			lastDuration:  gtype.NewInt64(),
+		},
 	}
 	if len(lruCap) > 0 {
-		c.cap = lruCap[0]
-		c.lru = newMemCacheLru(c)
+		c.SetCap(lruCap[0])
 	}
 	return c
 }
 
+// newAdapterMemoryWithPolicy creates and returns a new memory cache object,
+// which evicts keys using <policy> if its size exceeds <cap>.
+func newAdapterMemoryWithPolicy(cap int, policy EvictionPolicy) *adapterMemory {
+	c := newAdapterMemory()
+	c.policy = policy
+	c.SetCap(cap)
+	return c
+}
+
+// SetCap sets the max item count of the cache, and the least recently used items
+// are evicted if the size of the cache exceeds <cap>. There's no limit if <cap> <= 0.
+//
+// Note that the items which are set before the LRU feature enabled are not evicted
+// until they are set or retrieved again.
+func (c *adapterMemory) SetCap(cap int) {
+	c.lruMu.Lock()
+	defer c.lruMu.Unlock()
+	if cap > 0 {
+		c.initLru()
+	}
+	// The cap is set after lru is created,
+	// as lru is accessed without lock only if the LRU feature is enabled.
+	c.cap.Set(cap)
+}
+
+// GetCap returns the max item count of the cache, which is 0 if there's no limit.
+func (c *adapterMemory) GetCap() int {
+	return c.cap.Val()
+}
+
+// SetTTLJitter sets the max <ratio> in range of [0, 1] of TTL that the expiration is randomly
+// brought forward, which spreads the expirations of items set together, eg: the items warmed
+// at startup do not expire in the same second. The TTL of an item is randomly in range of
+// [TTL * (1 - ratio), TTL] if the jitter is set. It disables the jitter if <ratio> <= 0.
+// The optional parameter <source> specifies the random generator of the jitter,
+// eg: grand.NewSource(seed) for reproducible expirations in testing.
+func (c *adapterMemory) SetTTLJitter(ratio float64, source ...*grand.Source) {
+	if ratio > 1 {
+		ratio = 1
+	}
+	if len(source) > 0 {
+		c.ttlJitterSource.Set(source[0])
+	}
+	c.ttlJitter.Set(ratio)
+}
+
+// SetOnEvicted sets the callback <f> which is called if an item is removed from the cache
+// by LRU eviction, Remove or Clear. It is called synchronously in the goroutine removing
+// the item, so it should not block.
+func (c *adapterMemory) SetOnEvicted(f CallbackFunc) {
+	c.callbackMu.Lock()
+	c.onEvicted = f
+	c.callbackMu.Unlock()
+}
+
+// SetOnExpired sets the callback <f> which is called if an expired item is cleared from
+// the cache. It is called synchronously in the goroutine of the cleaning up timer,
+// so it should not block.
+func (c *adapterMemory) SetOnExpired(f CallbackFunc) {
+	c.callbackMu.Lock()
+	c.onExpired = f
+	c.callbackMu.Unlock()
+}
+
 // Set sets cache with <key>-<value> pair, which is expired after <duration>.
 //
 // It does not expire if <duration> == 0.
 // It deletes the <key> if <duration> < 0.
 func (c *adapterMemory) Set(key interface{}, value interface{}, duration time.Duration) error {
 	expireTime := c.getInternalExpire(duration)
+	cost := c.getCost(key, value)
 	c.dataMu.Lock()
-	c.data[key] = adapterMemoryItem{
+	c.doSetItem(key, adapterMemoryItem{
 		v: value,
 		e: expireTime,
-	}
+		c: cost,
+	})
 	c.dataMu.Unlock()
 	c.eventList.PushBack(&adapterMemoryEvent{
 		k: key,
@@ -112,13 +260,20 @@ func (c *adapterMemory) Set(key interface{}, value interface{}, duration time.Du
 // It deletes the <key> if given <value> is nil.
 // It does nothing if <key> does not exist in the cache.
 func (c *adapterMemory) Update(key interface{}, value interface{}) (oldValue interface{}, exist bool, err error) {
+	costFunc := c.getCostFunc()
 	c.dataMu.Lock()
 	defer c.dataMu.Unlock()
 	if item, ok := c.data[key]; ok {
-		c.data[key] = adapterMemoryItem{
+		cost := item.c
+		if costFunc != nil {
+			cost = costFunc(key, value)
+		}
+		c.doSetItem(key, adapterMemoryItem{
 			v: value,
 			e: item.e,
-		}
+			c: cost,
+			i: item.i,
+		})
 		return item.v, true, nil
 	}
 	return nil, false, nil
@@ -136,6 +291,7 @@ func (c *adapterMemory) UpdateExpire(key interface{}, duration time.Duration) (o
 		c.data[key] = adapterMemoryItem{
 			v: item.v,
 			e: newExpireTime,
+			c: item.c,
 		}
 		c.eventList.PushBack(&adapterMemoryEvent{
 			k: key,
@@ -187,22 +343,74 @@ func (c *adapterMemory) SetIfNotExist(key interface{}, value interface{}, durati
 // It does not expire if <duration> == 0.
 // It deletes the keys of <data> if <duration> < 0 or given <value> is nil.
 func (c *adapterMemory) Sets(data map[interface{}]interface{}, duration time.Duration) error {
-	expireTime := c.getInternalExpire(duration)
+	return c.SetMap(data, duration)
+}
+
+// SetMap batch sets cache with key-value pairs by <data>, which is expired after <duration>.
+// It takes the writing lock only once for all the pairs.
+//
+// It does not expire if <duration> == 0.
+// It deletes the keys of <data> if <duration> < 0.
+func (c *adapterMemory) SetMap(data map[interface{}]interface{}, duration time.Duration) error {
+	if len(data) == 0 {
+		return nil
+	}
+	var (
+		expireTime = c.getInternalExpire(duration)
+		costs      = make(map[interface{}]int64, len(data))
+		events     = make([]interface{}, 0, len(data))
+	)
+	for k, v := range data {
+		costs[k] = c.getCost(k, v)
+	}
+	c.dataMu.Lock()
 	for k, v := range data {
-		c.dataMu.Lock()
-		c.data[k] = adapterMemoryItem{
+		c.doSetItem(k, adapterMemoryItem{
 			v: v,
 			e: expireTime,
-		}
-		c.dataMu.Unlock()
-		c.eventList.PushBack(&adapterMemoryEvent{
+			c: costs[k],
+		})
+		events = append(events, &adapterMemoryEvent{
 			k: k,
 			e: expireTime,
 		})
 	}
+	c.dataMu.Unlock()
+	c.eventList.PushBacks(events)
 	return nil
 }
 
+// GetMap retrieves and returns the values of given <keys> as map.
+// The keys that do not exist or are expired are not contained in the result.
+// It takes the reading lock only once for all the keys.
+func (c *adapterMemory) GetMap(keys []interface{}) (map[interface{}]interface{}, error) {
+	var (
+		data    = make(map[interface{}]interface{}, len(keys))
+		found   = make([]interface{}, 0, len(keys))
+		sliding = make([]interface{}, 0)
+	)
+	c.dataMu.RLock()
+	for _, key := range keys {
+		if item, ok := c.data[key]; ok && !item.IsExpired() {
+			data[key] = item.v
+			found = append(found, key)
+			if item.i > 0 {
+				sliding = append(sliding, key)
+			}
+		}
+	}
+	c.dataMu.RUnlock()
+	// Extending the expirations of the keys in sliding mode.
+	for _, key := range sliding {
+		c.touch(key)
+	}
+	// Adding to LRU history if LRU feature is enabled.
+	if c.isLruEnabled() && len(found) > 0 {
+		c.lruGetList.PushBacks(found)
+	}
+	return data, nil
+}
+
 // Get retrieves and returns the associated value of given <key>.
 // It returns nil if it does not exist or its value is nil.
 func (c *adapterMemory) Get(key interface{}) (interface{}, error) {
@@ -211,11 +419,20 @@ func (c *adapterMemory) Get(key interface{}) (interface{}, error) {
 	c.dataMu.RUnlock()
 	if ok && !item.IsExpired() {
 		// Adding to LRU history if LRU feature is enabled.
-		if c.cap > 0 {
+		if c.isLruEnabled() {
 			c.lruGetList.PushBack(key)
 		}
+		// Extending the expiration if it is in sliding mode.
+		if item.i > 0 {
+			c.touch(key)
+		}
 		return item.v, nil
 	}
+	// Lazily deleting the expired item,
+	// which is necessary if proactive sweeping is disabled.
+	if ok {
+		c.clearByKey(key)
+	}
 	return nil, nil
 }
 
@@ -296,20 +513,28 @@ func (c *adapterMemory) Contains(key interface{}) (bool, error) {
 
 // Remove deletes the one or more keys from cache, and returns its value.
 // If multiple keys are given, it returns the value of the deleted last item.
+// It takes the writing lock only once for all the keys.
 func (c *adapterMemory) Remove(keys ...interface{}) (value interface{}, err error) {
+	removed := make(map[interface{}]interface{})
 	c.dataMu.Lock()
-	defer c.dataMu.Unlock()
 	for _, key := range keys {
 		item, ok := c.data[key]
 		if ok {
 			value = item.v
-			delete(c.data, key)
+			removed[key] = item.v
+			c.doDeleteItem(key)
 			c.eventList.PushBack(&adapterMemoryEvent{
 				k: key,
 				e: gtime.TimestampMilli() - 1000,
 			})
 		}
 	}
+	c.dataMu.Unlock()
+	if f := c.getOnEvicted(); f != nil {
+		for k, v := range removed {
+			f(k, v)
+		}
+	}
 	return value, nil
 }
 
@@ -364,16 +589,29 @@ func (c *adapterMemory) Size() (size int, err error) {
 // Note that this function is sensitive and should be carefully used.
 func (c *adapterMemory) Clear() error {
 	c.dataMu.Lock()
-	defer c.dataMu.Unlock()
+	data := c.data
 	c.data = make(map[interface{}]adapterMemoryItem)
+	c.totalCost.Set(0)
+	c.tagMu.Lock()
+	c.tagKeys = make(map[string]map[interface{}]struct{})
+	c.keyTags = make(map[interface{}][]string)
+	c.tagMu.Unlock()
+	c.dataMu.Unlock()
+	if f := c.getOnEvicted(); f != nil {
+		for k, item := range data {
+			f(k, item.v)
+		}
+	}
 	return nil
 }
 
 // Close closes the cache.
 func (c *adapterMemory) Close() error {
-	if c.cap > 0 {
+	c.lruMu.Lock()
+	if c.lru != nil {
 		c.lru.Close()
 	}
+	c.lruMu.Unlock()
 	c.closed.Set(true)
 	return nil
 }
@@ -405,7 +643,7 @@ func (c *adapterMemory) doSetWithLockCheck(key interface{}, value interface{}, d
 			value = v
 		}
 	}
-	c.data[key] = adapterMemoryItem{v: value, e: expireTimestamp}
+	c.doSetItem(key, adapterMemoryItem{v: value, e: expireTimestamp, c: c.getCost(key, value)})
 	c.eventList.PushBack(&adapterMemoryEvent{k: key, e: expireTimestamp})
 	return value, nil
 }
@@ -415,10 +653,23 @@ func (c *adapterMemory) getInternalExpire(duration time.Duration) int64 {
 	if duration == 0 {
 		return gDEFAULT_MAX_EXPIRE
 	} else {
+		if ratio := c.ttlJitter.Val(); ratio > 0 && duration > 0 {
+			if jitter := int(float64(duration.Nanoseconds()/1000000) * ratio); jitter > 0 {
+				duration -= time.Duration(c.getTTLJitterIntn(jitter+1)) * time.Millisecond
+			}
+		}
 		return gtime.TimestampMilli() + duration.Nanoseconds()/1000000
 	}
 }
 
+// getTTLJitterIntn returns a random int number in [0, max) for the TTL jitter.
+func (c *adapterMemory) getTTLJitterIntn(max int) int {
+	if source, ok := c.ttlJitterSource.Val().(*grand.Source); ok && source != nil {
+		return source.Intn(max)
+	}
+	return grand.Intn(max)
+}
+
 // makeExpireKey groups the <expire> in milliseconds to its according seconds.
 func (c *adapterMemory) makeExpireKey(expire int64) int64 {
 	return int64(math.Ceil(float64(expire/1000)+1) * 1000)
@@ -448,10 +699,10 @@ func (c *adapterMemory) getOrNewExpireSet(expire int64) (expireSet *gset.Set) {
 	return
 }
 
-// syncEventAndClearExpired does the asynchronous task loop:
+// syncEventAndClearExpired does the asynchronous task loop of janitor:
 //  1. Asynchronously process the data in the event list,
 //     and synchronize the results to the <expireTimes> and <expireSets> properties.
-//  2. Clean up the expired key-value pair data.
+//  2. Clean up the expired key-value pair data if proactive sweeping is enabled.
 func (c *adapterMemory) syncEventAndClearExpired() {
 	if c.closed.Val() {
 		gtimer.Exit()
@@ -488,12 +739,12 @@ func (c *adapterMemory) syncEventAndClearExpired() {
 			c.expireTimeMu.Unlock()
 		}
 		// Adding the key the LRU history by writing operations.
-		if c.cap > 0 {
+		if c.isLruEnabled() {
 			c.lru.Push(event.k)
 		}
 	}
 	// Processing expired keys from LRU.
-	if c.cap > 0 && c.lruGetList.Len() > 0 {
+	if c.isLruEnabled() && c.lruGetList.Len() > 0 {
 		for {
 			if v := c.lruGetList.PopFront(); v != nil {
 				c.lru.Push(v)
@@ -505,43 +756,57 @@ func (c *adapterMemory) syncEventAndClearExpired() {
 	// ========================
 	// Data Cleaning up.
 	// ========================
-	var (
-		expireSet *gset.Set
-		ek        = c.makeExpireKey(gtime.TimestampMilli())
-		eks       = []int64{ek - 1000, ek - 2000, ek - 3000, ek - 4000, ek - 5000}
-	)
-	for _, expireTime := range eks {
-		if expireSet = c.getExpireSet(expireTime); expireSet != nil {
-			// Iterating the set to delete all keys in it.
-			expireSet.Iterator(func(key interface{}) bool {
-				c.clearByKey(key)
-				return true
-			})
-			// Deleting the set after all of its keys are deleted.
-			c.expireSetMu.Lock()
-			delete(c.expireSets, expireTime)
-			c.expireSetMu.Unlock()
-		}
-	}
+	c.clearExpired()
 }
 
-// clearByKey deletes the key-value pair with given <key>.
+// clearByKey deletes the key-value pair with given <key>, and returns whether it is deleted.
 // The parameter <force> specifies whether doing this deleting forcibly.
-func (c *adapterMemory) clearByKey(key interface{}, force ...bool) {
+func (c *adapterMemory) clearByKey(key interface{}, force ...bool) (deleted bool) {
 	c.dataMu.Lock()
 	// Doubly check before really deleting it from cache.
-	if item, ok := c.data[key]; (ok && item.IsExpired()) || (len(force) > 0 && force[0]) {
-		delete(c.data, key)
+	item, ok := c.data[key]
+	expired := ok && item.IsExpired()
+	if expired || (len(force) > 0 && force[0]) {
+		c.doDeleteItem(key)
 	}
 	c.dataMu.Unlock()
 
+	// Calling the callback after the item is deleted.
+	if expired {
+		if f := c.getOnExpired(); f != nil {
+			f(key, item.v)
+		}
+	} else if ok && len(force) > 0 && force[0] {
+		if c.evictHook != nil {
+			c.evictHook(key)
+		}
+		if f := c.getOnEvicted(); f != nil {
+			f(key, item.v)
+		}
+	}
+
 	// Deleting its expire time from <expireTimes>.
 	c.expireTimeMu.Lock()
 	delete(c.expireTimes, key)
 	c.expireTimeMu.Unlock()
 
 	// Deleting it from LRU.
-	if c.cap > 0 {
+	if c.isLruEnabled() {
 		c.lru.Remove(key)
 	}
+	return ok && (expired || (len(force) > 0 && force[0]))
+}
+
+// getOnEvicted returns the callback for evicted items.
+func (c *adapterMemory) getOnEvicted() CallbackFunc {
+	c.callbackMu.RLock()
+	defer c.callbackMu.RUnlock()
+	return c.onEvicted
+}
+
+// getOnExpired returns the callback for expired items.
+func (c *adapterMemory) getOnExpired() CallbackFunc {
+	c.callbackMu.RLock()
+	defer c.callbackMu.RUnlock()
+	return c.onExpired
 }
@@ -0,0 +1,113 @@
+package gcache
+
+import (
+	"time"
+)
+
+// CostFunc calculates and returns the cost of the item, eg: the size of the value in bytes.
+type CostFunc = func(key, value interface{}) int64
+
+// SetMaxCost sets the max total cost of the items in the cache, and the least recently used
+// items are evicted if the total cost exceeds <maxCost>. There's no limit if <maxCost> <= 0.
+//
+// The cost of item is specified by SetWithCost, or calculated by the function set by
+// SetCostFunc, or else it is 0.
+func (c *adapterMemory) SetMaxCost(maxCost int64) {
+	c.lruMu.Lock()
+	defer c.lruMu.Unlock()
+	if maxCost > 0 {
+		c.initLru()
+	}
+	// The maxCost is set after lru is created,
+	// as lru is accessed without lock only if the LRU feature is enabled.
+	c.maxCost.Set(maxCost)
+}
+
+// GetMaxCost returns the max total cost of the items, which is 0 if there's no limit.
+func (c *adapterMemory) GetMaxCost() int64 {
+	return c.maxCost.Val()
+}
+
+// GetTotalCost returns the current total cost of the items in the cache.
+func (c *adapterMemory) GetTotalCost() int64 {
+	return c.totalCost.Val()
+}
+
+// SetCostFunc sets the function <f> calculating the cost of items which are set without cost.
+// Note that <f> is called within mutex lock of the cache, so it should be fast and
+// should not access the cache.
+func (c *adapterMemory) SetCostFunc(f CostFunc) {
+	c.costMu.Lock()
+	c.costFunc = f
+	c.costMu.Unlock()
+}
+
+// SetWithCost sets cache with <key>-<value> pair of given <cost>, which is expired after <duration>.
+//
+// It does not expire if <duration> == 0.
+// It deletes the <key> if <duration> < 0.
+func (c *adapterMemory) SetWithCost(key interface{}, value interface{}, duration time.Duration, cost int64) error {
+	expireTime := c.getInternalExpire(duration)
+	c.dataMu.Lock()
+	c.doSetItem(key, adapterMemoryItem{
+		v: value,
+		e: expireTime,
+		c: cost,
+	})
+	c.dataMu.Unlock()
+	c.eventList.PushBack(&adapterMemoryEvent{
+		k: key,
+		e: expireTime,
+	})
+	return nil
+}
+
+// initLru creates the LRU manager if it is not created.
+// It should be called within lruMu lock.
+func (c *adapterMemory) initLru() {
+	if c.lru == nil {
+		c.lru = newMemCacheLru(c, c.policy)
+	}
+}
+
+// isLruEnabled checks and returns whether the LRU feature is enabled,
+// which is enabled if the cap or the max cost is set.
+func (c *adapterMemory) isLruEnabled() bool {
+	return c.cap.Val() > 0 || c.maxCost.Val() > 0
+}
+
+// getCostFunc returns the function calculating the cost of items.
+func (c *adapterMemory) getCostFunc() CostFunc {
+	c.costMu.RLock()
+	defer c.costMu.RUnlock()
+	return c.costFunc
+}
+
+// getCost calculates and returns the cost of the item using the cost function.
+// It returns 0 if no cost function is set.
+func (c *adapterMemory) getCost(key, value interface{}) int64 {
+	if f := c.getCostFunc(); f != nil {
+		return f(key, value)
+	}
+	return 0
+}
+
+// doSetItem sets <item> for <key> and updates the total cost.
+// It should be called within dataMu writing lock.
+func (c *adapterMemory) doSetItem(key interface{}, item adapterMemoryItem) {
+	if old, ok := c.data[key]; ok {
+		c.totalCost.Add(-old.c)
+	}
+	c.data[key] = item
+	c.totalCost.Add(item.c)
+}
+
+// doDeleteItem deletes the item of <key>, and updates the total cost and its tags.
+// It should be called within dataMu writing lock.
+func (c *adapterMemory) doDeleteItem(key interface{}) {
+	if old, ok := c.data[key]; ok {
+		c.totalCost.Add(-old.c)
+		delete(c.data, key)
+		c.untagKey(key)
+	}
+}
@@ -0,0 +1,60 @@
+package gcache
+
+import (
+	"github.com/ilylx/gconv/internal/os/gtime"
+	"time"
+)
+
+// SetWithIdleTTL sets cache with <key>-<value> pair in sliding expiration mode,
+// which is expired after it is not retrieved for <idle> duration. Each successful
+// retrieving by Get, GetMap and so on extends its expiration to <idle> later,
+// which is the semantics needed by session storage.
+//
+// The sliding mode is cancelled if the expiration is updated by UpdateExpire,
+// or the <key> is set again by other setting functions.
+// It deletes the <key> if <idle> < 0, and it does not expire if <idle> == 0.
+func (c *adapterMemory) SetWithIdleTTL(key interface{}, value interface{}, idle time.Duration) error {
+	var (
+		expireTime = c.getInternalExpire(idle)
+		cost       = c.getCost(key, value)
+		idleMs     = int64(0)
+	)
+	if idle > 0 {
+		idleMs = idle.Nanoseconds() / 1000000
+	}
+	c.dataMu.Lock()
+	c.doSetItem(key, adapterMemoryItem{
+		v: value,
+		e: expireTime,
+		c: cost,
+		i: idleMs,
+	})
+	c.dataMu.Unlock()
+	c.eventList.PushBack(&adapterMemoryEvent{
+		k: key,
+		e: expireTime,
+	})
+	return nil
+}
+
+// touch extends the expiration of <key> to its idle TTL later if it is in sliding mode.
+func (c *adapterMemory) touch(key interface{}) {
+	c.dataMu.Lock()
+	item, ok := c.data[key]
+	if !ok || item.i <= 0 || item.IsExpired() {
+		c.dataMu.Unlock()
+		return
+	}
+	oldExpireTime := item.e
+	item.e = gtime.TimestampMilli() + item.i
+	c.data[key] = item
+	c.dataMu.Unlock()
+	// The expire set is grouped in seconds,
+	// so it needs synchronizing only if the group changes.
+	if c.makeExpireKey(item.e) != c.makeExpireKey(oldExpireTime) {
+		c.eventList.PushBack(&adapterMemoryEvent{
+			k: key,
+			e: item.e,
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package gcache
+
+import (
+	"github.com/ilylx/gconv/container/gtype"
+	"github.com/ilylx/gconv/internal/os/gtime"
+	"github.com/ilylx/gconv/internal/os/gtimer"
+	"sort"
+	"time"
+)
+
+// JanitorConfig is the configuration for the background janitor of memory cache,
+// which synchronizes the asynchronous events and sweeps the expired items.
+type JanitorConfig struct {
+	Interval  time.Duration // Interval of the janitor running, which is 1 second if it is 0.
+	BatchSize int           // Max count of expired keys checked in one sweep, no limit if it is 0.
+	Disabled  bool          // Disables proactive sweeping, the expired items are deleted lazily when retrieved.
+}
+
+// JanitorStats is the statistics of the janitor sweeping.
+type JanitorStats struct {
+	Sweeps        int64         // Count of the sweeps.
+	Reclaimed     int64         // Total count of the reclaimed items of all sweeps.
+	LastReclaimed int           // Count of the reclaimed items of the last sweep.
+	LastDuration  time.Duration // Time cost of the last sweep.
+}
+
+// adapterMemoryJanitorStats is the internal statistics of the janitor sweeping.
+type adapterMemoryJanitorStats struct {
+	sweeps        *gtype.Int64 // Count of the sweeps.
+	reclaimed     *gtype.Int64 // Total count of the reclaimed items.
+	lastReclaimed *gtype.Int   // Count of the reclaimed items of the last sweep.
+	lastDuration  *gtype.Int64 // Time cost in nanoseconds of the last sweep.
+}
+
+const (
+	// gDEFAULT_JANITOR_INTERVAL is the default interval of the janitor running.
+	gDEFAULT_JANITOR_INTERVAL = time.Second
+)
+
+// SetJanitor changes the configuration of the janitor, which restarts the janitor
+// if its interval changes.
+//
+// Note that the janitor still runs for internal data synchronization if the proactive
+// sweeping is disabled.
+func (c *adapterMemory) SetJanitor(config JanitorConfig) {
+	if config.Interval <= 0 {
+		config.Interval = gDEFAULT_JANITOR_INTERVAL
+	}
+	c.janitorMu.Lock()
+	restart := c.janitor != nil && c.janitorConfig.Interval != config.Interval
+	c.janitorConfig = config
+	c.janitorMu.Unlock()
+	if restart {
+		c.startJanitor()
+	}
+}
+
+// GetJanitorStats returns the statistics of the janitor sweeping.
+func (c *adapterMemory) GetJanitorStats() JanitorStats {
+	return JanitorStats{
+		Sweeps:        c.janitorStats.sweeps.Val(),
+		Reclaimed:     c.janitorStats.reclaimed.Val(),
+		LastReclaimed: c.janitorStats.lastReclaimed.Val(),
+		LastDuration:  time.Duration(c.janitorStats.lastDuration.Val()),
+	}
+}
+
+// startJanitor starts the janitor using configured interval,
+// it stops the running janitor if it is started before.
+func (c *adapterMemory) startJanitor() {
+	c.janitorMu.Lock()
+	defer c.janitorMu.Unlock()
+	if c.janitorConfig.Interval <= 0 {
+		c.janitorConfig.Interval = gDEFAULT_JANITOR_INTERVAL
+	}
+	if c.janitor != nil {
+		c.janitor.Close()
+	}
+	c.janitor = gtimer.AddSingleton(c.janitorConfig.Interval, c.syncEventAndClearExpired)
+}
+
+// getJanitorConfig returns the configuration of the janitor.
+func (c *adapterMemory) getJanitorConfig() JanitorConfig {
+	c.janitorMu.RLock()
+	defer c.janitorMu.RUnlock()
+	return c.janitorConfig
+}
+
+// clearExpired sweeps the expired items from the expire sets in order of expiration,
+// and checks at most BatchSize keys of the configuration in one sweep.
+func (c *adapterMemory) clearExpired() {
+	config := c.getJanitorConfig()
+	if config.Disabled {
+		return
+	}
+	var (
+		start       = time.Now()
+		ek          = c.makeExpireKey(gtime.TimestampMilli())
+		expireTimes = make([]int64, 0)
+		checked     = 0
+		reclaimed   = 0
+	)
+	// The sets expiring before current second are all expired.
+	c.expireSetMu.RLock()
+	for expireTime := range c.expireSets {
+		if expireTime <= ek-1000 {
+			expireTimes = append(expireTimes, expireTime)
+		}
+	}
+	c.expireSetMu.RUnlock()
+	sort.Slice(expireTimes, func(i, j int) bool {
+		return expireTimes[i] < expireTimes[j]
+	})
+	for _, expireTime := range expireTimes {
+		if config.BatchSize > 0 && checked >= config.BatchSize {
+			break
+		}
+		expireSet := c.getExpireSet(expireTime)
+		if expireSet == nil {
+			continue
+		}
+		keys := expireSet.Slice()
+		if config.BatchSize > 0 && checked+len(keys) > config.BatchSize {
+			// Only part of the set is swept,
+			// the rest keys are left to the next sweep.
+			keys = keys[:config.BatchSize-checked]
+			for _, key := range keys {
+				if c.clearByKey(key) {
+					reclaimed++
+				}
+				expireSet.Remove(key)
+			}
+			checked += len(keys)
+			break
+		}
+		for _, key := range keys {
+			if c.clearByKey(key) {
+				reclaimed++
+			}
+		}
+		checked += len(keys)
+		// Deleting the set after all of its keys are deleted.
+		c.expireSetMu.Lock()
+		delete(c.expireSets, expireTime)
+		c.expireSetMu.Unlock()
+	}
+	c.janitorStats.sweeps.Add(1)
+	c.janitorStats.reclaimed.Add(int64(reclaimed))
+	c.janitorStats.lastReclaimed.Set(reclaimed)
+	c.janitorStats.lastDuration.Set(int64(time.Since(start)))
+}
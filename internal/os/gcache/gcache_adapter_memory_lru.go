@@ -2,31 +2,35 @@ package gcache
 
 import (
 	"github.com/ilylx/gconv/container/glist"
-	"github.com/ilylx/gconv/container/gmap"
 	"github.com/ilylx/gconv/container/gtype"
 	"github.com/ilylx/gconv/internal/os/gtimer"
+	"sync"
 	"time"
 )
 
-// LRU cache object.
-// It uses list.List from stdlib for its underlying doubly linked list.
+// LRU cache object, which evicts keys using its eviction policy.
+// It uses Least Recently Used algorithm if no eviction policy is specified.
 type adapterMemoryLru struct {
+	mu      sync.Mutex     // Mutex for concurrent safety of <policy>.
 	cache   *adapterMemory // Parent cache object.
-	data    *gmap.Map      // Key mapping to the item of the list.
-	list    *glist.List    // Key list.
+	policy  EvictionPolicy // Eviction policy.
 	rawList *glist.List    // History for key adding.
 	closed  *gtype.Bool    // Closed or not.
 }
 
 // newMemCacheLru creates and returns a new LRU object.
-func newMemCacheLru(cache *adapterMemory) *adapterMemoryLru {
+// The optional parameter <policy> specifies the eviction policy, which is LRU in default.
+func newMemCacheLru(cache *adapterMemory, policy ...EvictionPolicy) *adapterMemoryLru {
 	lru := &adapterMemoryLru{
 		cache:   cache,
-		data:    gmap.New(true),
-		list:    glist.New(true),
 		rawList: glist.New(true),
 		closed:  gtype.NewBool(),
 	}
+	if len(policy) > 0 && policy[0] != nil {
+		lru.policy = policy[0]
+	} else {
+		lru.policy = NewPolicyLru()
+	}
 	gtimer.AddSingleton(time.Second, lru.SyncAndClear)
 	return lru
 }
@@ -38,15 +42,16 @@ func (lru *adapterMemoryLru) Close() {
 
 // Remove deletes the <key> FROM <lru>.
 func (lru *adapterMemoryLru) Remove(key interface{}) {
-	if v := lru.data.Get(key); v != nil {
-		lru.data.Remove(key)
-		lru.list.Remove(v.(*glist.Element))
-	}
+	lru.mu.Lock()
+	lru.policy.Remove(key)
+	lru.mu.Unlock()
 }
 
 // Size returns the size of <lru>.
 func (lru *adapterMemoryLru) Size() int {
-	return lru.data.Size()
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+	return lru.policy.Size()
 }
 
 // Push pushes <key> to the tail of <lru>.
@@ -54,25 +59,15 @@ func (lru *adapterMemoryLru) Push(key interface{}) {
 	lru.rawList.PushBack(key)
 }
 
-// Pop deletes and returns the key from tail of <lru>.
+// Pop deletes and returns the key that should be evicted from <lru>.
 func (lru *adapterMemoryLru) Pop() interface{} {
-	if v := lru.list.PopBack(); v != nil {
-		lru.data.Remove(v)
-		return v
-	}
-	return nil
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+	return lru.policy.Evict()
 }
 
-// Print is used for test only.
-//func (lru *adapterMemoryLru) Print() {
-//    for _, v := range lru.list.FrontAll() {
-//        fmt.Printf("%v ", v)
-//    }
-//    fmt.Println()
-//}
-
-// SyncAndClear synchronizes the keys from <rawList> to <list> and <data>
-// using Least Recently Used algorithm.
+// SyncAndClear synchronizes the keys from <rawList> to the eviction policy,
+// and evicts the keys exceeding the cap or the max cost.
 func (lru *adapterMemoryLru) SyncAndClear() {
 	if lru.closed.Val() {
 		gtimer.Exit()
@@ -81,21 +76,23 @@ func (lru *adapterMemoryLru) SyncAndClear() {
 	// Data synchronization.
 	for {
 		if v := lru.rawList.PopFront(); v != nil {
-			// Deleting the key from list.
-			if v := lru.data.Get(v); v != nil {
-				lru.list.Remove(v.(*glist.Element))
-			}
-			// Pushing key to the head of the list
-			// and setting its list item to hash table for quick indexing.
-			lru.data.Set(v, lru.list.PushFront(v))
+			lru.mu.Lock()
+			lru.policy.Access(v)
+			lru.mu.Unlock()
 		} else {
 			break
 		}
 	}
 	// Data cleaning up.
-	for i := lru.Size() - lru.cache.cap; i > 0; i-- {
-		if s := lru.Pop(); s != nil {
-			lru.cache.clearByKey(s, true)
+	var (
+		cap     = lru.cache.cap.Val()
+		maxCost = lru.cache.maxCost.Val()
+	)
+	for (cap > 0 && lru.Size() > cap) || (maxCost > 0 && lru.cache.totalCost.Val() > maxCost) {
+		s := lru.Pop()
+		if s == nil {
+			break
 		}
+		lru.cache.clearByKey(s, true)
 	}
 }
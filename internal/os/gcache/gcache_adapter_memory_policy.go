@@ -0,0 +1,217 @@
+package gcache
+
+import (
+	"github.com/ilylx/gconv/container/glist"
+)
+
+// EvictionPolicy is the interface for eviction policy of memory cache,
+// which decides which key is evicted if the size of the cache exceeds its cap.
+//
+// Note that the policy is used within mutex lock by the cache,
+// so its implementation does not need to be concurrent-safe.
+type EvictionPolicy interface {
+	// Access records the reading or writing of <key>, which adds <key> to the policy
+	// if it does not exist in the policy.
+	Access(key interface{})
+
+	// Remove deletes <key> from the policy.
+	Remove(key interface{})
+
+	// Evict deletes and returns the key that should be evicted.
+	// It returns nil if there's no key in the policy.
+	Evict() interface{}
+
+	// Size returns the count of keys in the policy.
+	Size() int
+}
+
+// policyLru is the Least Recently Used eviction policy,
+// which evicts the key that has not been accessed for the longest time.
+type policyLru struct {
+	data map[interface{}]*glist.Element // Key mapping to the item of the list.
+	list *glist.List                    // Key list, the most recently used key is at the front.
+}
+
+// policyFifo is the First In First Out eviction policy,
+// which evicts the key that is added earliest, no matter how it is accessed.
+type policyFifo struct {
+	data map[interface{}]*glist.Element // Key mapping to the item of the list.
+	list *glist.List                    // Key list, the latest added key is at the front.
+}
+
+// policyLfu is the Least Frequently Used eviction policy,
+// which evicts the key that is accessed least times.
+// The earliest accessed key is evicted if multiple keys have the same frequency.
+type policyLfu struct {
+	data    map[interface{}]*policyLfuItem // Key mapping to its frequency item.
+	freqs   map[int]*glist.List            // Frequency mapping to its key list.
+	minFreq int                            // Current min frequency of keys.
+}
+
+// policyLfuItem is the frequency item for key of LFU policy.
+type policyLfuItem struct {
+	freq    int            // Access times.
+	element *glist.Element // Element of the key in the list of its frequency.
+}
+
+// NewPolicyLru creates and returns a Least Recently Used eviction policy,
+// which is the default eviction policy of memory cache.
+func NewPolicyLru() EvictionPolicy {
+	return &policyLru{
+		data: make(map[interface{}]*glist.Element),
+		list: glist.New(),
+	}
+}
+
+// NewPolicyFifo creates and returns a First In First Out eviction policy,
+// which suits scan heavy workloads.
+func NewPolicyFifo() EvictionPolicy {
+	return &policyFifo{
+		data: make(map[interface{}]*glist.Element),
+		list: glist.New(),
+	}
+}
+
+// NewPolicyLfu creates and returns a Least Frequently Used eviction policy,
+// which suits hot-key heavy workloads.
+func NewPolicyLfu() EvictionPolicy {
+	return &policyLfu{
+		data:  make(map[interface{}]*policyLfuItem),
+		freqs: make(map[int]*glist.List),
+	}
+}
+
+// Access implements interface EvictionPolicy.
+func (p *policyLru) Access(key interface{}) {
+	if e, ok := p.data[key]; ok {
+		p.list.MoveToFront(e)
+		return
+	}
+	p.data[key] = p.list.PushFront(key)
+}
+
+// Remove implements interface EvictionPolicy.
+func (p *policyLru) Remove(key interface{}) {
+	if e, ok := p.data[key]; ok {
+		delete(p.data, key)
+		p.list.Remove(e)
+	}
+}
+
+// Evict implements interface EvictionPolicy.
+func (p *policyLru) Evict() interface{} {
+	if key := p.list.PopBack(); key != nil {
+		delete(p.data, key)
+		return key
+	}
+	return nil
+}
+
+// Size implements interface EvictionPolicy.
+func (p *policyLru) Size() int {
+	return len(p.data)
+}
+
+// Access implements interface EvictionPolicy.
+func (p *policyFifo) Access(key interface{}) {
+	if _, ok := p.data[key]; !ok {
+		p.data[key] = p.list.PushFront(key)
+	}
+}
+
+// Remove implements interface EvictionPolicy.
+func (p *policyFifo) Remove(key interface{}) {
+	if e, ok := p.data[key]; ok {
+		delete(p.data, key)
+		p.list.Remove(e)
+	}
+}
+
+// Evict implements interface EvictionPolicy.
+func (p *policyFifo) Evict() interface{} {
+	if key := p.list.PopBack(); key != nil {
+		delete(p.data, key)
+		return key
+	}
+	return nil
+}
+
+// Size implements interface EvictionPolicy.
+func (p *policyFifo) Size() int {
+	return len(p.data)
+}
+
+// Access implements interface EvictionPolicy.
+func (p *policyLfu) Access(key interface{}) {
+	item, ok := p.data[key]
+	if !ok {
+		p.data[key] = &policyLfuItem{
+			freq:    1,
+			element: p.getOrNewList(1).PushFront(key),
+		}
+		p.minFreq = 1
+		return
+	}
+	p.removeFromFreqList(key, item)
+	item.freq++
+	item.element = p.getOrNewList(item.freq).PushFront(key)
+}
+
+// Remove implements interface EvictionPolicy.
+func (p *policyLfu) Remove(key interface{}) {
+	if item, ok := p.data[key]; ok {
+		delete(p.data, key)
+		p.removeFromFreqList(key, item)
+	}
+}
+
+// Evict implements interface EvictionPolicy.
+func (p *policyLfu) Evict() interface{} {
+	if len(p.data) == 0 {
+		return nil
+	}
+	// The <minFreq> might be out of date after removing, so it searches upward.
+	for {
+		if list, ok := p.freqs[p.minFreq]; ok && list.Len() > 0 {
+			key := list.PopBack()
+			delete(p.data, key)
+			if list.Len() == 0 {
+				delete(p.freqs, p.minFreq)
+			}
+			return key
+		}
+		p.minFreq++
+	}
+}
+
+// Size implements interface EvictionPolicy.
+func (p *policyLfu) Size() int {
+	return len(p.data)
+}
+
+// getOrNewList returns the key list of frequency <freq>,
+// it creates and returns a new one if it does not exist.
+func (p *policyLfu) getOrNewList(freq int) *glist.List {
+	list, ok := p.freqs[freq]
+	if !ok {
+		list = glist.New()
+		p.freqs[freq] = list
+	}
+	return list
+}
+
+// removeFromFreqList deletes <key> from the key list of its frequency,
+// and updates the <minFreq> if the list becomes empty.
+func (p *policyLfu) removeFromFreqList(key interface{}, item *policyLfuItem) {
+	list, ok := p.freqs[item.freq]
+	if !ok {
+		return
+	}
+	list.Remove(item.element)
+	if list.Len() == 0 {
+		delete(p.freqs, item.freq)
+		if p.minFreq == item.freq {
+			p.minFreq++
+		}
+	}
+}
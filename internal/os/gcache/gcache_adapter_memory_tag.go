@@ -0,0 +1,91 @@
+package gcache
+
+import (
+	"time"
+)
+
+// SetWithTags sets cache with <key>-<value> pair, which is expired after <duration>,
+// and associates <key> with given <tags>, so that all keys of a tag can be removed
+// by RemoveByTag in one call. The old tags of <key> are replaced by <tags>.
+//
+// It does not expire if <duration> == 0.
+// It deletes the <key> if <duration> < 0.
+func (c *adapterMemory) SetWithTags(key interface{}, value interface{}, duration time.Duration, tags ...string) error {
+	expireTime := c.getInternalExpire(duration)
+	cost := c.getCost(key, value)
+	c.dataMu.Lock()
+	c.doSetItem(key, adapterMemoryItem{
+		v: value,
+		e: expireTime,
+		c: cost,
+	})
+	c.untagKey(key)
+	c.tagKey(key, tags)
+	c.dataMu.Unlock()
+	c.eventList.PushBack(&adapterMemoryEvent{
+		k: key,
+		e: expireTime,
+	})
+	return nil
+}
+
+// RemoveByTag deletes all keys associated with <tag>, and returns the deleted keys.
+func (c *adapterMemory) RemoveByTag(tag string) (keys []interface{}, err error) {
+	c.tagMu.RLock()
+	keys = make([]interface{}, 0, len(c.tagKeys[tag]))
+	for k := range c.tagKeys[tag] {
+		keys = append(keys, k)
+	}
+	c.tagMu.RUnlock()
+	if len(keys) == 0 {
+		return keys, nil
+	}
+	if _, err = c.Remove(keys...); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// GetTags returns the tags associated with <key>.
+func (c *adapterMemory) GetTags(key interface{}) []string {
+	c.tagMu.RLock()
+	defer c.tagMu.RUnlock()
+	tags := make([]string, len(c.keyTags[key]))
+	copy(tags, c.keyTags[key])
+	return tags
+}
+
+// tagKey associates <key> with <tags>.
+func (c *adapterMemory) tagKey(key interface{}, tags []string) {
+	if len(tags) == 0 {
+		return
+	}
+	c.tagMu.Lock()
+	defer c.tagMu.Unlock()
+	for _, tag := range tags {
+		keys, ok := c.tagKeys[tag]
+		if !ok {
+			keys = make(map[interface{}]struct{})
+			c.tagKeys[tag] = keys
+		}
+		if _, ok = keys[key]; !ok {
+			keys[key] = struct{}{}
+			c.keyTags[key] = append(c.keyTags[key], tag)
+		}
+	}
+}
+
+// untagKey deletes all associations of <key> and its tags.
+func (c *adapterMemory) untagKey(key interface{}) {
+	c.tagMu.Lock()
+	defer c.tagMu.Unlock()
+	for _, tag := range c.keyTags[key] {
+		if keys, ok := c.tagKeys[tag]; ok {
+			delete(keys, key)
+			if len(keys) == 0 {
+				delete(c.tagKeys, tag)
+			}
+		}
+	}
+	delete(c.keyTags, key)
+}
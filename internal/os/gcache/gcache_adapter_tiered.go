@@ -0,0 +1,391 @@
+package gcache
+
+import (
+	"github.com/ilylx/gconv/container/glist"
+	"github.com/ilylx/gconv/container/gtype"
+	"github.com/ilylx/gconv/internal/os/gtimer"
+	"sync"
+	"time"
+)
+
+// adapterTiered is the two-level tiered cache adapter, which composes a small fast
+// memory cache in front of a slower adapter, eg: Redis or file adapter.
+// The hot keys are served by the local memory cache, while the slower adapter
+// remains authoritative.
+type adapterTiered struct {
+	local     *adapterMemory // Local fast memory cache.
+	remote    Adapter        // Slower authoritative adapter.
+	config    TieredConfig   // Configuration.
+	flushMu   sync.Mutex     // Mutex for writing to <remote> in order.
+	writeList *glist.List    // Pending writing operations of <remote> in write-back mode.
+	closed    *gtype.Bool    // Closed or not.
+}
+
+// TieredMode is the writing mode of tiered adapter.
+type TieredMode int
+
+// TieredConfig is the configuration for tiered adapter.
+type TieredConfig struct {
+	Mode          TieredMode    // Writing mode, which is TIERED_WRITE_THROUGH in default.
+	LocalCap      int           // Max item count of the local cache, no limit if it is 0.
+	LocalTTL      time.Duration // Max TTL of items in the local cache, which uses the TTL of the item if it is 0.
+	FlushInterval time.Duration // Interval flushing pending writes in write-back mode, which is 100ms if it is 0.
+}
+
+const (
+	// TIERED_WRITE_THROUGH writes both the local cache and the slower adapter synchronously.
+	TIERED_WRITE_THROUGH TieredMode = iota
+
+	// TIERED_WRITE_BACK writes the local cache synchronously and the slower adapter
+	// asynchronously in order, which means the slower adapter is eventually consistent.
+	TIERED_WRITE_BACK
+
+	// gTIERED_FLUSH_INTERVAL is the default interval flushing pending writes in write-back mode.
+	gTIERED_FLUSH_INTERVAL = 100 * time.Millisecond
+)
+
+// NewAdapterTiered creates and returns a two-level tiered cache adapter, which serves
+// the hot keys using a local memory cache in front of the slower adapter <remote>.
+//
+// The reading operations retrieve the local cache first, and then the <remote> if missed.
+// The writing operations write both levels according to the writing mode of <config>.
+// The operations that depend on the state of <remote>, eg: Update, Size, Data, flush
+// the pending writes before they are done in write-back mode.
+//
+// Eg:
+//
+//	cache := gcache.New()
+//	cache.SetAdapter(gcache.NewAdapterTiered(redisAdapter, gcache.TieredConfig{
+//		Mode:     gcache.TIERED_WRITE_BACK,
+//		LocalCap: 10000,
+//		LocalTTL: time.Minute,
+//	}))
+func NewAdapterTiered(remote Adapter, config ...TieredConfig) Adapter {
+	c := &adapterTiered{
+		remote:    remote,
+		writeList: glist.New(true),
+		closed:    gtype.NewBool(),
+	}
+	if len(config) > 0 {
+		c.config = config[0]
+	}
+	if c.config.FlushInterval <= 0 {
+		c.config.FlushInterval = gTIERED_FLUSH_INTERVAL
+	}
+	c.local = newAdapterMemory(c.config.LocalCap)
+	c.local.startJanitor()
+	if c.config.Mode == TIERED_WRITE_BACK {
+		gtimer.AddSingleton(c.config.FlushInterval, c.flushPending)
+	}
+	return c
+}
+
+// Set sets cache with <key>-<value> pair, which is expired after <duration>.
+//
+// It does not expire if <duration> == 0.
+// It deletes the <key> if <duration> < 0.
+func (c *adapterTiered) Set(key interface{}, value interface{}, duration time.Duration) error {
+	if c.config.Mode == TIERED_WRITE_BACK {
+		if err := c.local.Set(key, value, c.getLocalDuration(duration)); err != nil {
+			return err
+		}
+		return c.write(func() error {
+			return c.remote.Set(key, value, duration)
+		})
+	}
+	// The slower adapter is written first in write-through mode,
+	// so the local cache never serves the value failing writing to the slower adapter.
+	if err := c.write(func() error {
+		return c.remote.Set(key, value, duration)
+	}); err != nil {
+		return err
+	}
+	return c.local.Set(key, value, c.getLocalDuration(duration))
+}
+
+// Sets batch sets cache with key-value pairs by <data>, which is expired after <duration>.
+//
+// It does not expire if <duration> == 0.
+// It deletes the keys of <data> if <duration> < 0 or given <value> is nil.
+func (c *adapterTiered) Sets(data map[interface{}]interface{}, duration time.Duration) error {
+	if c.config.Mode == TIERED_WRITE_BACK {
+		if err := c.local.Sets(data, c.getLocalDuration(duration)); err != nil {
+			return err
+		}
+		// It copies the <data> as the caller might change it before the pending write is flushed.
+		pending := make(map[interface{}]interface{}, len(data))
+		for k, v := range data {
+			pending[k] = v
+		}
+		return c.write(func() error {
+			return c.remote.Sets(pending, duration)
+		})
+	}
+	if err := c.write(func() error {
+		return c.remote.Sets(data, duration)
+	}); err != nil {
+		return err
+	}
+	return c.local.Sets(data, c.getLocalDuration(duration))
+}
+
+// SetIfNotExist sets cache with <key>-<value> pair which is expired after <duration>
+// if <key> does not exist in the slower adapter. It returns true the <key> dose not exist
+// in the cache and it sets <value> successfully to the cache, or else it returns false.
+func (c *adapterTiered) SetIfNotExist(key interface{}, value interface{}, duration time.Duration) (bool, error) {
+	if err := c.Flush(); err != nil {
+		return false, err
+	}
+	ok, err := c.remote.SetIfNotExist(key, value, duration)
+	if err != nil || !ok {
+		return ok, err
+	}
+	// The <value> might be a function, so it retrieves the real value from <remote>.
+	if v, err := c.remote.Get(key); err == nil && v != nil {
+		_ = c.local.Set(key, v, c.getLocalDuration(duration))
+	}
+	return ok, nil
+}
+
+// Get retrieves and returns the associated value of given <key>.
+// It returns nil if it does not exist or its value is nil.
+func (c *adapterTiered) Get(key interface{}) (interface{}, error) {
+	if v, err := c.local.Get(key); err != nil || v != nil {
+		return v, err
+	}
+	v, err := c.remote.Get(key)
+	if err != nil || v == nil {
+		return v, err
+	}
+	c.setLocalFromRemote(key, v)
+	return v, nil
+}
+
+// GetOrSet retrieves and returns the value of <key>, or sets <key>-<value> pair and
+// returns <value> if <key> does not exist in the cache.
+func (c *adapterTiered) GetOrSet(key interface{}, value interface{}, duration time.Duration) (interface{}, error) {
+	if v, err := c.local.Get(key); err != nil || v != nil {
+		return v, err
+	}
+	if err := c.Flush(); err != nil {
+		return nil, err
+	}
+	v, err := c.remote.GetOrSet(key, value, duration)
+	if err != nil || v == nil {
+		return v, err
+	}
+	c.setLocalFromRemote(key, v)
+	return v, nil
+}
+
+// GetOrSetFunc retrieves and returns the value of <key>, or sets <key> with result of
+// function <f> and returns its result if <key> does not exist in the cache.
+func (c *adapterTiered) GetOrSetFunc(key interface{}, f func() (interface{}, error), duration time.Duration) (interface{}, error) {
+	if v, err := c.local.Get(key); err != nil || v != nil {
+		return v, err
+	}
+	if err := c.Flush(); err != nil {
+		return nil, err
+	}
+	v, err := c.remote.GetOrSetFunc(key, f, duration)
+	if err != nil || v == nil {
+		return v, err
+	}
+	c.setLocalFromRemote(key, v)
+	return v, nil
+}
+
+// GetOrSetFuncLock retrieves and returns the value of <key>, or sets <key> with result of
+// function <f> and returns its result if <key> does not exist in the cache.
+func (c *adapterTiered) GetOrSetFuncLock(key interface{}, f func() (interface{}, error), duration time.Duration) (interface{}, error) {
+	if v, err := c.local.Get(key); err != nil || v != nil {
+		return v, err
+	}
+	if err := c.Flush(); err != nil {
+		return nil, err
+	}
+	v, err := c.remote.GetOrSetFuncLock(key, f, duration)
+	if err != nil || v == nil {
+		return v, err
+	}
+	c.setLocalFromRemote(key, v)
+	return v, nil
+}
+
+// Contains returns true if <key> exists in the cache, or else returns false.
+func (c *adapterTiered) Contains(key interface{}) (bool, error) {
+	v, err := c.Get(key)
+	if err != nil {
+		return false, err
+	}
+	return v != nil, nil
+}
+
+// GetExpire retrieves and returns the expiration of <key> in the slower adapter.
+//
+// It returns 0 if the <key> does not expire.
+// It returns -1 if the <key> does not exist in the cache.
+func (c *adapterTiered) GetExpire(key interface{}) (time.Duration, error) {
+	if err := c.Flush(); err != nil {
+		return -1, err
+	}
+	return c.remote.GetExpire(key)
+}
+
+// Remove deletes the one or more keys from cache, and returns its value.
+// If multiple keys are given, it returns the value of the deleted last item.
+func (c *adapterTiered) Remove(keys ...interface{}) (value interface{}, err error) {
+	if value, err = c.local.Remove(keys...); err != nil {
+		return nil, err
+	}
+	if c.config.Mode == TIERED_WRITE_BACK {
+		return value, c.write(func() error {
+			_, err := c.remote.Remove(keys...)
+			return err
+		})
+	}
+	return c.remote.Remove(keys...)
+}
+
+// Update updates the value of <key> without changing its expiration and returns the old value.
+// The returned value <exist> is false if the <key> does not exist in the cache.
+func (c *adapterTiered) Update(key interface{}, value interface{}) (oldValue interface{}, exist bool, err error) {
+	if err = c.Flush(); err != nil {
+		return
+	}
+	if oldValue, exist, err = c.remote.Update(key, value); err != nil || !exist {
+		return
+	}
+	_, _, err = c.local.Update(key, value)
+	return
+}
+
+// UpdateExpire updates the expiration of <key> and returns the old expiration duration value.
+//
+// It returns -1 and does nothing if the <key> does not exist in the cache.
+// It deletes the <key> if <duration> < 0.
+func (c *adapterTiered) UpdateExpire(key interface{}, duration time.Duration) (oldDuration time.Duration, err error) {
+	if err = c.Flush(); err != nil {
+		return
+	}
+	if oldDuration, err = c.remote.UpdateExpire(key, duration); err != nil || oldDuration == -1 {
+		return
+	}
+	_, err = c.local.UpdateExpire(key, c.getLocalDuration(duration))
+	return
+}
+
+// Size returns the number of items in the slower adapter.
+func (c *adapterTiered) Size() (size int, err error) {
+	if err = c.Flush(); err != nil {
+		return
+	}
+	return c.remote.Size()
+}
+
+// Data returns a copy of all key-value pairs in the slower adapter as map type.
+func (c *adapterTiered) Data() (map[interface{}]interface{}, error) {
+	if err := c.Flush(); err != nil {
+		return nil, err
+	}
+	return c.remote.Data()
+}
+
+// Keys returns all keys in the slower adapter as slice.
+func (c *adapterTiered) Keys() ([]interface{}, error) {
+	if err := c.Flush(); err != nil {
+		return nil, err
+	}
+	return c.remote.Keys()
+}
+
+// Values returns all values in the slower adapter as slice.
+func (c *adapterTiered) Values() ([]interface{}, error) {
+	if err := c.Flush(); err != nil {
+		return nil, err
+	}
+	return c.remote.Values()
+}
+
+// Clear clears all data of both levels.
+// Note that this function is sensitive and should be carefully used.
+func (c *adapterTiered) Clear() error {
+	if err := c.Flush(); err != nil {
+		return err
+	}
+	if err := c.local.Clear(); err != nil {
+		return err
+	}
+	return c.remote.Clear()
+}
+
+// Close flushes the pending writes, and closes both levels.
+func (c *adapterTiered) Close() error {
+	if !c.closed.Cas(false, true) {
+		return nil
+	}
+	err := c.Flush()
+	_ = c.local.Close()
+	if closeErr := c.remote.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Flush writes the pending writes to the slower adapter in order in write-back mode.
+// It returns the first error of the writes, but it does not stop writing the rest.
+func (c *adapterTiered) Flush() error {
+	c.flushMu.Lock()
+	defer c.flushMu.Unlock()
+	var err error
+	for {
+		v := c.writeList.PopFront()
+		if v == nil {
+			break
+		}
+		if e := v.(func() error)(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// flushPending is the timer job flushing the pending writes in write-back mode.
+// The errors are ignored as there's no caller to receive them.
+func (c *adapterTiered) flushPending() {
+	if c.closed.Val() {
+		gtimer.Exit()
+		return
+	}
+	_ = c.Flush()
+}
+
+// write does the writing operation <f> of the slower adapter according to the writing mode.
+func (c *adapterTiered) write(f func() error) error {
+	if c.config.Mode == TIERED_WRITE_BACK {
+		c.writeList.PushBack(f)
+		return nil
+	}
+	c.flushMu.Lock()
+	defer c.flushMu.Unlock()
+	return f()
+}
+
+// setLocalFromRemote sets <key>-<value> retrieved from the slower adapter to the local cache,
+// whose TTL is no longer than the TTL in the slower adapter.
+func (c *adapterTiered) setLocalFromRemote(key interface{}, value interface{}) {
+	duration, err := c.remote.GetExpire(key)
+	if err != nil || duration < 0 {
+		return
+	}
+	_ = c.local.Set(key, value, c.getLocalDuration(duration))
+}
+
+// getLocalDuration returns the TTL for the local cache with given TTL <duration>,
+// which is capped by the LocalTTL of configuration.
+func (c *adapterTiered) getLocalDuration(duration time.Duration) time.Duration {
+	if c.config.LocalTTL > 0 && (duration == 0 || duration > c.config.LocalTTL) {
+		return c.config.LocalTTL
+	}
+	return duration
+}
@@ -0,0 +1,61 @@
+package gcache
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// testFailingAdapter is the slower adapter whose writing operations fail.
+type testFailingAdapter struct {
+	*adapterMemory
+}
+
+var errTestWrite = errors.New("write error")
+
+func (a testFailingAdapter) Set(key interface{}, value interface{}, duration time.Duration) error {
+	return errTestWrite
+}
+
+func (a testFailingAdapter) Sets(data map[interface{}]interface{}, duration time.Duration) error {
+	return errTestWrite
+}
+
+func Test_AdapterTiered_WriteThroughError(t *testing.T) {
+	c := NewAdapterTiered(testFailingAdapter{newAdapterMemory()})
+	defer c.Close()
+	if err := c.Set("k1", 1, 0); err != errTestWrite {
+		t.Fatalf("expected write error, got %v", err)
+	}
+	if err := c.Sets(map[interface{}]interface{}{"k2": 2}, 0); err != errTestWrite {
+		t.Fatalf("expected write error, got %v", err)
+	}
+	// The local cache does not serve the values failing writing to the slower adapter.
+	for _, key := range []string{"k1", "k2"} {
+		if v, _ := c.Get(key); v != nil {
+			t.Errorf("expected %s not to be cached, got %v", key, v)
+		}
+	}
+}
+
+func Test_AdapterTiered_WriteBackSets(t *testing.T) {
+	remote := newAdapterMemory()
+	c := NewAdapterTiered(remote, TieredConfig{Mode: TIERED_WRITE_BACK, FlushInterval: time.Hour})
+	defer c.Close()
+	data := map[interface{}]interface{}{"k": 1}
+	if err := c.Sets(data, 0); err != nil {
+		t.Fatal(err)
+	}
+	// Changing the map after Sets does not change the pending write.
+	data["k"] = 2
+	data["other"] = 3
+	if err := c.(*adapterTiered).Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := remote.Get("k"); v != 1 {
+		t.Errorf("expected 1, got %v", v)
+	}
+	if ok, _ := remote.Contains("other"); ok {
+		t.Error("expected other not to be written")
+	}
+}
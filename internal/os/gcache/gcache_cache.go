@@ -1,9 +1,10 @@
 package gcache
 
 import (
+	"errors"
 	"github.com/ilylx/gconv"
 	"github.com/ilylx/gconv/container/gvar"
-	"github.com/ilylx/gconv/internal/os/gtimer"
+	"github.com/ilylx/gconv/internal/grand"
 	"time"
 )
 
@@ -15,13 +16,24 @@ type Cache struct {
 // New creates and returns a new cache object using default memory adapter.
 // Note that the LRU feature is only available using memory adapter.
 func New(lruCap ...int) *Cache {
-	memAdapter := newAdapterMemory(lruCap...)
+	return newWithAdapterMemory(newAdapterMemory(lruCap...))
+}
+
+// NewWithPolicy creates and returns a new cache object using default memory adapter,
+// which evicts keys using given eviction <policy> if the size of the cache exceeds <cap>.
+// The <policy> can be NewPolicyLru, NewPolicyLfu, NewPolicyFifo or any custom policy.
+func NewWithPolicy(cap int, policy EvictionPolicy) *Cache {
+	return newWithAdapterMemory(newAdapterMemoryWithPolicy(cap, policy))
+}
+
+// newWithAdapterMemory creates and returns a new cache object using given memory adapter.
+func newWithAdapterMemory(memAdapter *adapterMemory) *Cache {
 	c := &Cache{
 		Adapter: memAdapter,
 	}
 	// Here may be a "timer leak" if adapter is manually changed from memory adapter.
 	// Do not worry about this, as adapter is less changed and it dose nothing if it's not used.
-	gtimer.AddSingleton(time.Second, memAdapter.syncEventAndClearExpired)
+	memAdapter.startJanitor()
 	return c
 }
 
@@ -32,12 +44,186 @@ func (c *Cache) SetAdapter(adapter Adapter) {
 	c.Adapter = adapter
 }
 
+// SetCap sets the max item count of the cache, and the least recently used items
+// are evicted if the size of the cache exceeds <cap>. There's no limit if <cap> <= 0.
+//
+// Note that the LRU feature is only available using memory adapter,
+// it returns an error if the adapter is changed from memory adapter.
+func (c *Cache) SetCap(cap int) error {
+	if memAdapter, ok := c.Adapter.(*adapterMemory); ok {
+		memAdapter.SetCap(cap)
+		return nil
+	}
+	return errors.New("cap is only available using memory adapter")
+}
+
+// SetMaxCost sets the max total cost of the items in the cache, and the least recently used
+// items are evicted if the total cost exceeds <maxCost>. There's no limit if <maxCost> <= 0.
+//
+// Note that the cost feature is only available using memory adapter.
+func (c *Cache) SetMaxCost(maxCost int64) error {
+	if memAdapter, ok := c.Adapter.(*adapterMemory); ok {
+		memAdapter.SetMaxCost(maxCost)
+		return nil
+	}
+	return errors.New("max cost is only available using memory adapter")
+}
+
+// SetCostFunc sets the function <f> calculating the cost of items which are set without cost,
+// eg: the length of []byte value.
+//
+// Note that the cost feature is only available using memory adapter.
+func (c *Cache) SetCostFunc(f CostFunc) error {
+	if memAdapter, ok := c.Adapter.(*adapterMemory); ok {
+		memAdapter.SetCostFunc(f)
+		return nil
+	}
+	return errors.New("cost function is only available using memory adapter")
+}
+
+// SetWithCost sets cache with <key>-<value> pair of given <cost>, which is expired after <duration>.
+// It does not expire if <duration> == 0.
+//
+// Note that the cost feature is only available using memory adapter.
+func (c *Cache) SetWithCost(key interface{}, value interface{}, duration time.Duration, cost int64) error {
+	if memAdapter, ok := c.Adapter.(*adapterMemory); ok {
+		return memAdapter.SetWithCost(key, value, duration, cost)
+	}
+	return errors.New("cost is only available using memory adapter")
+}
+
+// SetTTLJitter sets the max <ratio> in range of [0, 1] of TTL that the expiration is randomly
+// brought forward, which spreads the expirations of items set together to prevent them
+// expiring in the same second. It disables the jitter if <ratio> <= 0.
+// The optional parameter <source> specifies the random generator of the jitter.
+//
+// Note that the jitter is only available using memory adapter.
+func (c *Cache) SetTTLJitter(ratio float64, source ...*grand.Source) error {
+	if memAdapter, ok := c.Adapter.(*adapterMemory); ok {
+		memAdapter.SetTTLJitter(ratio, source...)
+		return nil
+	}
+	return errors.New("ttl jitter is only available using memory adapter")
+}
+
+// SetWithIdleTTL sets cache with <key>-<value> pair in sliding expiration mode,
+// which is expired after it is not retrieved for <idle> duration. Each successful
+// retrieving extends its expiration to <idle> later.
+//
+// Note that the sliding expiration is only available using memory adapter.
+func (c *Cache) SetWithIdleTTL(key interface{}, value interface{}, idle time.Duration) error {
+	if memAdapter, ok := c.Adapter.(*adapterMemory); ok {
+		return memAdapter.SetWithIdleTTL(key, value, idle)
+	}
+	return errors.New("idle ttl is only available using memory adapter")
+}
+
+// SetJanitor changes the configuration of the background janitor, which sweeps the
+// expired items proactively. The proactive sweeping can be disabled for lazy-expiration
+// workloads, and then the expired items are deleted when they are retrieved.
+//
+// Note that the janitor is only available using memory adapter or file adapter.
+func (c *Cache) SetJanitor(config JanitorConfig) error {
+	if adapter, ok := c.Adapter.(apiJanitor); ok {
+		adapter.SetJanitor(config)
+		return nil
+	}
+	return errors.New("janitor is not supported by the adapter")
+}
+
+// GetJanitorStats returns the statistics of the janitor sweeping,
+// eg: how many items each sweep reclaimed.
+//
+// Note that the janitor is only available using memory adapter or file adapter.
+func (c *Cache) GetJanitorStats() (JanitorStats, error) {
+	if adapter, ok := c.Adapter.(apiJanitor); ok {
+		return adapter.GetJanitorStats(), nil
+	}
+	return JanitorStats{}, errors.New("janitor is not supported by the adapter")
+}
+
+// SetWithTags sets cache with <key>-<value> pair, which is expired after <duration>,
+// and associates <key> with given <tags>, so that all keys of a tag can be removed
+// by RemoveByTag in one call. It does not expire if <duration> == 0.
+//
+// Note that the tag feature is only available using memory adapter.
+func (c *Cache) SetWithTags(key interface{}, value interface{}, duration time.Duration, tags ...string) error {
+	if memAdapter, ok := c.Adapter.(*adapterMemory); ok {
+		return memAdapter.SetWithTags(key, value, duration, tags...)
+	}
+	return errors.New("tags are only available using memory adapter")
+}
+
+// RemoveByTag deletes all keys associated with <tag>, and returns the deleted keys.
+//
+// Note that the tag feature is only available using memory adapter.
+func (c *Cache) RemoveByTag(tag string) ([]interface{}, error) {
+	if memAdapter, ok := c.Adapter.(*adapterMemory); ok {
+		return memAdapter.RemoveByTag(tag)
+	}
+	return nil, errors.New("tags are only available using memory adapter")
+}
+
+// OnEvicted sets the callback <f> which is called if an item is removed from the cache
+// by LRU eviction, Remove or Clear. It is called synchronously, so it should not block.
+//
+// Note that the callback is only available using memory adapter or file adapter.
+func (c *Cache) OnEvicted(f CallbackFunc) error {
+	if adapter, ok := c.Adapter.(apiCallback); ok {
+		adapter.SetOnEvicted(f)
+		return nil
+	}
+	return errors.New("callback is not supported by the adapter")
+}
+
+// OnExpired sets the callback <f> which is called if an expired item is cleared from
+// the cache. It is called synchronously, so it should not block.
+//
+// Note that the callback is only available using memory adapter or file adapter.
+func (c *Cache) OnExpired(f CallbackFunc) error {
+	if adapter, ok := c.Adapter.(apiCallback); ok {
+		adapter.SetOnExpired(f)
+		return nil
+	}
+	return errors.New("callback is not supported by the adapter")
+}
+
 // GetVar retrieves and returns the value of <key> as gvar.Var.
 func (c *Cache) GetVar(key interface{}) (*gvar.Var, error) {
 	v, err := c.Get(key)
 	return gvar.New(v), err
 }
 
+// GetMap retrieves and returns the values of given <keys> as map.
+// The keys that do not exist are not contained in the result.
+//
+// The memory adapter retrieves all the keys within one locking, and other adapters
+// retrieve the keys one by one.
+func (c *Cache) GetMap(keys []interface{}) (map[interface{}]interface{}, error) {
+	if adapter, ok := c.Adapter.(apiGetMap); ok {
+		return adapter.GetMap(keys)
+	}
+	data := make(map[interface{}]interface{}, len(keys))
+	for _, key := range keys {
+		v, err := c.Get(key)
+		if err != nil {
+			return nil, err
+		}
+		if v != nil {
+			data[key] = v
+		}
+	}
+	return data, nil
+}
+
+// SetMap batch sets cache with key-value pairs by <data>, which is expired after <duration>.
+// It is alias of Sets, and the memory adapter sets all the pairs within one locking.
+//
+// It does not expire if <duration> == 0.
+func (c *Cache) SetMap(data map[interface{}]interface{}, duration time.Duration) error {
+	return c.Sets(data, duration)
+}
+
 // Removes deletes <keys> in the cache.
 // Deprecated, use Remove instead.
 func (c *Cache) Removes(keys []interface{}) error {
@@ -53,3 +239,20 @@ func (c *Cache) KeyStrings() ([]string, error) {
 	}
 	return gconv.Strings(keys), nil
 }
+
+// apiCallback is used for type assert api for callback setting of adapter.
+type apiCallback interface {
+	SetOnEvicted(f CallbackFunc)
+	SetOnExpired(f CallbackFunc)
+}
+
+// apiJanitor is used for type assert api for janitor of adapter.
+type apiJanitor interface {
+	SetJanitor(config JanitorConfig)
+	GetJanitorStats() JanitorStats
+}
+
+// apiGetMap is used for type assert api for GetMap of adapter.
+type apiGetMap interface {
+	GetMap(keys []interface{}) (map[interface{}]interface{}, error)
+}
@@ -0,0 +1,131 @@
+package gcache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Typed is the generic typed wrapper of Cache, which retrieves and sets the values
+// as type <V> using keys of type <K>, eliminating the type assertion at call sites.
+//
+// The <ctx> of its methods is checked before the operation, and the operation is not done
+// if <ctx> is cancelled or timeout, which returns the error of <ctx>. A nil <ctx> is ignored.
+type Typed[K comparable, V any] struct {
+	cache *Cache // Underlying cache.
+}
+
+// NewTyped creates and returns a typed wrapper of given <cache>.
+// It uses the default cache if <cache> is not given.
+//
+// Eg:
+//
+//	users := gcache.NewTyped[int, *User]()
+//	user, err := users.GetOrLoad(ctx, 1, loadUser, time.Minute)
+func NewTyped[K comparable, V any](cache ...*Cache) *Typed[K, V] {
+	t := &Typed[K, V]{
+		cache: defaultCache,
+	}
+	if len(cache) > 0 && cache[0] != nil {
+		t.cache = cache[0]
+	}
+	return t
+}
+
+// Cache returns the underlying cache.
+func (t *Typed[K, V]) Cache() *Cache {
+	return t.cache
+}
+
+// Set sets cache with <key>-<value> pair, which is expired after <duration>.
+// It does not expire if <duration> == 0.
+func (t *Typed[K, V]) Set(ctx context.Context, key K, value V, duration time.Duration) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+	return t.cache.Set(key, value, duration)
+}
+
+// Get retrieves and returns the value of <key>.
+// The returned <found> is false if <key> does not exist in the cache.
+// It returns an error if the value in the cache is not type of <V>.
+func (t *Typed[K, V]) Get(ctx context.Context, key K) (value V, found bool, err error) {
+	if err = checkContext(ctx); err != nil {
+		return value, false, err
+	}
+	v, err := t.cache.Get(key)
+	if err != nil || v == nil {
+		return value, false, err
+	}
+	return t.convert(key, v)
+}
+
+// GetOrLoad retrieves and returns the value of <key>, or sets <key> with the result of
+// <loader> and returns it if <key> does not exist in the cache. The key-value pair expires
+// after the optional <duration>, and it does not expire if <duration> is not given.
+//
+// The <loader> is called within writing mutex lock of the cache, so concurrent callers
+// loading the same key do not call <loader> repeatedly. The result is not cached if
+// <loader> returns error. The <ctx> is also checked before calling <loader>, as the waiting
+// for the lock might be long, and nothing is cached if <ctx> is done.
+func (t *Typed[K, V]) GetOrLoad(ctx context.Context, key K, loader func(K) (V, error), duration ...time.Duration) (V, error) {
+	var expire time.Duration
+	if len(duration) > 0 {
+		expire = duration[0]
+	}
+	if err := checkContext(ctx); err != nil {
+		var value V
+		return value, err
+	}
+	v, err := t.cache.GetOrSetFuncLock(key, func() (interface{}, error) {
+		if err := checkContext(ctx); err != nil {
+			return nil, err
+		}
+		return loader(key)
+	}, expire)
+	if err != nil || v == nil {
+		var value V
+		return value, err
+	}
+	value, _, err := t.convert(key, v)
+	return value, err
+}
+
+// Remove deletes the one or more keys from cache.
+func (t *Typed[K, V]) Remove(ctx context.Context, keys ...K) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+	array := make([]interface{}, len(keys))
+	for i, key := range keys {
+		array[i] = key
+	}
+	_, err := t.cache.Remove(array...)
+	return err
+}
+
+// Contains returns true if <key> exists in the cache, or else returns false.
+func (t *Typed[K, V]) Contains(ctx context.Context, key K) (bool, error) {
+	if err := checkContext(ctx); err != nil {
+		return false, err
+	}
+	return t.cache.Contains(key)
+}
+
+// checkContext returns the error of <ctx> if it is done, or else it returns nil.
+func checkContext(ctx context.Context) error {
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Err()
+}
+
+// convert asserts and returns value <v> of <key> as type <V>.
+func (t *Typed[K, V]) convert(key K, v interface{}) (value V, found bool, err error) {
+	value, ok := v.(V)
+	if !ok {
+		return value, true, errors.New(fmt.Sprintf(`value of key "%v" is type of %T, but %T is expected`, key, v, value))
+	}
+	return value, true, nil
+}
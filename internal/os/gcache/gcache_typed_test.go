@@ -0,0 +1,41 @@
+package gcache
+
+import (
+	"context"
+	"testing"
+)
+
+func Test_Typed_Context(t *testing.T) {
+	var (
+		typed       = NewTyped[string, int](New())
+		ctx, cancel = context.WithCancel(context.Background())
+		loaded      = 0
+		loader      = func(key string) (int, error) {
+			loaded++
+			return 1, nil
+		}
+	)
+	if v, err := typed.GetOrLoad(ctx, "k", loader); err != nil || v != 1 {
+		t.Fatalf("unexpected result: %v, %v", v, err)
+	}
+	cancel()
+	if err := typed.Set(ctx, "k", 2, 0); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if _, _, err := typed.Get(ctx, "k"); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if _, err := typed.GetOrLoad(ctx, "other", loader); err != context.Canceled || loaded != 1 {
+		t.Errorf("expected context.Canceled without loading, got %v, %d", err, loaded)
+	}
+	if err := typed.Remove(ctx, "k"); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	// Nothing is changed by the cancelled operations.
+	if v, found, err := typed.Get(context.Background(), "k"); err != nil || !found || v != 1 {
+		t.Errorf("unexpected result: %v, %v, %v", v, found, err)
+	}
+	if ok, _ := typed.Contains(context.Background(), "other"); ok {
+		t.Error("expected other not to be loaded")
+	}
+}
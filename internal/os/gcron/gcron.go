@@ -1,4 +1,7 @@
 // Package gcron implements a cron pattern parser and job runner.
+//
+// The cron pattern contains 6 fields with seconds precision: second minute hour day month week,
+// and the seconds field is optional, which makes it compatible with standard crontab patterns.
 package gcron
 
 import (
@@ -41,6 +44,8 @@ func GetLogLevel() int {
 }
 
 // Add adds a timed task to default cron object.
+// The <pattern> can be a crontab pattern with or without the leading seconds field,
+// eg: "0 */5 * * * *", "0 2 * * *", or a predefined pattern, eg: "@daily", "@every 1h".
 // A unique <name> can be bound with the timed task.
 // It returns and error if the <name> is already used.
 func Add(pattern string, job func(), name ...string) (*Entry, error) {
@@ -51,6 +51,8 @@ func (c *Cron) GetLogLevel() int {
 }
 
 // Add adds a timed task.
+// The <pattern> can be a crontab pattern with or without the leading seconds field,
+// eg: "0 */5 * * * *", "0 2 * * *", or a predefined pattern, eg: "@daily", "@every 1h".
 // A unique <name> can be bound with the timed task.
 // It returns and error if the <name> is already used.
 func (c *Cron) Add(pattern string, job func(), name ...string) (*Entry, error) {
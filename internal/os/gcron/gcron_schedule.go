@@ -88,6 +88,12 @@ func newSchedule(pattern string) (*cronSchedule, error) {
 			return nil, errors.New(fmt.Sprintf(`invalid pattern: "%s"`, pattern))
 		}
 	}
+	// Handle the standard crontab pattern without seconds field, like:
+	// 0 2 * * *
+	// which is the same as seconds field being 0.
+	if fields := strings.Fields(pattern); len(fields) == 5 {
+		pattern = "0 " + strings.Join(fields, " ")
+	}
 	// Handle the common cron pattern, like:
 	// 0 0 0 1 1 2
 	if match, _ := gregex.MatchString(gRegexForCron, pattern); len(match) == 7 {
@@ -207,11 +207,18 @@ func DirNames(path string) ([]string, error) {
 // as in Match. The pattern may describe hierarchical names such as
 // /usr/*/bin/ed (assuming the Separator is '/').
 //
+// It also supports brace expansion, eg: "*.{go,mod}", and "**" matching any levels
+// of directories, eg: "src/**/*.{go,mod}".
+//
 // Glob ignores file system errors such as I/O errors reading directories.
 // The only possible returned error is ErrBadPattern, when pattern
 // is malformed.
 func Glob(pattern string, onlyNames ...bool) ([]string, error) {
-	if list, err := filepath.Glob(pattern); err == nil {
+	globFunc := filepath.Glob
+	if isDoubleStarPattern(pattern) {
+		globFunc = globDoubleStar
+	}
+	if list, err := globFunc(pattern); err == nil {
 		if len(onlyNames) > 0 && onlyNames[0] && len(list) > 0 {
 			array := make([]string, len(list))
 			for k, v := range list {
@@ -227,6 +234,9 @@ func Glob(pattern string, onlyNames ...bool) ([]string, error) {
 
 // Remove deletes all file/directory with <path> parameter.
 // If parameter <path> is directory, it deletes it recursively.
+//
+// Note that it never follows symlinks, which means if <path> or any of its sub-files is
+// a symlink, the symlink itself is deleted and the file or directory it points to is kept.
 func Remove(path string) error {
 	return os.RemoveAll(path)
 }
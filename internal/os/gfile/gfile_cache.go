@@ -10,16 +10,44 @@ import (
 const (
 	// Default expire time for file content caching in seconds.
 	gDEFAULT_CACHE_EXPIRE = time.Minute
+
+	// Default max count of the cached files, the least recently used ones are evicted
+	// if the count exceeds it.
+	gDEFAULT_CACHE_CAP = 1000
+
+	// Default max total size in bytes of the cached file contents, the least recently used
+	// ones are evicted if the total size exceeds it.
+	gDEFAULT_CACHE_SIZE = 64 * 1024 * 1024
 )
 
 var (
 	// Default expire time for file content caching.
 	cacheExpire = cmdenv.Get("gf.gfile.cache", gDEFAULT_CACHE_EXPIRE).Duration()
 
+	// Max count of the cached files.
+	cacheCap = cmdenv.Get("gf.gfile.cachecap", gDEFAULT_CACHE_CAP).Int()
+
+	// Max total size in bytes of the cached file contents.
+	cacheSize = cmdenv.Get("gf.gfile.cachesize", gDEFAULT_CACHE_SIZE).Int64()
+
 	// internalCache is the memory cache for internal usage.
-	internalCache = gcache.New()
+	internalCache = newInternalCache()
 )
 
+// newInternalCache creates and returns the memory cache for file contents,
+// which is limited by both the count and the total size of the contents.
+func newInternalCache() *gcache.Cache {
+	cache := gcache.New(cacheCap)
+	_ = cache.SetCostFunc(func(key, value interface{}) int64 {
+		if b, ok := value.([]byte); ok {
+			return int64(len(b))
+		}
+		return 0
+	})
+	_ = cache.SetMaxCost(cacheSize)
+	return cache
+}
+
 // GetContents returns string content of given file by <path> from cache.
 // If there's no content in the cache, it will read it from disk file specified by <path>.
 // The parameter <expire> specifies the caching time for this file content in seconds.
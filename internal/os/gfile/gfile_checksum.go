@@ -0,0 +1,71 @@
+package gfile
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	CHECKSUM_MD5    = "md5"    // MD5 checksum algorithm.
+	CHECKSUM_CRC32  = "crc32"  // CRC32 (IEEE) checksum algorithm.
+	CHECKSUM_SHA1   = "sha1"   // SHA-1 checksum algorithm.
+	CHECKSUM_SHA256 = "sha256" // SHA-256 checksum algorithm.
+)
+
+// Checksum calculates and returns the checksum of file <path> as lowercase hex string
+// using algorithm <algo>, which can be: md5, crc32, sha1, sha256.
+// It reads the file in stream, which does not load the whole file into memory.
+func Checksum(path string, algo string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	return ChecksumReader(file, algo)
+}
+
+// ChecksumReader calculates and returns the checksum of all data read from <reader>
+// as lowercase hex string using algorithm <algo>, which can be: md5, crc32, sha1, sha256.
+func ChecksumReader(reader io.Reader, algo string) (string, error) {
+	h, err := newChecksumHash(algo)
+	if err != nil {
+		return "", err
+	}
+	if _, err = io.Copy(h, reader); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// ChecksumVerify checks whether the checksum of file <path> using algorithm <algo>
+// equals to <expected>, which is case-insensitive hex string.
+func ChecksumVerify(path string, algo string, expected string) (bool, error) {
+	checksum, err := Checksum(path, algo)
+	if err != nil {
+		return false, err
+	}
+	return strings.EqualFold(checksum, strings.TrimSpace(expected)), nil
+}
+
+// newChecksumHash creates and returns the hash for checksum algorithm <algo>.
+func newChecksumHash(algo string) (hash.Hash, error) {
+	switch strings.ToLower(algo) {
+	case CHECKSUM_MD5:
+		return md5.New(), nil
+	case CHECKSUM_CRC32:
+		return crc32.NewIEEE(), nil
+	case CHECKSUM_SHA1:
+		return sha1.New(), nil
+	case CHECKSUM_SHA256, "sha-256":
+		return sha256.New(), nil
+	}
+	return nil, errors.New(fmt.Sprintf(`unsupported checksum algorithm "%s"`, algo))
+}
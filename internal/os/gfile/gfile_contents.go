@@ -159,22 +159,29 @@ func GetBytesByTwoOffsetsByPath(path string, start int64, end int64) []byte {
 
 // ReadLines reads file content line by line, which is passed to the callback function <callback> as string.
 // It matches each line of text, separated by chars '\r' or '\n', stripped any trailing end-of-line marker.
+// It stops reading if <callback> returns false.
+//
+// The optional parameter <maxLineLength> specifies the max length of each line in bytes, which is
+// bufio.MaxScanTokenSize in default. It returns error bufio.ErrTooLong if any line exceeds the length.
 //
 // Note that the parameter passed to callback function might be an empty value, and the last non-empty line
 // will be passed to callback function <callback> even if it has no newline marker.
-func ReadLines(file string, callback func(text string)) error {
-	cb := func(bytes []byte) {
-		callback(gconv.UnsafeBytesToStr(bytes))
-	}
-	return ReadByteLines(file, cb)
+func ReadLines(file string, callback func(line string) bool, maxLineLength ...int) error {
+	return ReadLinesBytes(file, func(line []byte) bool {
+		return callback(string(line))
+	}, maxLineLength...)
 }
 
-// ReadByteLines reads file content line by line, which is passed to the callback function <callback> as []byte.
+// ReadLinesBytes reads file content line by line, which is passed to the callback function <callback> as []byte.
 // It matches each line of text, separated by chars '\r' or '\n', stripped any trailing end-of-line marker.
+// It stops reading if <callback> returns false.
 //
-// Note that the parameter passed to callback function might be an empty value, and the last non-empty line
-// will be passed to callback function <callback> even if it has no newline marker.
-func ReadByteLines(file string, callback func(bytes []byte)) error {
+// The optional parameter <maxLineLength> specifies the max length of each line in bytes, which is
+// bufio.MaxScanTokenSize in default. It returns error bufio.ErrTooLong if any line exceeds the length.
+//
+// Note that the parameter passed to callback function is only valid until the callback returns,
+// as the underlying buffer is reused for the next line.
+func ReadLinesBytes(file string, callback func(line []byte) bool, maxLineLength ...int) error {
 	f, err := os.Open(file)
 	if err != nil {
 		return err
@@ -182,9 +189,33 @@ func ReadByteLines(file string, callback func(bytes []byte)) error {
 	defer f.Close()
 
 	scanner := bufio.NewScanner(f)
-
+	if len(maxLineLength) > 0 && maxLineLength[0] > 0 {
+		// The buffer should contain the line and its end-of-line marker "\r\n".
+		maxLength := maxLineLength[0]
+		scanner.Buffer(make([]byte, 0, DefaultReadBuffer), maxLength+2)
+		scanner.Split(func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+			advance, token, err = bufio.ScanLines(data, atEOF)
+			if err == nil && len(token) > maxLength {
+				return 0, nil, bufio.ErrTooLong
+			}
+			return
+		})
+	}
 	for scanner.Scan() {
-		callback(scanner.Bytes())
+		if !callback(scanner.Bytes()) {
+			break
+		}
 	}
-	return nil
+	return scanner.Err()
+}
+
+// ReadByteLines reads file content line by line, which is passed to the callback function <callback> as []byte.
+// It matches each line of text, separated by chars '\r' or '\n', stripped any trailing end-of-line marker.
+//
+// Deprecated: use ReadLinesBytes instead, which supports stopping reading and the max line length.
+func ReadByteLines(file string, callback func(bytes []byte)) error {
+	return ReadLinesBytes(file, func(line []byte) bool {
+		callback(line)
+		return true
+	})
 }
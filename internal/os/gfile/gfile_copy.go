@@ -9,17 +9,30 @@ import (
 	"path/filepath"
 )
 
+// CopyOption is the option for Copy.
+type CopyOption struct {
+	// NoFollowSymlinks specifies that the symlinks are copied as symlinks instead of
+	// the files or directories they point to. See CopyPreservingSymlinks.
+	NoFollowSymlinks bool
+}
+
 // Copy file/directory from <src> to <dst>.
 //
 // If <src> is file, it calls CopyFile to implements copy feature,
 // or else it calls CopyDir.
-func Copy(src string, dst string) error {
+//
+// The optional parameter <option> specifies the copy option. It calls CopyPreservingSymlinks
+// if the symlinks should not be followed.
+func Copy(src string, dst string, option ...CopyOption) error {
 	if src == "" {
 		return errors.New("source path cannot be empty")
 	}
 	if dst == "" {
 		return errors.New("destination path cannot be empty")
 	}
+	if len(option) > 0 && option[0].NoFollowSymlinks {
+		return CopyPreservingSymlinks(src, dst)
+	}
 	if IsFile(src) {
 		return CopyFile(src, dst)
 	}
@@ -130,3 +143,152 @@ func CopyDir(src string, dst string) (err error) {
 	}
 	return
 }
+
+// CopyDirFunc recursively copies a directory tree from <src> to <dst>, preserving the
+// permissions and modification times of the files and directories.
+//
+// The optional parameter <filter> specifies whether the file or directory of <path> should
+// be copied, and the whole subtree is skipped if it returns false for a directory.
+// The optional parameter <progress> is called after each chunk of file contents is copied,
+// with the copied and total bytes of all files to be copied.
+//
+// Note that, the source directory must exist and symlinks are ignored and skipped.
+func CopyDirFunc(
+	src string, dst string,
+	filter func(path string, info os.FileInfo) bool,
+	progress func(copied, total int64),
+) (err error) {
+	if src == "" {
+		return errors.New("source directory cannot be empty")
+	}
+	if dst == "" {
+		return errors.New("destination directory cannot be empty")
+	}
+	src = filepath.Clean(src)
+	dst = filepath.Clean(dst)
+	// If src and dst are the same path, it does nothing.
+	if src == dst {
+		return nil
+	}
+	si, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if !si.IsDir() {
+		return fmt.Errorf(`source "%s" is not a directory`, src)
+	}
+	type copyEntry struct {
+		path string
+		info os.FileInfo
+	}
+	var (
+		entries = make([]copyEntry, 0)
+		total   = int64(0)
+	)
+	// Collecting the entries to be copied and calculating the total size.
+	err = filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return nil
+		}
+		if path != src && filter != nil && !filter(path, info) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.IsDir() {
+			total += info.Size()
+		}
+		entries = append(entries, copyEntry{path: path, info: info})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	var (
+		copied  = int64(0)
+		written = func(n int64) {
+			copied += n
+			if progress != nil {
+				progress(copied, total)
+			}
+		}
+	)
+	for _, entry := range entries {
+		relPath, err := filepath.Rel(src, entry.path)
+		if err != nil {
+			return err
+		}
+		dstPath := filepath.Join(dst, relPath)
+		if entry.info.IsDir() {
+			// The directory should be writable for owner before all files are copied,
+			// and its permission is restored after that.
+			if err = os.MkdirAll(dstPath, entry.info.Mode().Perm()|0700); err != nil {
+				return err
+			}
+			continue
+		}
+		if err = copyFileWithProgress(entry.path, dstPath, entry.info, written); err != nil {
+			return err
+		}
+	}
+	// The modification times of directories are set after all files are copied,
+	// as copying files into a directory changes its modification time.
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].info.IsDir() {
+			relPath, _ := filepath.Rel(src, entries[i].path)
+			dstPath := filepath.Join(dst, relPath)
+			if err = os.Chmod(dstPath, entries[i].info.Mode().Perm()); err != nil {
+				return err
+			}
+			if err = os.Chtimes(dstPath, entries[i].info.ModTime(), entries[i].info.ModTime()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// copyFileWithProgress copies file <src> to <dst> preserving the permission and
+// modification time of <info>, and calls <written> after each chunk is copied.
+func copyFileWithProgress(src, dst string, info os.FileInfo, written func(n int64)) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return
+	}
+	defer func() {
+		if e := out.Close(); e != nil && err == nil {
+			err = e
+		}
+		if err == nil {
+			if err = os.Chmod(dst, info.Mode().Perm()); err == nil {
+				err = os.Chtimes(dst, info.ModTime(), info.ModTime())
+			}
+		}
+	}()
+	buffer := make([]byte, 32*1024)
+	for {
+		n, readErr := in.Read(buffer)
+		if n > 0 {
+			if _, err = out.Write(buffer[:n]); err != nil {
+				return
+			}
+			written(int64(n))
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+	return out.Sync()
+}
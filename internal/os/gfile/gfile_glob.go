@@ -0,0 +1,247 @@
+package gfile
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// MatchGlob checks whether <filePath> matches the glob <pattern>.
+//
+// Besides the syntax of filepath.Match, it supports brace expansion, eg: "*.{go,mod}",
+// and "**" matching any levels of directories, eg: "src/**/*.go" matches "src/a.go"
+// and "src/a/b/c.go". The path separators of both <pattern> and <filePath> are
+// normalized as '/' before matching.
+func MatchGlob(pattern string, filePath string) (bool, error) {
+	parts := splitGlobPath(filepath.ToSlash(filePath))
+	for _, p := range expandBraces(filepath.ToSlash(pattern)) {
+		match, err := matchGlobParts(splitGlobPath(p), parts)
+		if err != nil {
+			return false, err
+		}
+		if match {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// globDoubleStar returns the paths matching <pattern> which contains "**" or braces.
+// It walks the longest leading directory of <pattern> that contains no meta chars.
+func globDoubleStar(pattern string) ([]string, error) {
+	var (
+		list = make([]string, 0)
+		seen = make(map[string]struct{})
+	)
+	for _, p := range expandBraces(filepath.ToSlash(pattern)) {
+		base := globBaseDir(p)
+		if _, err := os.Stat(base); err != nil {
+			continue
+		}
+		err := filepath.Walk(base, func(walkPath string, info os.FileInfo, err error) error {
+			// It ignores file system errors such as I/O errors reading directories.
+			if err != nil {
+				return nil
+			}
+			match, err := MatchGlob(p, walkPath)
+			if err != nil {
+				return err
+			}
+			if match {
+				if _, ok := seen[walkPath]; !ok {
+					seen[walkPath] = struct{}{}
+					list = append(list, walkPath)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(list)
+	return list, nil
+}
+
+// globBaseDir returns the longest leading directory of <pattern> that contains no meta chars.
+func globBaseDir(pattern string) string {
+	var (
+		parts = strings.Split(pattern, "/")
+		index = 0
+	)
+	for index < len(parts)-1 && !hasGlobMeta(parts[index]) {
+		index++
+	}
+	base := strings.Join(parts[:index], "/")
+	if base == "" {
+		if strings.HasPrefix(pattern, "/") {
+			return "/"
+		}
+		return "."
+	}
+	return filepath.FromSlash(base)
+}
+
+// hasGlobMeta checks whether <s> contains any glob meta chars.
+func hasGlobMeta(s string) bool {
+	return strings.ContainsAny(s, "*?[{")
+}
+
+// isDoubleStarPattern checks whether <pattern> needs doublestar matching,
+// which means it contains "**" or braces.
+func isDoubleStarPattern(pattern string) bool {
+	return strings.Contains(pattern, "**") || strings.Contains(pattern, "{")
+}
+
+// matchScanPattern checks whether the sub-file <filePath> named <name> of scanning
+// directory <root> matches <pattern>. The pattern containing '/' or "**" matches the
+// path relative to <root>, or else it matches the file name.
+func matchScanPattern(pattern string, root string, filePath string, name string) bool {
+	target := name
+	if strings.Contains(pattern, "/") || strings.Contains(pattern, "**") {
+		relPath, err := filepath.Rel(root, filePath)
+		if err != nil {
+			return false
+		}
+		target = relPath
+	}
+	match, err := MatchGlob(pattern, target)
+	return err == nil && match
+}
+
+// splitGlobPath splits <p> into path segments by '/', ignoring the empty segments
+// except the leading one of absolute path.
+func splitGlobPath(p string) []string {
+	var (
+		array = strings.Split(p, "/")
+		parts = make([]string, 0, len(array))
+	)
+	for i, part := range array {
+		if part == "" && i > 0 {
+			continue
+		}
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// matchGlobParts matches the path segments <parts> using the pattern segments <patterns>,
+// in which "**" matches zero or more segments.
+func matchGlobParts(patterns []string, parts []string) (bool, error) {
+	for len(patterns) > 0 {
+		if patterns[0] == "**" {
+			for len(patterns) > 0 && patterns[0] == "**" {
+				patterns = patterns[1:]
+			}
+			if len(patterns) == 0 {
+				return true, nil
+			}
+			for i := 0; i <= len(parts); i++ {
+				if match, err := matchGlobParts(patterns, parts[i:]); err != nil || match {
+					return match, err
+				}
+			}
+			return false, nil
+		}
+		if len(parts) == 0 {
+			return false, nil
+		}
+		match, err := path.Match(patterns[0], parts[0])
+		if err != nil || !match {
+			return false, err
+		}
+		patterns, parts = patterns[1:], parts[1:]
+	}
+	return len(parts) == 0, nil
+}
+
+// expandBraces expands the braces in <pattern>, which supports nested braces,
+// eg: "a.{go,m{o,d}}" is expanded to ["a.go", "a.mo", "a.md"].
+// The brace without ',' or unclosed brace is kept as it is.
+func expandBraces(pattern string) []string {
+	start, end, commas := findBraces(pattern)
+	if start < 0 {
+		return []string{pattern}
+	}
+	var (
+		prefix  = pattern[:start]
+		suffix  = pattern[end+1:]
+		options = make([]string, 0, len(commas)+1)
+		from    = start + 1
+		result  = make([]string, 0)
+	)
+	for _, comma := range commas {
+		options = append(options, pattern[from:comma])
+		from = comma + 1
+	}
+	options = append(options, pattern[from:end])
+	for _, option := range options {
+		result = append(result, expandBraces(prefix+option+suffix)...)
+	}
+	return result
+}
+
+// findBraces finds the first outermost brace pair containing ',' in <pattern>,
+// and returns the indexes of the braces and the top level commas in it.
+// It returns -1 as <start> if there's no such brace pair.
+func findBraces(pattern string) (start, end int, commas []int) {
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '{' {
+			continue
+		}
+		depth := 0
+		commas = commas[:0]
+		for j := i; j < len(pattern); j++ {
+			switch pattern[j] {
+			case '{':
+				depth++
+			case '}':
+				depth--
+				if depth == 0 {
+					if len(commas) > 0 {
+						return i, j, commas
+					}
+					j = len(pattern)
+				}
+			case ',':
+				if depth == 1 {
+					commas = append(commas, j)
+				}
+			}
+		}
+	}
+	return -1, -1, nil
+}
+
+// splitPatterns splits <pattern> into multiple patterns by ',' that is not in braces,
+// and trims the spaces of each pattern.
+func splitPatterns(pattern string) []string {
+	var (
+		patterns = make([]string, 0)
+		depth    = 0
+		from     = 0
+	)
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '{':
+			depth++
+		case '}':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				if p := strings.TrimSpace(pattern[from:i]); p != "" {
+					patterns = append(patterns, p)
+				}
+				from = i + 1
+			}
+		}
+	}
+	if p := strings.TrimSpace(pattern[from:]); p != "" {
+		patterns = append(patterns, p)
+	}
+	return patterns
+}
@@ -0,0 +1,109 @@
+package gfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// HomeDir returns absolute path of current user's home directory.
+// It is the same as Home without sub-folders/sub-files.
+func HomeDir() (string, error) {
+	return Home()
+}
+
+// ConfigDir returns the directory for the configuration files of application <app>,
+// which is the sub-folder <app> of the user's configuration directory.
+// It returns the user's configuration directory if <app> is empty.
+//
+// The user's configuration directory is:
+// $XDG_CONFIG_HOME or $HOME/.config on Unix systems,
+// $HOME/Library/Application Support on macOS,
+// %AppData% on Windows.
+func ConfigDir(app string) (string, error) {
+	switch runtime.GOOS {
+	case "windows":
+		return getWindowsDir("AppData", app)
+	case "darwin", "ios":
+		return getHomeSubDir(app, "Library", "Application Support")
+	default:
+		return getXdgDir("XDG_CONFIG_HOME", app, ".config")
+	}
+}
+
+// CacheDir returns the directory for the cache files of application <app>,
+// which is the sub-folder <app> of the user's cache directory.
+// It returns the user's cache directory if <app> is empty.
+//
+// The user's cache directory is:
+// $XDG_CACHE_HOME or $HOME/.cache on Unix systems,
+// $HOME/Library/Caches on macOS,
+// %LocalAppData% on Windows.
+func CacheDir(app string) (string, error) {
+	switch runtime.GOOS {
+	case "windows":
+		return getWindowsDir("LocalAppData", app)
+	case "darwin", "ios":
+		return getHomeSubDir(app, "Library", "Caches")
+	default:
+		return getXdgDir("XDG_CACHE_HOME", app, ".cache")
+	}
+}
+
+// DataDir returns the directory for the data files of application <app>,
+// which is the sub-folder <app> of the user's data directory.
+// It returns the user's data directory if <app> is empty.
+//
+// The user's data directory is:
+// $XDG_DATA_HOME or $HOME/.local/share on Unix systems,
+// $HOME/Library/Application Support on macOS,
+// %LocalAppData% on Windows.
+func DataDir(app string) (string, error) {
+	switch runtime.GOOS {
+	case "windows":
+		return getWindowsDir("LocalAppData", app)
+	case "darwin", "ios":
+		return getHomeSubDir(app, "Library", "Application Support")
+	default:
+		return getXdgDir("XDG_DATA_HOME", app, ".local", "share")
+	}
+}
+
+// getXdgDir returns the sub-folder <app> of the directory specified by environment
+// variable <env>, or of the sub-folder <names> of the home directory if the environment
+// variable is empty. Note that the relative path in <env> is ignored as the XDG
+// specification says.
+func getXdgDir(env string, app string, names ...string) (string, error) {
+	if dir := os.Getenv(env); dir != "" && filepath.IsAbs(dir) {
+		return joinAppDir(dir, app), nil
+	}
+	return getHomeSubDir(app, names...)
+}
+
+// getHomeSubDir returns the sub-folder <app> of the sub-folder <names> of the home directory.
+func getHomeSubDir(app string, names ...string) (string, error) {
+	dir, err := Home(names...)
+	if err != nil {
+		return "", err
+	}
+	return joinAppDir(dir, app), nil
+}
+
+// getWindowsDir returns the sub-folder <app> of the directory specified by environment
+// variable <env> on Windows.
+func getWindowsDir(env string, app string) (string, error) {
+	dir := os.Getenv(env)
+	if dir == "" {
+		return "", errors.New("%" + env + "% is not defined")
+	}
+	return joinAppDir(dir, app), nil
+}
+
+// joinAppDir joins <dir> and <app> if <app> is not empty.
+func joinAppDir(dir string, app string) string {
+	if app == "" {
+		return dir
+	}
+	return Join(dir, app)
+}
@@ -0,0 +1,93 @@
+package gfile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FileLock is the advisory lock of file across processes, which is implemented using
+// flock on unix-like systems and LockFileEx on windows.
+//
+// Note that the advisory lock only works among the processes that lock the same file
+// using this feature, and it does not prevent other processes reading or writing the file.
+// Use gmlock instead for locking within one process.
+type FileLock struct {
+	mu   sync.Mutex // Mutex for concurrent safety of unlocking.
+	path string     // Path of the locked file.
+	file *os.File   // Opened file holding the lock.
+}
+
+// LockFile acquires the exclusive advisory lock of file <path>, which blocks until the lock
+// is acquired. The file and its parent directories are created if they do not exist.
+// The returned FileLock should be unlocked using Unlock after use.
+//
+// Eg:
+//
+//	lock, err := gfile.LockFile("/var/data/app.lock")
+//	if err != nil {
+//		return err
+//	}
+//	defer lock.Unlock()
+func LockFile(path string) (*FileLock, error) {
+	lock, _, err := doLockFile(path, true)
+	return lock, err
+}
+
+// TryLockFile tries acquiring the exclusive advisory lock of file <path> without blocking.
+// It returns false if the lock is being held by another process or FileLock.
+// The file and its parent directories are created if they do not exist.
+func TryLockFile(path string) (*FileLock, bool, error) {
+	return doLockFile(path, false)
+}
+
+// Path returns the path of the locked file.
+func (l *FileLock) Path() string {
+	return l.path
+}
+
+// Unlock releases the lock. It does nothing if the lock is already released.
+func (l *FileLock) Unlock() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := unlockFile(l.file)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	l.file = nil
+	return err
+}
+
+// doLockFile opens file <path> and acquires its exclusive lock.
+// It blocks until the lock is acquired if <block> is true.
+func doLockFile(path string, block bool) (*FileLock, bool, error) {
+	if path == "" {
+		return nil, false, errors.New("lock file path cannot be empty")
+	}
+	if dir := Dir(path); !Exists(dir) {
+		if err := Mkdir(dir); err != nil {
+			return nil, false, err
+		}
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, DefaultPermOpen)
+	if err != nil {
+		return nil, false, err
+	}
+	locked, err := lockFile(file, block)
+	if err != nil {
+		file.Close()
+		return nil, false, errors.New(fmt.Sprintf(`lock file "%s" failed: %s`, path, err.Error()))
+	}
+	if !locked {
+		file.Close()
+		return nil, false, nil
+	}
+	return &FileLock{
+		path: path,
+		file: file,
+	}, true, nil
+}
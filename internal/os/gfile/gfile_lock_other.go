@@ -0,0 +1,20 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !windows
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!windows
+
+package gfile
+
+import (
+	"errors"
+	"os"
+	"runtime"
+)
+
+// lockFile returns error as file locking is not supported on current platform.
+func lockFile(file *os.File, block bool) (bool, error) {
+	return false, errors.New("file locking is not supported on " + runtime.GOOS)
+}
+
+// unlockFile returns error as file locking is not supported on current platform.
+func unlockFile(file *os.File) error {
+	return errors.New("file locking is not supported on " + runtime.GOOS)
+}
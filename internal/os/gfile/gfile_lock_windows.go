@@ -0,0 +1,63 @@
+//go:build windows
+// +build windows
+
+package gfile
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	lockFileFailImmediately = 0x00000001 // LOCKFILE_FAIL_IMMEDIATELY.
+	lockFileExclusiveLock   = 0x00000002 // LOCKFILE_EXCLUSIVE_LOCK.
+	errorLockViolation      = 33         // ERROR_LOCK_VIOLATION.
+)
+
+var (
+	modKernel32      = syscall.NewLazyDLL("kernel32.dll")
+	procLockFileEx   = modKernel32.NewProc("LockFileEx")
+	procUnlockFileEx = modKernel32.NewProc("UnlockFileEx")
+)
+
+// lockFile acquires the exclusive lock of <file> using LockFileEx.
+// It returns false if it does not block and the lock is held by others.
+func lockFile(file *os.File, block bool) (bool, error) {
+	flags := uint32(lockFileExclusiveLock)
+	if !block {
+		flags |= lockFileFailImmediately
+	}
+	overlapped := new(syscall.Overlapped)
+	r, _, err := procLockFileEx.Call(
+		file.Fd(),
+		uintptr(flags),
+		0,
+		uintptr(^uint32(0)),
+		uintptr(^uint32(0)),
+		uintptr(unsafe.Pointer(overlapped)),
+	)
+	if r != 0 {
+		return true, nil
+	}
+	if errno, ok := err.(syscall.Errno); ok && errno == errorLockViolation {
+		return false, nil
+	}
+	return false, err
+}
+
+// unlockFile releases the lock of <file>.
+func unlockFile(file *os.File) error {
+	overlapped := new(syscall.Overlapped)
+	r, _, err := procUnlockFileEx.Call(
+		file.Fd(),
+		0,
+		uintptr(^uint32(0)),
+		uintptr(^uint32(0)),
+		uintptr(unsafe.Pointer(overlapped)),
+	)
+	if r != 0 {
+		return nil
+	}
+	return err
+}
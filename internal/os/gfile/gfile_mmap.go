@@ -0,0 +1,101 @@
+package gfile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// MmapFile is the read-only memory-mapped content of a file, which is created by GetBytesMmap.
+// It should be released using Release if it is not used any more.
+type MmapFile struct {
+	mu     sync.Mutex // Mutex for concurrent safety of releasing.
+	path   string     // Path of the mapped file.
+	data   []byte     // Mapped content of the file.
+	mapped bool       // Whether <data> is mapped, or else it is read into the heap.
+}
+
+// GetBytesMmap maps the content of file <path> into memory for read-only access,
+// which avoids reading the whole file content into the heap, especially for large files.
+// The content is retrieved by Bytes of the returned MmapFile, which should be released
+// using Release after use.
+//
+// Note that the returned content should not be modified, and should not be accessed after
+// it is released. It falls back to reading the file content into the heap on platforms not
+// supporting memory mapping.
+//
+// Eg:
+//
+//	file, err := gfile.GetBytesMmap("/path/to/large/file")
+//	if err != nil {
+//	    return err
+//	}
+//	defer file.Release()
+//	hash := crc32.ChecksumIEEE(file.Bytes())
+func GetBytesMmap(path string) (*MmapFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, errors.New(fmt.Sprintf(`"%s" is a directory`, path))
+	}
+	size := info.Size()
+	if int64(int(size)) != size {
+		return nil, errors.New(fmt.Sprintf(`file "%s" is too large to be mapped: %d bytes`, path, size))
+	}
+	m := &MmapFile{
+		path: path,
+	}
+	// Empty file cannot be mapped.
+	if size == 0 {
+		m.data = make([]byte, 0)
+		return m, nil
+	}
+	if m.data, m.mapped, err = mmapFile(file, int(size)); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Path returns the path of the mapped file.
+func (m *MmapFile) Path() string {
+	return m.path
+}
+
+// Bytes returns the mapped content of the file, which is nil if it is released.
+// The returned content should not be modified.
+func (m *MmapFile) Bytes() []byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.data
+}
+
+// Len returns the length of the mapped content.
+func (m *MmapFile) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.data)
+}
+
+// Release unmaps the content of the file. It does nothing if it is already released.
+func (m *MmapFile) Release() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.data == nil {
+		return nil
+	}
+	var err error
+	if m.mapped {
+		err = munmapFile(m.data)
+	}
+	m.data = nil
+	m.mapped = false
+	return err
+}
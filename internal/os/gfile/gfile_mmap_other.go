@@ -0,0 +1,24 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !windows
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!windows
+
+package gfile
+
+import (
+	"io"
+	"os"
+)
+
+// mmapFile reads <size> bytes of <file> into the heap,
+// as memory mapping is not supported on current platform.
+func mmapFile(file *os.File, size int) ([]byte, bool, error) {
+	data := make([]byte, size)
+	if _, err := io.ReadFull(file, data); err != nil {
+		return nil, false, err
+	}
+	return data, false, nil
+}
+
+// munmapFile does nothing as memory mapping is not supported on current platform.
+func munmapFile(data []byte) error {
+	return nil
+}
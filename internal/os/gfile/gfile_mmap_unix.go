@@ -0,0 +1,23 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package gfile
+
+import (
+	"os"
+	"syscall"
+)
+
+// mmapFile maps <size> bytes of <file> into memory for reading.
+func mmapFile(file *os.File, size int) ([]byte, bool, error) {
+	data, err := syscall.Mmap(int(file.Fd()), 0, size, syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+// munmapFile unmaps the <data> mapped by mmapFile.
+func munmapFile(data []byte) error {
+	return syscall.Munmap(data)
+}
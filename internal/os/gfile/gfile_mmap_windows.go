@@ -0,0 +1,39 @@
+//go:build windows
+// +build windows
+
+package gfile
+
+import (
+	"os"
+	"reflect"
+	"syscall"
+	"unsafe"
+)
+
+// mmapFile maps <size> bytes of <file> into memory for reading.
+func mmapFile(file *os.File, size int) ([]byte, bool, error) {
+	mapping, err := syscall.CreateFileMapping(syscall.Handle(file.Fd()), nil, syscall.PAGE_READONLY, 0, 0, nil)
+	if err != nil {
+		return nil, false, os.NewSyscallError("CreateFileMapping", err)
+	}
+	// The mapping handle can be closed after the view is mapped,
+	// as the view holds a reference to the mapping.
+	defer syscall.CloseHandle(mapping)
+	addr, err := syscall.MapViewOfFile(mapping, syscall.FILE_MAP_READ, 0, 0, uintptr(size))
+	if err != nil {
+		return nil, false, os.NewSyscallError("MapViewOfFile", err)
+	}
+	var (
+		data   []byte
+		header = (*reflect.SliceHeader)(unsafe.Pointer(&data))
+	)
+	header.Data = addr
+	header.Len = size
+	header.Cap = size
+	return data, true, nil
+}
+
+// munmapFile unmaps the <data> mapped by mmapFile.
+func munmapFile(data []byte) error {
+	return os.NewSyscallError("UnmapViewOfFile", syscall.UnmapViewOfFile(uintptr(unsafe.Pointer(&data[0]))))
+}
@@ -2,9 +2,7 @@ package gfile
 
 import (
 	"github.com/ilylx/gconv/internal/gerror"
-	"github.com/ilylx/gconv/internal/gstr"
 	"os"
-	"path/filepath"
 	"sort"
 )
 
@@ -17,13 +15,15 @@ const (
 // It scans directory recursively if given parameter <recursive> is true.
 //
 // The pattern parameter <pattern> supports multiple file name patterns,
-// using the ',' symbol to separate multiple patterns.
+// using the ',' symbol to separate multiple patterns. The brace expansion is supported,
+// eg: "*.{go,mod}". The pattern containing '/' or "**" matches the path relative to <path>,
+// in which "**" matches any levels of directories, eg: "src/**/*.{go,mod}".
 func ScanDir(path string, pattern string, recursive ...bool) ([]string, error) {
 	isRecursive := false
 	if len(recursive) > 0 {
 		isRecursive = recursive[0]
 	}
-	list, err := doScanDir(0, path, pattern, isRecursive, nil)
+	list, err := doScanDir(0, path, path, pattern, isRecursive, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -47,7 +47,7 @@ func ScanDir(path string, pattern string, recursive ...bool) ([]string, error) {
 // the <path> and its sub-folders. It ignores the sub-file path if <handler> returns an empty
 // string, or else it appends the sub-file path to result slice.
 func ScanDirFunc(path string, pattern string, recursive bool, handler func(path string) string) ([]string, error) {
-	list, err := doScanDir(0, path, pattern, recursive, handler)
+	list, err := doScanDir(0, path, path, pattern, recursive, handler)
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +69,7 @@ func ScanDirFile(path string, pattern string, recursive ...bool) ([]string, erro
 	if len(recursive) > 0 {
 		isRecursive = recursive[0]
 	}
-	list, err := doScanDir(0, path, pattern, isRecursive, func(path string) string {
+	list, err := doScanDir(0, path, path, pattern, isRecursive, func(path string) string {
 		if IsDir(path) {
 			return ""
 		}
@@ -101,7 +101,7 @@ func ScanDirFile(path string, pattern string, recursive ...bool) ([]string, erro
 // Note that the parameter <path> for <handler> is not a directory but a file.
 // It returns only files, exclusive of directories.
 func ScanDirFileFunc(path string, pattern string, recursive bool, handler func(path string) string) ([]string, error) {
-	list, err := doScanDir(0, path, pattern, recursive, func(path string) string {
+	list, err := doScanDir(0, path, path, pattern, recursive, func(path string) string {
 		if IsDir(path) {
 			return ""
 		}
@@ -119,6 +119,9 @@ func ScanDirFileFunc(path string, pattern string, recursive bool, handler func(p
 // doScanDir is an internal method which scans directory and returns the absolute path
 // list of files that are not sorted.
 //
+// The parameter <root> is the root directory of scanning, which is used for matching
+// the relative path of sub-files.
+//
 // The pattern parameter <pattern> supports multiple file name patterns, using the ','
 // symbol to separate multiple patterns.
 //
@@ -129,7 +132,7 @@ func ScanDirFileFunc(path string, pattern string, recursive bool, handler func(p
 // The parameter <handler> specifies the callback function handling each sub-file path of
 // the <path> and its sub-folders. It ignores the sub-file path if <handler> returns an empty
 // string, or else it appends the sub-file path to result slice.
-func doScanDir(depth int, path string, pattern string, recursive bool, handler func(path string) string) ([]string, error) {
+func doScanDir(depth int, root string, path string, pattern string, recursive bool, handler func(path string) string) ([]string, error) {
 	if depth >= gMAX_SCAN_DEPTH {
 		return nil, gerror.Newf("directory scanning exceeds max recursive depth: %d", gMAX_SCAN_DEPTH)
 	}
@@ -145,12 +148,12 @@ func doScanDir(depth int, path string, pattern string, recursive bool, handler f
 	}
 	var (
 		filePath = ""
-		patterns = gstr.SplitAndTrim(pattern, ",")
+		patterns = splitPatterns(pattern)
 	)
 	for _, name := range names {
 		filePath = path + Separator + name
 		if IsDir(filePath) && recursive {
-			array, _ := doScanDir(depth+1, filePath, pattern, true, handler)
+			array, _ := doScanDir(depth+1, root, filePath, pattern, true, handler)
 			if len(array) > 0 {
 				list = append(list, array...)
 			}
@@ -164,7 +167,7 @@ func doScanDir(depth int, path string, pattern string, recursive bool, handler f
 		}
 		// If it meets pattern, then add it to the result list.
 		for _, p := range patterns {
-			if match, err := filepath.Match(p, name); err == nil && match {
+			if matchScanPattern(p, root, filePath, name) {
 				filePath = Abs(filePath)
 				if filePath != "" {
 					list = append(list, filePath)
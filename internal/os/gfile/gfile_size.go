@@ -21,8 +21,13 @@ func ReadableSize(path string) string {
 	return FormatSize(Size(path))
 }
 
-// StrToSize converts formatted size string to its size in bytes.
+// StrToSize converts formatted size string to its size in bytes, eg: "100MB", "1.5 GiB", "512k".
+// The units are case-insensitive and are all based on 1024, the blank chars between the number
+// and the unit are ignored. It also parses the result of FormatSize.
+//
+// It returns -1 if <sizeStr> is not a valid size string.
 func StrToSize(sizeStr string) int64 {
+	sizeStr = strings.TrimSpace(sizeStr)
 	i := 0
 	for ; i < len(sizeStr); i++ {
 		if sizeStr[i] == '.' || (sizeStr[i] >= '0' && sizeStr[i] <= '9') {
@@ -32,14 +37,17 @@ func StrToSize(sizeStr string) int64 {
 		}
 	}
 	var (
-		unit      = sizeStr[i:]
-		number, _ = strconv.ParseFloat(sizeStr[:i], 64)
+		unit        = strings.TrimSpace(sizeStr[i:])
+		number, err = strconv.ParseFloat(sizeStr[:i], 64)
 	)
+	if err != nil {
+		return -1
+	}
 	if unit == "" {
 		return int64(number)
 	}
 	switch strings.ToLower(unit) {
-	case "b", "bytes":
+	case "b", "byte", "bytes":
 		return int64(number)
 	case "k", "kb", "ki", "kib", "kilobyte":
 		return int64(number * 1024)
@@ -49,7 +57,7 @@ func StrToSize(sizeStr string) int64 {
 		return int64(number * 1024 * 1024 * 1024)
 	case "t", "tb", "ti", "tib", "terabyte":
 		return int64(number * 1024 * 1024 * 1024 * 1024)
-	case "p", "common", "pi", "pib", "petabyte":
+	case "p", "pb", "pi", "pib", "petabyte":
 		return int64(number * 1024 * 1024 * 1024 * 1024 * 1024)
 	case "e", "eb", "ei", "eib", "exabyte":
 		return int64(number * 1024 * 1024 * 1024 * 1024 * 1024 * 1024)
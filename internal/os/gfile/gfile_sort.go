@@ -1,10 +1,23 @@
 package gfile
 
 import (
+	"errors"
+	"fmt"
 	"github.com/ilylx/gconv/container/garray"
+	"os"
+	"sort"
 	"strings"
 )
 
+// SortKey is the key sorting the files by.
+type SortKey int
+
+const (
+	SORT_BY_NAME  SortKey = iota // Sorting by file path.
+	SORT_BY_MTIME                // Sorting by modification time.
+	SORT_BY_SIZE                 // Sorting by file size.
+)
+
 // fileSortFunc is the comparison function for files.
 // It sorts the array in order of: directory -> file.
 // If <path1> and <path2> are the same type, it then sorts them as strings.
@@ -31,3 +44,62 @@ func SortFiles(files []string) []string {
 	array.Add(files...)
 	return array.Slice()
 }
+
+// ScanDirSorted returns the sub-files with absolute paths of given <path> matching <pattern>,
+// which are sorted by <by> in ascending order, or in descending order if <desc> is true.
+// The files having the same modification time or size are sorted by their paths.
+// It scans directory recursively if given parameter <recursive> is true.
+//
+// Eg:
+//
+//	// Retrieving the latest log file.
+//	files, err := gfile.ScanDirSorted("/var/log/app", "*.log", gfile.SORT_BY_MTIME, true)
+func ScanDirSorted(path string, pattern string, by SortKey, desc bool, recursive ...bool) ([]string, error) {
+	files, err := ScanDir(path, pattern, recursive...)
+	if err != nil {
+		return nil, err
+	}
+	if by == SORT_BY_NAME {
+		if desc {
+			sort.Sort(sort.Reverse(sort.StringSlice(files)))
+		}
+		return files, nil
+	}
+	if by != SORT_BY_MTIME && by != SORT_BY_SIZE {
+		return nil, errors.New(fmt.Sprintf(`invalid sort key: %d`, by))
+	}
+	// The file information is retrieved once for each file before sorting.
+	infos := make(map[string]os.FileInfo, len(files))
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		infos[file] = info
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		var (
+			info1 = infos[files[i]]
+			info2 = infos[files[j]]
+			n     int
+		)
+		switch {
+		case by == SORT_BY_MTIME && info1.ModTime().Before(info2.ModTime()):
+			n = -1
+		case by == SORT_BY_MTIME && info1.ModTime().After(info2.ModTime()):
+			n = 1
+		case by == SORT_BY_SIZE && info1.Size() < info2.Size():
+			n = -1
+		case by == SORT_BY_SIZE && info1.Size() > info2.Size():
+			n = 1
+		}
+		if n == 0 {
+			n = strings.Compare(files[i], files[j])
+		}
+		if desc {
+			return n > 0
+		}
+		return n < 0
+	})
+	return files, nil
+}
@@ -0,0 +1,104 @@
+package gfile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// IsSymlink checks whether given <path> is a symlink.
+// It does not follow the symlink, and returns false if <path> does not exist.
+func IsSymlink(path string) bool {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeSymlink != 0
+}
+
+// ReadLink returns the destination of the symlink <path>.
+// The returned destination might be relative to the directory of <path>.
+func ReadLink(path string) (string, error) {
+	return os.Readlink(path)
+}
+
+// CopyPreservingSymlinks copies file/directory from <src> to <dst> recursively, in which the
+// symlinks are copied as symlinks with the same destinations, instead of the files or
+// directories they point to. The permissions and modification times of the files and
+// directories are preserved.
+//
+// It is useful copying directory trees containing symlinks pointing to large data sets.
+// Note that the relative destinations of the symlinks are kept as they are, which might
+// point to different files in <dst>.
+func CopyPreservingSymlinks(src string, dst string) error {
+	if src == "" {
+		return errors.New("source path cannot be empty")
+	}
+	if dst == "" {
+		return errors.New("destination path cannot be empty")
+	}
+	src = filepath.Clean(src)
+	dst = filepath.Clean(dst)
+	// If src and dst are the same path, it does nothing.
+	if src == dst {
+		return nil
+	}
+	info, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+	return doCopyPreservingSymlinks(src, dst, info)
+}
+
+// doCopyPreservingSymlinks copies <src> of <info> to <dst> recursively without following symlinks.
+func doCopyPreservingSymlinks(src string, dst string, info os.FileInfo) error {
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		link, err := os.Readlink(src)
+		if err != nil {
+			return err
+		}
+		// The existing file or symlink should be removed before creating the symlink.
+		if dstInfo, err := os.Lstat(dst); err == nil {
+			if dstInfo.IsDir() {
+				return errors.New(fmt.Sprintf(`destination "%s" is an existing directory`, dst))
+			}
+			if err = os.Remove(dst); err != nil {
+				return err
+			}
+		}
+		return os.Symlink(link, dst)
+
+	case info.IsDir():
+		// The directory should be writable for owner before all files are copied,
+		// and its permission is restored after that.
+		if err := os.MkdirAll(dst, info.Mode().Perm()|0700); err != nil {
+			return err
+		}
+		names, err := DirNames(src)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			subInfo, err := os.Lstat(filepath.Join(src, name))
+			if err != nil {
+				return err
+			}
+			if err = doCopyPreservingSymlinks(filepath.Join(src, name), filepath.Join(dst, name), subInfo); err != nil {
+				return err
+			}
+		}
+		if err = os.Chmod(dst, info.Mode().Perm()); err != nil {
+			return err
+		}
+		return os.Chtimes(dst, info.ModTime(), info.ModTime())
+
+	case info.Mode().IsRegular():
+		return copyFileWithProgress(src, dst, info, func(n int64) {})
+
+	default:
+		// The special files like devices, pipes and sockets are ignored.
+		return nil
+	}
+}
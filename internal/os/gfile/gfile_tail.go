@@ -0,0 +1,209 @@
+package gfile
+
+import (
+	"bytes"
+	"github.com/ilylx/gconv/container/gtype"
+	"github.com/ilylx/gconv/internal/os/gfsnotify"
+	"github.com/ilylx/gconv/internal/os/gtimer"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Tailer follows the appended lines of a growing file, like command "tail -F".
+type Tailer struct {
+	mu       sync.Mutex          // Mutex for concurrent safety of reading.
+	notifyMu sync.Mutex          // Mutex for calling the callback in order, which is not held by Close.
+	path     string              // Absolute path of the followed file.
+	fromEnd  bool                // Whether starts reading from the end of the file.
+	callback func(line string)   // Callback for each line.
+	file     *os.File            // Currently opened file.
+	offset   int64               // Read offset of the opened file.
+	partial  []byte              // Incomplete last line that has no line ending yet.
+	opened   bool                // Whether the initial opening is done.
+	closed   *gtype.Bool         // Closed or not.
+	entry    *gtimer.Entry       // Timer entry for polling.
+	watch    *gfsnotify.Callback // Callback of the directory watching.
+}
+
+const (
+	// gTAIL_POLL_INTERVAL is the interval polling the followed file,
+	// which is the fallback in case that the file system notification is not available.
+	gTAIL_POLL_INTERVAL = 500 * time.Millisecond
+
+	// gTAIL_MAX_LINE_SIZE is the max size of a line, the longer line is split into lines of this size.
+	gTAIL_MAX_LINE_SIZE = 1024 * 1024
+)
+
+// Tail follows the lines appended to file <path> and calls <callback> with each line
+// without line ending. It reads from the end of the file if <fromEnd> is true, or else
+// it reads from the beginning.
+//
+// It survives the rotation and truncation of the file: it reads the rest of the old file
+// and the new file from the beginning if the file is rotated, and it reads from the
+// beginning if the file is truncated. It waits for the file if it does not exist.
+//
+// The changes are watched using gfsnotify, with a polling fallback in case that the file
+// system notification is not available. The <callback> is called from the goroutines of
+// the watching and the polling, but the calls are serialized in the order of the lines,
+// so it should not block for long. It is safe to call Close in the <callback>.
+// The line longer than 1MB is split into lines of 1MB.
+// The returned Tailer should be closed if it is not used any more.
+func Tail(path string, fromEnd bool, callback func(line string)) (*Tailer, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	t := &Tailer{
+		path:     absPath,
+		fromEnd:  fromEnd,
+		callback: callback,
+		closed:   gtype.NewBool(),
+	}
+	t.check()
+	// The file that is created after Tail is called is read from the beginning.
+	t.mu.Lock()
+	t.opened = true
+	t.mu.Unlock()
+	// It watches the directory instead of the file, as the watching of the file
+	// is lost if the file is rotated.
+	t.watch, _ = gfsnotify.Add(filepath.Dir(absPath), func(event *gfsnotify.Event) {
+		if event.Path == t.path {
+			t.check()
+		}
+	}, false)
+	t.entry = gtimer.AddSingleton(gTAIL_POLL_INTERVAL, func() {
+		if t.closed.Val() {
+			gtimer.Exit()
+		}
+		t.check()
+	})
+	return t, nil
+}
+
+// Path returns the absolute path of the followed file.
+func (t *Tailer) Path() string {
+	return t.path
+}
+
+// Close stops following the file.
+func (t *Tailer) Close() {
+	if !t.closed.Cas(false, true) {
+		return
+	}
+	if t.entry != nil {
+		t.entry.Close()
+	}
+	if t.watch != nil {
+		_ = gfsnotify.RemoveCallback(t.watch.Id)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.file != nil {
+		t.file.Close()
+		t.file = nil
+	}
+}
+
+// check reads the new lines of the file, and calls the callback with them.
+// The callback is called without holding <mu>, so the callback can call Close.
+func (t *Tailer) check() {
+	t.notifyMu.Lock()
+	defer t.notifyMu.Unlock()
+	for _, line := range t.readLines() {
+		if t.closed.Val() {
+			return
+		}
+		t.callback(line)
+	}
+}
+
+// readLines reads and returns the new lines of the file, and handles the rotation and truncation.
+func (t *Tailer) readLines() (lines []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed.Val() {
+		return nil
+	}
+	if t.file == nil && !t.open() {
+		return nil
+	}
+	// Checking the rotation, which means the path points to another file.
+	if pathInfo, err := os.Stat(t.path); err == nil {
+		if fileInfo, err := t.file.Stat(); err == nil && !os.SameFile(pathInfo, fileInfo) {
+			lines = t.read(lines)
+			lines = t.flushPartial(lines)
+			t.file.Close()
+			t.file = nil
+			if !t.open() {
+				return lines
+			}
+		}
+	}
+	// Checking the truncation, which means the file size is less than the read offset.
+	if fileInfo, err := t.file.Stat(); err == nil && fileInfo.Size() < t.offset {
+		t.offset = 0
+		t.partial = nil
+	}
+	return t.read(lines)
+}
+
+// open opens the file, and seeks to the end of the file if it exists when Tail is called
+// and <fromEnd> is true. It returns false if the file cannot be opened.
+func (t *Tailer) open() bool {
+	file, err := os.Open(t.path)
+	if err != nil {
+		return false
+	}
+	t.file = file
+	t.offset = 0
+	t.partial = nil
+	if !t.opened && t.fromEnd {
+		if offset, err := file.Seek(0, io.SeekEnd); err == nil {
+			t.offset = offset
+		}
+	}
+	t.opened = true
+	return true
+}
+
+// read reads the content from the read offset to the end of the file,
+// and appends each complete line to <lines>.
+func (t *Tailer) read(lines []string) []string {
+	buffer := make([]byte, 32*1024)
+	for {
+		n, err := t.file.ReadAt(buffer, t.offset)
+		if n > 0 {
+			t.offset += int64(n)
+			t.partial = append(t.partial, buffer[:n]...)
+			for {
+				index := bytes.IndexByte(t.partial, '\n')
+				if index < 0 {
+					break
+				}
+				lines = append(lines, string(bytes.TrimSuffix(t.partial[:index], []byte{'\r'})))
+				t.partial = t.partial[index+1:]
+			}
+			// The incomplete line is capped, the exceeding part is split as a line.
+			for len(t.partial) > gTAIL_MAX_LINE_SIZE {
+				lines = append(lines, string(t.partial[:gTAIL_MAX_LINE_SIZE]))
+				t.partial = t.partial[gTAIL_MAX_LINE_SIZE:]
+			}
+		}
+		if err != nil || n == 0 {
+			break
+		}
+	}
+	return lines
+}
+
+// flushPartial appends the incomplete last line of the rotated file to <lines>.
+func (t *Tailer) flushPartial(lines []string) []string {
+	if len(t.partial) > 0 {
+		lines = append(lines, string(bytes.TrimSuffix(t.partial, []byte{'\r'})))
+		t.partial = nil
+	}
+	return lines
+}
@@ -0,0 +1,50 @@
+package gfile
+
+import (
+	"os"
+)
+
+// CreateTempFile creates a new temporary file in the temporary directory of current system,
+// opens the file for reading and writing, and returns the opened file.
+//
+// The file name is generated by taking <pattern> and adding a random string to the end.
+// If <pattern> includes a "*", the random string replaces the last "*".
+// It is the caller's responsibility to remove the file when it is no longer needed.
+//
+// Note that it is not named TempFile/TempDir, as TempDir is already used for retrieving
+// the temporary directory of current system.
+func CreateTempFile(pattern string) (*os.File, error) {
+	return os.CreateTemp(tempDir, pattern)
+}
+
+// CreateTempDir creates a new temporary directory in the temporary directory of current system
+// and returns the path of the new directory.
+//
+// The directory name is generated by taking <pattern> and applying a random string to the end.
+// If <pattern> includes a "*", the random string replaces the last "*".
+// It is the caller's responsibility to remove the directory when it is no longer needed.
+func CreateTempDir(pattern string) (string, error) {
+	return os.MkdirTemp(tempDir, pattern)
+}
+
+// WithTempDir creates a new temporary directory, calls <f> with the path of the directory,
+// and removes the directory and all its sub-files after <f> returns, even if <f> panics.
+// It returns the error returned by <f>, or the error of removing the directory.
+//
+// Eg:
+//
+//	err := gfile.WithTempDir(func(dir string) error {
+//	    return gfile.PutContents(gfile.Join(dir, "test.txt"), "test")
+//	})
+func WithTempDir(f func(dir string) error) (err error) {
+	dir, err := CreateTempDir("")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if e := os.RemoveAll(dir); e != nil && err == nil {
+			err = e
+		}
+	}()
+	return f(dir)
+}
@@ -0,0 +1,120 @@
+package gfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// WalkOption is the option for WalkDir.
+type WalkOption struct {
+	MaxDepth       int  // Max depth of walking, in which depth 1 means the direct sub-files. No limit if it is 0.
+	FollowSymlinks bool // Whether walks into the directories that the symlinks point to.
+}
+
+var (
+	// WalkSkipDir is returned by the handler of WalkDir to skip the sub-files of the directory.
+	WalkSkipDir = filepath.SkipDir
+
+	// WalkSkipAll is returned by the handler of WalkDir to stop walking immediately.
+	WalkSkipAll = errors.New("skip all")
+)
+
+// WalkDir walks the directory tree of <path>, and calls <handler> for each sub-file and
+// sub-directory in lexical order, with its path, file info and depth, in which depth 1
+// means the direct sub-files of <path>. Unlike ScanDir, it reads the directories one by
+// one, without materializing the whole tree into memory.
+//
+// The <handler> returns WalkSkipDir to skip the sub-files of current directory, or
+// WalkSkipAll to stop walking immediately, or any other error to stop walking and return
+// the error.
+//
+// The optional parameter <option> specifies the max depth of walking and whether following
+// the symlinks. The symlinks are not followed in default, and the symlinks pointing to
+// their ancestor directories are not followed to avoid endless loop.
+func WalkDir(path string, handler func(path string, info os.FileInfo, depth int) error, option ...WalkOption) error {
+	walkOption := WalkOption{}
+	if len(option) > 0 {
+		walkOption = option[0]
+	}
+	ancestors := make(map[string]struct{})
+	if realPath, err := filepath.EvalSymlinks(path); err == nil {
+		ancestors[realPath] = struct{}{}
+	}
+	err := doWalkDir(path, 1, handler, walkOption, ancestors)
+	if err == WalkSkipAll || err == WalkSkipDir {
+		return nil
+	}
+	return err
+}
+
+// doWalkDir walks the sub-files of directory <path> in <depth> recursively.
+// The parameter <ancestors> is the real paths of the walking directories, which is used
+// for avoiding endless loop of symlinks.
+func doWalkDir(
+	path string, depth int,
+	handler func(path string, info os.FileInfo, depth int) error,
+	option WalkOption, ancestors map[string]struct{},
+) error {
+	if depth > gMAX_SCAN_DEPTH {
+		return errors.New("directory walking exceeds max recursive depth")
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	names, err := file.Readdirnames(-1)
+	file.Close()
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		filePath := filepath.Join(path, name)
+		info, err := os.Lstat(filePath)
+		if err != nil {
+			// The file might be deleted during walking.
+			continue
+		}
+		var (
+			isDir    = info.IsDir()
+			realPath = ""
+		)
+		if option.FollowSymlinks {
+			if info.Mode()&os.ModeSymlink != 0 {
+				if targetInfo, err := os.Stat(filePath); err == nil && targetInfo.IsDir() {
+					isDir = true
+				}
+			}
+			if isDir {
+				// Avoiding endless loop of symlinks pointing to the ancestor directories.
+				if realPath, err = filepath.EvalSymlinks(filePath); err != nil {
+					isDir = false
+				} else if _, ok := ancestors[realPath]; ok {
+					isDir = false
+				}
+			}
+		}
+		if err = handler(filePath, info, depth); err != nil {
+			if err == WalkSkipDir {
+				continue
+			}
+			return err
+		}
+		if !isDir || (option.MaxDepth > 0 && depth >= option.MaxDepth) {
+			continue
+		}
+		if realPath != "" {
+			ancestors[realPath] = struct{}{}
+		}
+		err = doWalkDir(filePath, depth+1, handler, option, ancestors)
+		if realPath != "" {
+			delete(ancestors, realPath)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
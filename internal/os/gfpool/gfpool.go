@@ -11,10 +11,14 @@ import (
 
 // File pointer pool.
 type Pool struct {
-	id   *gtype.Int    // Pool id, which is used to mark this pool whether recreated.
-	pool *gpool.Pool   // Underlying pool.
-	init *gtype.Bool   // Whether initialized, used for marking this file added to fsnotify, and it can only be added just once.
-	ttl  time.Duration // Time to live for file pointer items.
+	id    *gtype.Int    // Pool id, which is used to mark this pool whether recreated.
+	pool  *gpool.Pool   // Underlying pool.
+	init  *gtype.Bool   // Whether initialized, used for marking this file added to fsnotify, and it can only be added just once.
+	ttl   time.Duration // Time to live for file pointer items.
+	path  string        // File path of the pool.
+	flag  int           // Flag for opening file.
+	perm  os.FileMode   // Permission for opening file.
+	stats *poolStats    // Statistics of the pool.
 }
 
 // File is an item in the pool.
@@ -26,6 +30,7 @@ type File struct {
 	flag     int         // Flash for opening file.
 	perm     os.FileMode // Permission for opening file.
 	path     string      // Absolute path of the file.
+	reused   bool        // Whether the file pointer is retrieved from the pool instead of newly opened.
 }
 
 var (
@@ -1,6 +1,7 @@
 package gfpool
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -11,6 +12,13 @@ import (
 // It automatically creates an associated file pointer pool internally when it's called first time.
 // It retrieves a file item from the file pointer pool after then.
 func Open(path string, flag int, perm os.FileMode, ttl ...time.Duration) (file *File, err error) {
+	return OpenCtx(context.Background(), path, flag, perm, ttl...)
+}
+
+// OpenCtx creates and returns a file item with given file path, flag and opening permission,
+// just like Open. It blocks if it needs opening new file pointer and the limit of open file
+// pointers is reached, until any file pointer is closed or <ctx> is done. See SetMaxOpen.
+func OpenCtx(ctx context.Context, path string, flag int, perm os.FileMode, ttl ...time.Duration) (file *File, err error) {
 	var fpTTL time.Duration
 	if len(ttl) > 0 {
 		fpTTL = ttl[0]
@@ -29,7 +37,7 @@ func Open(path string, flag int, perm os.FileMode, ttl ...time.Duration) (file *
 		},
 	).(*Pool)
 
-	return pool.File()
+	return pool.FileCtx(ctx)
 }
 
 // Stat returns the FileInfo structure describing file.
@@ -43,7 +51,13 @@ func (f *File) Stat() (os.FileInfo, error) {
 // Close puts the file pointer back to the file pointer pool.
 func (f *File) Close() error {
 	if f.pid == f.pool.id.Val() {
-		return f.pool.pool.Put(f)
+		if err := f.pool.pool.Put(f); err != nil {
+			return err
+		}
+		// The idle file pointer can be reclaimed by the blocked opening.
+		limiter.wake()
+		return nil
 	}
-	return nil
+	// The file pointer of the recreated pool is closed instead of being put back.
+	return f.pool.closeFile(f.File)
 }
@@ -0,0 +1,110 @@
+package gfpool
+
+import (
+	"context"
+	"sync"
+)
+
+// openLimiter limits the count of concurrently open file pointers of all pools.
+type openLimiter struct {
+	mu     sync.Mutex    // Mutex for concurrent safety.
+	max    int           // Max count of open file pointers, no limit if it is <= 0.
+	count  int           // Current count of open file pointers.
+	notify chan struct{} // Closed and recreated when any file pointer is closed.
+}
+
+var (
+	// Global limiter for the open file pointers of all pools.
+	limiter = &openLimiter{
+		notify: make(chan struct{}),
+	}
+)
+
+// SetMaxOpen sets the max count of concurrently open file pointers of all the pools,
+// which prevents the process exhausting its file descriptor limit when there are lots of
+// distinct file paths in use. There's no limit if <max> <= 0, which is the default.
+//
+// Opening new file pointer blocks if the limit is reached, until any file pointer is closed
+// or the context given to OpenCtx/FileCtx is done. The idle file pointers in the pools created
+// by Open/OpenCtx are closed to release the limit when it is reached.
+func SetMaxOpen(max int) {
+	limiter.mu.Lock()
+	limiter.max = max
+	limiter.broadcast()
+	limiter.mu.Unlock()
+}
+
+// GetMaxOpen returns the max count of concurrently open file pointers of all the pools.
+func GetMaxOpen() int {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	return limiter.max
+}
+
+// acquire acquires a slot for opening file pointer. It blocks if the limit is reached,
+// and returns the error of <ctx> if <ctx> is done before any slot is available.
+func (l *openLimiter) acquire(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		if l.max <= 0 || l.count < l.max {
+			l.count++
+			l.mu.Unlock()
+			return nil
+		}
+		notify := l.notify
+		l.mu.Unlock()
+		// It closes an idle file pointer in the pools to release a slot.
+		if reclaimIdleFile() {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+// release releases a slot acquired by acquire.
+func (l *openLimiter) release() {
+	l.mu.Lock()
+	l.count--
+	l.broadcast()
+	l.mu.Unlock()
+}
+
+// wake wakes up all the waiting acquirers, which is called when any file pointer
+// is put back to the pool and becomes reclaimable.
+func (l *openLimiter) wake() {
+	l.mu.Lock()
+	if l.max > 0 {
+		l.broadcast()
+	}
+	l.mu.Unlock()
+}
+
+// broadcast wakes up all the waiting acquirers.
+// It should be called within mutex lock.
+func (l *openLimiter) broadcast() {
+	close(l.notify)
+	l.notify = make(chan struct{})
+}
+
+// reclaimIdleFile closes one idle file pointer in the pools created by Open/OpenCtx.
+// It returns false if there's no idle file pointer in the pools.
+func reclaimIdleFile() (reclaimed bool) {
+	pools.RLockFunc(func(m map[string]interface{}) {
+		for _, v := range m {
+			p := v.(*Pool)
+			if p.pool.Size() == 0 {
+				continue
+			}
+			if item, err := p.pool.Get(); err == nil {
+				p.pool.ExpireFunc(item)
+				reclaimed = true
+				return
+			}
+		}
+	})
+	return
+}
@@ -1,6 +1,7 @@
 package gfpool
 
 import (
+	"context"
 	"github.com/ilylx/gconv/container/gpool"
 	"github.com/ilylx/gconv/container/gtype"
 	"github.com/ilylx/gconv/internal/os/gfsnotify"
@@ -21,33 +22,42 @@ func New(path string, flag int, perm os.FileMode, ttl ...time.Duration) *Pool {
 		fpTTL = ttl[0]
 	}
 	p := &Pool{
-		id:   gtype.NewInt(),
-		ttl:  fpTTL,
-		init: gtype.NewBool(),
+		id:    gtype.NewInt(),
+		ttl:   fpTTL,
+		init:  gtype.NewBool(),
+		path:  path,
+		flag:  flag,
+		perm:  perm,
+		stats: newPoolStats(),
 	}
-	p.pool = newFilePool(p, path, flag, perm, fpTTL)
+	p.pool = newFilePool(p, fpTTL)
 	return p
 }
 
-// newFilePool creates and returns a file pointer pool with given file path, flag and opening permission.
-func newFilePool(p *Pool, path string, flag int, perm os.FileMode, ttl time.Duration) *gpool.Pool {
-	pool := gpool.New(ttl, func() (interface{}, error) {
-		file, err := os.OpenFile(path, flag, perm)
-		if err != nil {
-			return nil, err
-		}
-		return &File{
-			File: file,
-			pid:  p.id.Val(),
-			pool: p,
-			flag: flag,
-			perm: perm,
-			path: path,
-		}, nil
-	}, func(i interface{}) {
-		_ = i.(*File).File.Close()
+// newFilePool creates and returns the underlying pool of file pointer pool <p>.
+// The file pointers are created by newFile instead of the underlying pool,
+// as opening file might block until the context is done.
+func newFilePool(p *Pool, ttl time.Duration) *gpool.Pool {
+	return gpool.New(ttl, nil, func(i interface{}) {
+		p.stats.expired.Add(1)
+		_ = p.closeFile(i.(*File).File)
 	})
-	return pool
+}
+
+// newFile opens and returns a new file item of the pool.
+func (p *Pool) newFile(ctx context.Context) (*File, error) {
+	file, err := p.openFile(ctx, p.path, p.flag, p.perm)
+	if err != nil {
+		return nil, err
+	}
+	return &File{
+		File: file,
+		pid:  p.id.Val(),
+		pool: p,
+		flag: p.flag,
+		perm: p.perm,
+		path: p.path,
+	}, nil
 }
 
 // File retrieves file item from the file pointer pool and returns it. It creates one if
@@ -55,57 +65,78 @@ func newFilePool(p *Pool, path string, flag int, perm os.FileMode, ttl time.Dura
 // Note that it should be closed when it will never be used. When it's closed, it is not
 // really closed the underlying file pointer but put back to the file pinter pool.
 func (p *Pool) File() (*File, error) {
-	if v, err := p.pool.Get(); err != nil {
+	return p.FileCtx(context.Background())
+}
+
+// FileCtx retrieves file item from the file pointer pool and returns it, just like File.
+// It blocks if it needs opening new file pointer and the limit of open file pointers is
+// reached, until any file pointer is closed or <ctx> is done. See SetMaxOpen.
+func (p *Pool) FileCtx(ctx context.Context) (*File, error) {
+	if v, err := p.pool.Get(); err == nil {
+		return p.prepareFile(ctx, v.(*File))
+	}
+	// It opens a new file pointer as the pool is empty.
+	f, err := p.newFile(ctx)
+	if err != nil {
 		return nil, err
-	} else {
-		var err error
-		f := v.(*File)
-		f.stat, err = os.Stat(f.path)
-		if f.flag&os.O_CREATE > 0 {
-			if os.IsNotExist(err) {
-				if f.File, err = os.OpenFile(f.path, f.flag, f.perm); err != nil {
-					return nil, err
-				} else {
-					// Retrieve the state of the new created file.
-					if f.stat, err = f.File.Stat(); err != nil {
-						return nil, err
-					}
-				}
-			}
-		}
-		if f.flag&os.O_TRUNC > 0 {
-			if f.stat.Size() > 0 {
-				if err = f.Truncate(0); err != nil {
+	}
+	return p.prepareFile(ctx, f)
+}
+
+// prepareFile checks and prepares the file item <f> for using.
+func (p *Pool) prepareFile(ctx context.Context, f *File) (*File, error) {
+	var err error
+	if f.reused {
+		p.stats.hits.Add(1)
+	}
+	f.reused = true
+	f.stat, err = os.Stat(f.path)
+	if f.flag&os.O_CREATE > 0 {
+		if os.IsNotExist(err) {
+			// The old file pointer should be closed as the file is removed.
+			_ = p.closeFile(f.File)
+			if f.File, err = p.openFile(ctx, f.path, f.flag, f.perm); err != nil {
+				return nil, err
+			} else {
+				// Retrieve the state of the new created file.
+				if f.stat, err = f.File.Stat(); err != nil {
 					return nil, err
 				}
 			}
 		}
-		if f.flag&os.O_APPEND > 0 {
-			if _, err = f.Seek(0, 2); err != nil {
-				return nil, err
-			}
-		} else {
-			if _, err = f.Seek(0, 0); err != nil {
+	}
+	if f.flag&os.O_TRUNC > 0 {
+		if f.stat.Size() > 0 {
+			if err = f.Truncate(0); err != nil {
 				return nil, err
 			}
 		}
-		// It firstly checks using !p.init.Val() for performance purpose.
-		if !p.init.Val() && p.init.Cas(false, true) {
-			_, _ = gfsnotify.Add(f.path, func(event *gfsnotify.Event) {
-				// If teh file is removed or renamed, recreates the pool by increasing the pool id.
-				if event.IsRemove() || event.IsRename() {
-					// It drops the old pool.
-					p.id.Add(1)
-					// Clears the pool items staying in the pool.
-					p.pool.Clear()
-					// It uses another adding to drop the file items between the two adding.
-					// Whenever the pool id changes, the pool will be recreated.
-					p.id.Add(1)
-				}
-			}, false)
+	}
+	if f.flag&os.O_APPEND > 0 {
+		if _, err = f.Seek(0, 2); err != nil {
+			return nil, err
 		}
-		return f, nil
+	} else {
+		if _, err = f.Seek(0, 0); err != nil {
+			return nil, err
+		}
+	}
+	// It firstly checks using !p.init.Val() for performance purpose.
+	if !p.init.Val() && p.init.Cas(false, true) {
+		_, _ = gfsnotify.Add(f.path, func(event *gfsnotify.Event) {
+			// If teh file is removed or renamed, recreates the pool by increasing the pool id.
+			if event.IsRemove() || event.IsRename() {
+				// It drops the old pool.
+				p.id.Add(1)
+				// Clears the pool items staying in the pool.
+				p.pool.Clear()
+				// It uses another adding to drop the file items between the two adding.
+				// Whenever the pool id changes, the pool will be recreated.
+				p.id.Add(1)
+			}
+		}, false)
 	}
+	return f, nil
 }
 
 // Close closes current file pointer pool.
@@ -0,0 +1,98 @@
+package gfpool
+
+import (
+	"context"
+	"github.com/ilylx/gconv/container/gtype"
+	"os"
+	"sort"
+)
+
+// Stats is the statistics of a file pointer pool.
+type Stats struct {
+	Path       string // File path of the pool.
+	Flag       int    // Flag for opening file.
+	Open       int64  // Count of currently open file pointers, including the idle ones in the pool.
+	Idle       int    // Count of idle file pointers in the pool.
+	Opens      int64  // Total count of file opening.
+	Hits       int64  // Total count of file pointers reused from the pool.
+	Expired    int64  // Total count of idle file pointers closed by expiration, reclaiming or pool recreation.
+	OpenErrors int64  // Total count of file opening failures.
+}
+
+// poolStats is the internal counters of a file pointer pool.
+type poolStats struct {
+	open       *gtype.Int64 // Count of currently open file pointers.
+	opens      *gtype.Int64 // Total count of file opening.
+	hits       *gtype.Int64 // Total count of file pointers reused from the pool.
+	expired    *gtype.Int64 // Total count of idle file pointers closed by the pool.
+	openErrors *gtype.Int64 // Total count of file opening failures.
+}
+
+// newPoolStats creates and returns the internal counters of a file pointer pool.
+func newPoolStats() *poolStats {
+	return &poolStats{
+		open:       gtype.NewInt64(),
+		opens:      gtype.NewInt64(),
+		hits:       gtype.NewInt64(),
+		expired:    gtype.NewInt64(),
+		openErrors: gtype.NewInt64(),
+	}
+}
+
+// Stats returns the statistics of the file pointer pool.
+func (p *Pool) Stats() Stats {
+	return Stats{
+		Path:       p.path,
+		Flag:       p.flag,
+		Open:       p.stats.open.Val(),
+		Idle:       p.pool.Size(),
+		Opens:      p.stats.opens.Val(),
+		Hits:       p.stats.hits.Val(),
+		Expired:    p.stats.expired.Val(),
+		OpenErrors: p.stats.openErrors.Val(),
+	}
+}
+
+// AllStats returns the statistics of all the file pointer pools created by Open,
+// which are sorted by their file paths. It is used for monitoring the file descriptor
+// pressure of the process.
+func AllStats() []Stats {
+	array := make([]Stats, 0, pools.Size())
+	pools.RLockFunc(func(m map[string]interface{}) {
+		for _, v := range m {
+			array = append(array, v.(*Pool).Stats())
+		}
+	})
+	sort.Slice(array, func(i, j int) bool {
+		if array[i].Path != array[j].Path {
+			return array[i].Path < array[j].Path
+		}
+		return array[i].Flag < array[j].Flag
+	})
+	return array
+}
+
+// openFile opens the file and updates the statistics.
+// It blocks if the limit of open file pointers is reached, until <ctx> is done.
+func (p *Pool) openFile(ctx context.Context, path string, flag int, perm os.FileMode) (*os.File, error) {
+	if err := limiter.acquire(ctx); err != nil {
+		p.stats.openErrors.Add(1)
+		return nil, err
+	}
+	file, err := os.OpenFile(path, flag, perm)
+	if err != nil {
+		limiter.release()
+		p.stats.openErrors.Add(1)
+		return nil, err
+	}
+	p.stats.opens.Add(1)
+	p.stats.open.Add(1)
+	return file, nil
+}
+
+// closeFile closes the file and updates the statistics.
+func (p *Pool) closeFile(file *os.File) error {
+	p.stats.open.Add(-1)
+	limiter.release()
+	return file.Close()
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/ilylx/gconv/container/gqueue"
 	"github.com/ilylx/gconv/container/gset"
 	"github.com/ilylx/gconv/container/gtype"
+	"github.com/ilylx/gconv/internal/cmdenv"
 	"github.com/ilylx/gconv/internal/intlog"
 	"github.com/ilylx/gconv/internal/os/gcache"
 	"sync"
@@ -25,6 +26,7 @@ type Watcher struct {
 	nameSet   *gset.StrSet      // Used for AddOnce feature.
 	callbacks *gmap.StrAnyMap   // Path(file/folder) to callbacks mapping.
 	closeChan chan struct{}     // Used for watcher closing notification.
+	poller    *poller           // Polling monitor, used for the paths that the underlying fsnotify fails monitoring.
 }
 
 // Callback is the callback function for Watcher.
@@ -66,30 +68,53 @@ var (
 	defaultWatcher      *Watcher                  // Default watcher.
 	callbackIdMap       = gmap.NewIntAnyMap(true) // Id to callback mapping.
 	callbackIdGenerator = gtype.NewInt()          // Atomic id generator for callback.
+
+	// Polling interval of the default watcher. The default watcher uses polling instead of
+	// the underlying fsnotify if it is configured greater than 0.
+	defaultWatcherPollInterval = cmdenv.Get("gf.gfsnotify.poll", 0).Duration()
 )
 
 // New creates and returns a new watcher.
 // Note that the watcher number is limited by the file handle setting of the system.
 // Eg: fs.inotify.max_user_instances system variable in linux systems.
+//
+// The watcher falls back to polling if the underlying fsnotify is not available,
+// or it fails monitoring any path.
 func New() (*Watcher, error) {
-	w := &Watcher{
-		cache:     gcache.New(),
-		events:    gqueue.New(),
-		nameSet:   gset.NewStrSet(true),
-		closeChan: make(chan struct{}),
-		callbacks: gmap.NewStrAnyMap(true),
-	}
+	w := newWatcher(defaultPollInterval)
 	if watcher, err := fsnotify.NewWatcher(); err == nil {
 		w.watcher = watcher
 	} else {
-		intlog.Printf("New watcher failed: %v", err)
-		return nil, err
+		intlog.Printf("New watcher failed, falls back to polling: %v", err)
 	}
 	w.startWatchLoop()
 	w.startEventLoop()
 	return w, nil
 }
 
+// NewPoll creates and returns a new watcher which monitors the file changes by polling
+// at <interval>, comparing the modification time, size and mode of the files. It is used
+// on the file systems that the file system notification does not work, eg: NFS or some
+// containers. The callback API is the same as the watcher created by New.
+func NewPoll(interval time.Duration) (*Watcher, error) {
+	w := newWatcher(interval)
+	w.startWatchLoop()
+	w.startEventLoop()
+	return w, nil
+}
+
+// newWatcher creates and returns a new watcher without the underlying fsnotify.
+func newWatcher(pollInterval time.Duration) *Watcher {
+	return &Watcher{
+		cache:     gcache.New(),
+		events:    gqueue.New(),
+		nameSet:   gset.NewStrSet(true),
+		closeChan: make(chan struct{}),
+		callbacks: gmap.NewStrAnyMap(true),
+		poller:    newPoller(pollInterval),
+	}
+}
+
 // Add monitors <path> using default watcher with callback function <callbackFunc>.
 // The optional parameter <recursive> specifies whether monitoring the <path> recursively, which is true in default.
 func Add(path string, callbackFunc func(event *Event), recursive ...bool) (callback *Callback, err error) {
@@ -154,6 +179,10 @@ func getDefaultWatcher() (*Watcher, error) {
 		return defaultWatcher, nil
 	}
 	var err error
-	defaultWatcher, err = New()
+	if defaultWatcherPollInterval > 0 {
+		defaultWatcher, err = NewPoll(defaultWatcherPollInterval)
+	} else {
+		defaultWatcher, err = New()
+	}
 	return defaultWatcher, err
 }
@@ -0,0 +1,148 @@
+package gfsnotify
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// eventFilter filters the events of the files under the watched directory by patterns.
+type eventFilter struct {
+	root     string   // Absolute path of the watched directory.
+	includes []string // Patterns of the files whose events are handled.
+	excludes []string // Patterns of the files and directories whose events are ignored.
+}
+
+// AddRec monitors directory <path> recursively using default watcher with callback function
+// <callbackFunc>, which is only called for the events of files matching <patterns>.
+// See Watcher.AddRec.
+func AddRec(path string, patterns string, callbackFunc func(event *Event)) (callback *Callback, err error) {
+	w, err := getDefaultWatcher()
+	if err != nil {
+		return nil, err
+	}
+	return w.AddRec(path, patterns, callbackFunc)
+}
+
+// AddRec monitors directory <path> recursively with callback function <callbackFunc>, which is
+// only called for the events of files matching <patterns>. The newly created sub-folders are
+// automatically added to the monitor.
+//
+// The parameter <patterns> supports multiple patterns separated by ',', and the pattern with
+// prefix '!' is exclude pattern. The pattern containing '/' matches the path relative to <path>,
+// in which "**" matches any levels of directories, or else it matches the file name.
+// The events of the file are ignored if the file or any of its parent directories under <path>
+// matches any exclude pattern. It handles all the events not excluded if there's no include pattern.
+//
+// Eg:
+//
+//	gfsnotify.AddRec("config", "*.yaml,*.toml,!tmp,!**/*.bak", func(event *gfsnotify.Event) {
+//	    reloadConfig(event.Path)
+//	})
+func (w *Watcher) AddRec(path string, patterns string, callbackFunc func(event *Event)) (callback *Callback, err error) {
+	root := fileRealPath(path)
+	if root == "" {
+		return nil, errors.New(fmt.Sprintf(`"%s" does not exist`, path))
+	}
+	if !fileIsDir(root) {
+		return nil, errors.New(fmt.Sprintf(`"%s" is not a directory`, path))
+	}
+	filter := newEventFilter(root, patterns)
+	return w.Add(root, func(event *Event) {
+		if filter.Match(event.Path) {
+			callbackFunc(event)
+		}
+	}, true)
+}
+
+// newEventFilter creates and returns an event filter of directory <root> with <patterns>.
+func newEventFilter(root string, patterns string) *eventFilter {
+	filter := &eventFilter{
+		root: root,
+	}
+	for _, pattern := range strings.Split(patterns, ",") {
+		pattern = strings.TrimSpace(pattern)
+		switch {
+		case pattern == "":
+			continue
+		case pattern[0] == '!':
+			if pattern = strings.TrimSpace(pattern[1:]); pattern != "" {
+				filter.excludes = append(filter.excludes, pattern)
+			}
+		default:
+			filter.includes = append(filter.includes, pattern)
+		}
+	}
+	return filter
+}
+
+// Match checks whether the events of file <path> should be handled.
+func (f *eventFilter) Match(path string) bool {
+	relPath, err := filepath.Rel(f.root, path)
+	if err != nil || relPath == "." || strings.HasPrefix(relPath, "..") {
+		// The events of the watched directory itself are always handled.
+		return true
+	}
+	relPath = filepath.ToSlash(relPath)
+	// The file is excluded if itself or any of its parent directories is excluded.
+	for subPath := relPath; subPath != "."; subPath = fileSlashDir(subPath) {
+		for _, pattern := range f.excludes {
+			if matchPattern(pattern, subPath) {
+				return false
+			}
+		}
+	}
+	if len(f.includes) == 0 {
+		return true
+	}
+	for _, pattern := range f.includes {
+		if matchPattern(pattern, relPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// fileSlashDir returns the parent directory of slash separated relative path <path>,
+// which is "." if <path> has no parent directory.
+func fileSlashDir(path string) string {
+	if index := strings.LastIndexByte(path, '/'); index >= 0 {
+		return path[:index]
+	}
+	return "."
+}
+
+// matchPattern checks whether slash separated relative path <relPath> matches <pattern>.
+// The pattern without '/' matches the file name.
+func matchPattern(pattern string, relPath string) bool {
+	pattern = filepath.ToSlash(pattern)
+	if !strings.Contains(pattern, "/") {
+		match, _ := filepath.Match(pattern, relPath[strings.LastIndexByte(relPath, '/')+1:])
+		return match
+	}
+	return matchPatternParts(strings.Split(pattern, "/"), strings.Split(relPath, "/"))
+}
+
+// matchPatternParts checks whether the path parts <names> match the pattern parts <patterns>,
+// in which "**" matches zero or more path parts.
+func matchPatternParts(patterns []string, names []string) bool {
+	for len(patterns) > 0 {
+		if patterns[0] == "**" {
+			for i := 0; i <= len(names); i++ {
+				if matchPatternParts(patterns[1:], names[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(names) == 0 {
+			return false
+		}
+		if match, _ := filepath.Match(patterns[0], names[0]); !match {
+			return false
+		}
+		patterns, names = patterns[1:], names[1:]
+	}
+	return len(names) == 0
+}
@@ -0,0 +1,218 @@
+package gfsnotify
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// poller is the polling-based monitor for file changes, which compares the modification
+// time, size and mode of the monitored files at intervals. It is used on the file systems
+// that the underlying fsnotify does not work, eg: NFS or some containers.
+//
+// Like fsnotify, it produces events of the monitored files and the direct sub-files of the
+// monitored directories.
+type poller struct {
+	mu        sync.Mutex               // Mutex for concurrent safety of <paths>.
+	interval  time.Duration            // Polling interval.
+	paths     map[string]*pollSnapshot // Monitored path to its last snapshot mapping.
+	events    chan fsnotify.Event      // Produced events.
+	closeChan chan struct{}            // Used for poller closing notification.
+	closeOnce sync.Once                // Used for closing only once.
+}
+
+// pollSnapshot is the snapshot of a monitored path.
+type pollSnapshot struct {
+	self     pollFileState            // State of the monitored path.
+	children map[string]pollFileState // States of the direct sub-files if it is a directory.
+}
+
+// pollFileState is the state of a file used for changes comparison.
+type pollFileState struct {
+	isDir bool        // Is directory or not.
+	mtime time.Time   // Modification time.
+	size  int64       // File size.
+	mode  os.FileMode // File mode.
+}
+
+const (
+	// Default polling interval.
+	defaultPollInterval = time.Second
+)
+
+// newPoller creates and returns a poller polling at <interval>.
+func newPoller(interval time.Duration) *poller {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	p := &poller{
+		interval:  interval,
+		paths:     make(map[string]*pollSnapshot),
+		events:    make(chan fsnotify.Event, 128),
+		closeChan: make(chan struct{}),
+	}
+	go p.loop()
+	return p
+}
+
+// Add starts monitoring <path>.
+func (p *poller) Add(path string) error {
+	snapshot, err := newPollSnapshot(path)
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// The existing snapshot is kept for changes comparison.
+	if _, ok := p.paths[path]; !ok {
+		p.paths[path] = snapshot
+	}
+	return nil
+}
+
+// Remove stops monitoring <path>.
+func (p *poller) Remove(path string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.paths[path]; !ok {
+		return errors.New(fmt.Sprintf(`can't remove non-existent poll watch for: %s`, path))
+	}
+	delete(p.paths, path)
+	return nil
+}
+
+// Close stops the polling.
+func (p *poller) Close() error {
+	p.closeOnce.Do(func() {
+		close(p.closeChan)
+	})
+	return nil
+}
+
+// loop polls the monitored paths at intervals.
+func (p *poller) loop() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.closeChan:
+			return
+		case <-ticker.C:
+			p.poll()
+		}
+	}
+}
+
+// poll compares the monitored paths with their last snapshots and produces the events.
+func (p *poller) poll() {
+	p.mu.Lock()
+	paths := make([]string, 0, len(p.paths))
+	for path := range p.paths {
+		paths = append(paths, path)
+	}
+	p.mu.Unlock()
+	for _, path := range paths {
+		snapshot, _ := newPollSnapshot(path)
+		p.mu.Lock()
+		old, ok := p.paths[path]
+		if ok {
+			if snapshot == nil {
+				// The monitoring of the path is removed if it is removed, just like fsnotify.
+				delete(p.paths, path)
+			} else {
+				p.paths[path] = snapshot
+			}
+		}
+		p.mu.Unlock()
+		if !ok {
+			continue
+		}
+		if snapshot == nil {
+			p.send(path, REMOVE)
+			continue
+		}
+		if old.self.isDir && snapshot.self.isDir {
+			p.compareChildren(path, old.children, snapshot.children)
+		}
+		p.compare(path, old.self, snapshot.self)
+	}
+}
+
+// compareChildren compares the sub-files of directory <path> and produces the events.
+func (p *poller) compareChildren(path string, old, new map[string]pollFileState) {
+	for name, state := range new {
+		childPath := filepath.Join(path, name)
+		if oldState, ok := old[name]; ok {
+			p.compare(childPath, oldState, state)
+		} else {
+			p.send(childPath, CREATE)
+		}
+	}
+	for name := range old {
+		if _, ok := new[name]; !ok {
+			p.send(filepath.Join(path, name), REMOVE)
+		}
+	}
+}
+
+// compare compares the states of <path> and produces the events.
+func (p *poller) compare(path string, old, new pollFileState) {
+	// The modification time of directory changes when its sub-files change,
+	// which is reported by the events of the sub-files.
+	if !new.isDir && (!old.mtime.Equal(new.mtime) || old.size != new.size) {
+		p.send(path, WRITE)
+	}
+	if old.mode != new.mode {
+		p.send(path, CHMOD)
+	}
+}
+
+// send sends the event of <path> with <op>.
+func (p *poller) send(path string, op Op) {
+	select {
+	case p.events <- fsnotify.Event{Name: path, Op: fsnotify.Op(op)}:
+	case <-p.closeChan:
+	}
+}
+
+// newPollSnapshot creates and returns the snapshot of <path>.
+func newPollSnapshot(path string) (*pollSnapshot, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &pollSnapshot{
+		self: newPollFileState(info),
+	}
+	if info.IsDir() {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		infos, err := file.Readdir(-1)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.children = make(map[string]pollFileState, len(infos))
+		for _, info := range infos {
+			snapshot.children[info.Name()] = newPollFileState(info)
+		}
+	}
+	return snapshot, nil
+}
+
+// newPollFileState creates and returns the state of file <info>.
+func newPollFileState(info os.FileInfo) pollFileState {
+	return pollFileState{
+		isDir: info.IsDir(),
+		mtime: info.ModTime(),
+		size:  info.Size(),
+		mode:  info.Mode(),
+	}
+}
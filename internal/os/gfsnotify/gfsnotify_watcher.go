@@ -37,7 +37,7 @@ func (w *Watcher) AddOnce(name, path string, callbackFunc func(event *Event), re
 		if fileIsDir(path) && (len(recursive) == 0 || recursive[0]) {
 			for _, subPath := range fileAllDirs(path) {
 				if fileIsDir(subPath) {
-					if err := w.watcher.Add(subPath); err != nil {
+					if err := w.addMonitor(subPath); err != nil {
 						intlog.Error(err)
 					} else {
 						intlog.Printf("watcher adds monitor for: %s", subPath)
@@ -84,7 +84,7 @@ func (w *Watcher) addWithCallbackFunc(name, path string, callbackFunc func(event
 		callback.elem = list.PushBack(callback)
 	})
 	// Add the path to underlying monitor.
-	if err := w.watcher.Add(path); err != nil {
+	if err := w.addMonitor(path); err != nil {
 		intlog.Error(err)
 	} else {
 		intlog.Printf("watcher adds monitor for: %s", path)
@@ -99,12 +99,40 @@ func (w *Watcher) addWithCallbackFunc(name, path string, callbackFunc func(event
 // Close closes the watcher.
 func (w *Watcher) Close() {
 	w.events.Close()
-	if err := w.watcher.Close(); err != nil {
-		intlog.Error(err)
+	if w.watcher != nil {
+		if err := w.watcher.Close(); err != nil {
+			intlog.Error(err)
+		}
 	}
+	_ = w.poller.Close()
 	close(w.closeChan)
 }
 
+// addMonitor adds <path> to the underlying fsnotify monitor,
+// or to the polling monitor if the underlying fsnotify is not available or fails.
+func (w *Watcher) addMonitor(path string) error {
+	if w.watcher != nil {
+		err := w.watcher.Add(path)
+		if err == nil {
+			return nil
+		}
+		intlog.Printf("watcher fails adding monitor for: %s, falls back to polling: %v", path, err)
+	}
+	return w.poller.Add(path)
+}
+
+// removeMonitor removes <path> from the underlying fsnotify monitor and the polling monitor.
+func (w *Watcher) removeMonitor(path string) error {
+	pollErr := w.poller.Remove(path)
+	if w.watcher == nil {
+		return pollErr
+	}
+	if err := w.watcher.Remove(path); err != nil && pollErr != nil {
+		return err
+	}
+	return nil
+}
+
 // Remove removes monitor and all callbacks associated with the <path> recursively.
 func (w *Watcher) Remove(path string) error {
 	// Firstly remove the callbacks of the path.
@@ -122,14 +150,14 @@ func (w *Watcher) Remove(path string) error {
 	if subPaths, err := fileScanDir(path, "*", true); err == nil && len(subPaths) > 0 {
 		for _, subPath := range subPaths {
 			if w.checkPathCanBeRemoved(subPath) {
-				if err := w.watcher.Remove(subPath); err != nil {
+				if err := w.removeMonitor(subPath); err != nil {
 					intlog.Error(err)
 				}
 			}
 		}
 	}
 	// Lastly remove the monitor of the path from underlying monitor.
-	return w.watcher.Remove(path)
+	return w.removeMonitor(path)
 }
 
 // checkPathCanBeRemoved checks whether the given path have no callbacks bound.
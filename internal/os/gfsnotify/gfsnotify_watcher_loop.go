@@ -3,10 +3,23 @@ package gfsnotify
 import (
 	"github.com/ilylx/gconv/container/glist"
 	"github.com/ilylx/gconv/internal/intlog"
+
+	"github.com/fsnotify/fsnotify"
 )
 
-// startWatchLoop starts the loop for event listening fro underlying inotify monitor.
+// startWatchLoop starts the loop for event listening fro underlying inotify monitor
+// and the polling monitor.
 func (w *Watcher) startWatchLoop() {
+	var (
+		events <-chan fsnotify.Event
+		errors <-chan error
+	)
+	// The channels are nil if the underlying fsnotify is not available,
+	// which are never selected.
+	if w.watcher != nil {
+		events = w.watcher.Events
+		errors = w.watcher.Errors
+	}
 	go func() {
 		for {
 			select {
@@ -15,25 +28,34 @@ func (w *Watcher) startWatchLoop() {
 				return
 
 			// Event listening.
-			case ev := <-w.watcher.Events:
-				// Filter the repeated event in custom duration.
-				w.cache.SetIfNotExist(ev.String(), func() (interface{}, error) {
-					w.events.Push(&Event{
-						event:   ev,
-						Path:    ev.Name,
-						Op:      Op(ev.Op),
-						Watcher: w,
-					})
-					return struct{}{}, nil
-				}, repeatEventFilterDuration)
+			case ev := <-events:
+				w.pushEvent(ev)
+
+			// Event listening of polling.
+			case ev := <-w.poller.events:
+				w.pushEvent(ev)
 
-			case err := <-w.watcher.Errors:
+			case err := <-errors:
 				intlog.Error(err)
 			}
 		}
 	}()
 }
 
+// pushEvent pushes the event <ev> to the event queue,
+// which filters the repeated event in custom duration.
+func (w *Watcher) pushEvent(ev fsnotify.Event) {
+	w.cache.SetIfNotExist(ev.String(), func() (interface{}, error) {
+		w.events.Push(&Event{
+			event:   ev,
+			Path:    ev.Name,
+			Op:      Op(ev.Op),
+			Watcher: w,
+		})
+		return struct{}{}, nil
+	}, repeatEventFilterDuration)
+}
+
 // getCallbacks searches and returns all callbacks with given <path>.
 // It also searches its parent for callbacks if they're recursive.
 func (w *Watcher) getCallbacks(path string) (callbacks []*Callback) {
@@ -82,7 +104,7 @@ func (w *Watcher) startEventLoop() {
 				// If there's no any callback of this path, it removes it from monitor.
 				callbacks := w.getCallbacks(event.Path)
 				if len(callbacks) == 0 {
-					w.watcher.Remove(event.Path)
+					_ = w.removeMonitor(event.Path)
 					continue
 				}
 				switch {
@@ -92,7 +114,7 @@ func (w *Watcher) startEventLoop() {
 					if fileExists(event.Path) {
 						// It adds the path back to monitor.
 						// We need no worry about the repeat adding.
-						if err := w.watcher.Add(event.Path); err != nil {
+						if err := w.addMonitor(event.Path); err != nil {
 							intlog.Error(err)
 						} else {
 							intlog.Printf("fake remove event, watcher re-adds monitor for: %s", event.Path)
@@ -108,7 +130,7 @@ func (w *Watcher) startEventLoop() {
 					if fileExists(event.Path) {
 						// It might lost the monitoring for the path, so we add the path back to monitor.
 						// We need no worry about the repeat adding.
-						if err := w.watcher.Add(event.Path); err != nil {
+						if err := w.addMonitor(event.Path); err != nil {
 							intlog.Error(err)
 						} else {
 							intlog.Printf("fake rename event, watcher re-adds monitor for: %s", event.Path)
@@ -126,7 +148,7 @@ func (w *Watcher) startEventLoop() {
 						// If it's a folder, it then does adding recursively to monitor.
 						for _, subPath := range fileAllDirs(event.Path) {
 							if fileIsDir(subPath) {
-								if err := w.watcher.Add(subPath); err != nil {
+								if err := w.addMonitor(subPath); err != nil {
 									intlog.Error(err)
 								} else {
 									intlog.Printf("folder creation event, watcher adds monitor for: %s", subPath)
@@ -135,7 +157,7 @@ func (w *Watcher) startEventLoop() {
 						}
 					} else {
 						// If it's a file, it directly adds it to monitor.
-						if err := w.watcher.Add(event.Path); err != nil {
+						if err := w.addMonitor(event.Path); err != nil {
 							intlog.Error(err)
 						} else {
 							intlog.Printf("file creation event, watcher adds monitor for: %s", event.Path)
@@ -29,6 +29,12 @@ func DefaultLogger() *Logger {
 	return logger
 }
 
+// AsyncStats returns the statistics of the goroutine pool for async logging output,
+// which is used for monitoring the async logging.
+func AsyncStats() grpool.Stats {
+	return asyncPool.Stats()
+}
+
 // SetDefaultLogger sets the default logger for package glog.
 // Note that there might be concurrent safety issue if calls this function
 // in different goroutines.
@@ -104,3 +104,14 @@ func Critical(v ...interface{}) {
 func Criticalf(format string, v ...interface{}) {
 	logger.Criticalf(format, v...)
 }
+
+// Log prints the logging content with header of given <level> and newline,
+// which is commonly used for custom levels registered by RegisterLevel.
+func Log(level int, v ...interface{}) {
+	logger.Log(level, v...)
+}
+
+// Logf prints the logging content with header of given <level>, custom format and newline.
+func Logf(level int, format string, v ...interface{}) {
+	logger.Logf(level, format, v...)
+}
@@ -98,3 +98,15 @@ func Line(long ...bool) *Logger {
 func Async(enabled ...bool) *Logger {
 	return logger.Async(enabled...)
 }
+
+// Fields is a chaining function,
+// which attaches structured key-value pairs <fields> to the current logging content output.
+func Fields(fields map[string]interface{}) *Logger {
+	return logger.Fields(fields)
+}
+
+// Field is a chaining function,
+// which attaches a structured key-value pair to the current logging content output.
+func Field(key string, value interface{}) *Logger {
+	return logger.Field(key, value)
+}
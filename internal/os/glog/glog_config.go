@@ -142,3 +142,9 @@ func SetLevelPrefixes(prefixes map[int]string) {
 func GetLevelPrefix(level int) string {
 	return logger.GetLevelPrefix(level)
 }
+
+// SetRedaction sets the sensitive <keys> whose values are replaced with <replacement>
+// before they are written for the default logger.
+func SetRedaction(keys []string, replacement string) {
+	logger.SetRedaction(keys, replacement)
+}
@@ -29,6 +29,7 @@ type Logger struct {
 	init   *gtype.Bool     // Initialized.
 	parent *Logger         // Parent logger, if it is not empty, it means the logger is used in chaining function.
 	config Config          // Logger configuration.
+	fields []logField      // Structured key-value fields for logging.
 }
 
 const (
@@ -72,6 +73,7 @@ func (l *Logger) Clone() *Logger {
 	logger := New()
 	logger.ctx = l.ctx
 	logger.config = l.config
+	logger.fields = l.fields
 	logger.parent = l
 	return logger
 }
@@ -164,7 +166,7 @@ func (l *Logger) print(std io.Writer, lead string, values ...interface{}) {
 				if ctxStr != "" {
 					ctxStr += ", "
 				}
-				ctxStr += fmt.Sprintf("%s: %+v", key, v)
+				ctxStr += fmt.Sprintf("%s: %+v", key, l.redactCtxValue(key, v))
 			}
 		}
 		if ctxStr != "" {
@@ -175,7 +177,7 @@ func (l *Logger) print(std io.Writer, lead string, values ...interface{}) {
 		if err, ok := v.(error); ok {
 			tempStr = fmt.Sprintf("%+v", err)
 		} else {
-			tempStr = gconv.String(v)
+			tempStr = gconv.String(l.redactValue(v))
 		}
 		if len(valueStr) > 0 {
 			if valueStr[len(valueStr)-1] == '\n' {
@@ -192,6 +194,13 @@ func (l *Logger) print(std io.Writer, lead string, values ...interface{}) {
 			valueStr = tempStr
 		}
 	}
+	// Structured fields.
+	if len(l.fields) > 0 {
+		if len(valueStr) > 0 && valueStr[len(valueStr)-1] != '\n' {
+			valueStr += " "
+		}
+		valueStr += l.formatFields()
+	}
 	buffer.WriteString(valueStr + "\n")
 	if l.config.Flags&F_ASYNC > 0 {
 		err := asyncPool.Add(func() {
@@ -139,6 +139,27 @@ func (l *Logger) Criticalf(format string, v ...interface{}) {
 	}
 }
 
+// Log prints the logging content with header of given <level> and newline,
+// which is commonly used for custom levels registered by RegisterLevel.
+// It also prints caller stack info if stack feature is enabled and <level> is error or higher.
+func (l *Logger) Log(level int, v ...interface{}) {
+	severity := getLevelSeverity(level)
+	if !l.checkLevel(severity) {
+		return
+	}
+	if severity >= LEVEL_ERRO {
+		l.printErr(l.getLevelPrefixWithBrackets(level), v...)
+	} else {
+		l.printStd(l.getLevelPrefixWithBrackets(level), v...)
+	}
+}
+
+// Logf prints the logging content with header of given <level>, custom format and newline.
+// See Log.
+func (l *Logger) Logf(level int, format string, v ...interface{}) {
+	l.Log(level, l.format(format, v...))
+}
+
 // checkLevel checks whether the given <level> could be output.
 func (l *Logger) checkLevel(level int) bool {
 	return l.config.Level&level > 0
@@ -27,12 +27,14 @@ type Config struct {
 	HeaderPrint          bool           `c:"header"` // Print header or not(true in default).
 	StdoutPrint          bool           `c:"stdout"` // Output to stdout or not(true in default).
 	LevelPrefixes        map[int]string // Logging level to its prefix string mapping.
-	RotateSize           int64          // Rotate the logging file if its size > 0 in bytes.
+	RotateSize           int64          // Rotate the logging file if its size > 0 in bytes. It can be size string like "100MB" in configuration map.
 	RotateExpire         time.Duration  // Rotate the logging file if its mtime exceeds this duration.
 	RotateBackupLimit    int            // Max backup for rotated files, default is 0, means no backups.
 	RotateBackupExpire   time.Duration  // Max expire for rotated files, which is 0 in default, means no expiration.
 	RotateBackupCompress int            // Compress level for rotated files using gzip algorithm. It's 0 in default, means no compression.
 	RotateCheckInterval  time.Duration  // Asynchronizely checks the backups and expiration at intervals. It's 1 hour in default.
+	RedactKeys           []string       // Sensitive keys whose values are redacted in logging content.
+	RedactReplacement    string         // Replacement string for redacted values, which is "******" in default.
 }
 
 // DefaultConfig returns the default configuration for logger.
@@ -81,10 +83,10 @@ func (l *Logger) SetConfigWithMap(m map[string]interface{}) error {
 	// Change string configuration to int value for level.
 	levelKey, levelValue := gutil.MapPossibleItemByKey(m, "Level")
 	if levelValue != nil {
-		if level, ok := levelStringMap[strings.ToUpper(gconv.String(levelValue))]; ok {
+		if level, err := ParseLevel(gconv.String(levelValue)); err == nil {
 			m[levelKey] = level
 		} else {
-			return errors.New(fmt.Sprintf(`invalid level string: %v`, levelValue))
+			return err
 		}
 	}
 	// Change string configuration to int value for file rotation size.
@@ -0,0 +1,96 @@
+package glog
+
+import (
+	"github.com/ilylx/gconv"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// logField is a structured key-value pair attached to logging content.
+type logField struct {
+	key   string
+	value interface{}
+}
+
+// Fields is a chaining function,
+// which attaches structured key-value pairs <fields> to the current logging content output.
+// The pairs are rendered in key order as "key=value" suffix of the logging content.
+func (l *Logger) Fields(fields map[string]interface{}) *Logger {
+	logger := (*Logger)(nil)
+	if l.parent == nil {
+		logger = l.Clone()
+	} else {
+		logger = l
+	}
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		logger.setField(k, fields[k])
+	}
+	return logger
+}
+
+// Field is a chaining function,
+// which attaches a structured key-value pair to the current logging content output.
+// Multiple calls of Field keep the attaching sequence of the pairs.
+func (l *Logger) Field(key string, value interface{}) *Logger {
+	logger := (*Logger)(nil)
+	if l.parent == nil {
+		logger = l.Clone()
+	} else {
+		logger = l
+	}
+	logger.setField(key, value)
+	return logger
+}
+
+// GetFields returns a copy of the structured key-value pairs attached to the logger.
+func (l *Logger) GetFields() map[string]interface{} {
+	m := make(map[string]interface{}, len(l.fields))
+	for _, f := range l.fields {
+		m[f.key] = f.value
+	}
+	return m
+}
+
+// setField sets <key> to <value>, overwriting existing field of the same key.
+// Note that it always creates a new slice, as the fields slice might be shared with
+// the parent logger.
+func (l *Logger) setField(key string, value interface{}) {
+	fields := make([]logField, 0, len(l.fields)+1)
+	for _, f := range l.fields {
+		if f.key != key {
+			fields = append(fields, f)
+		}
+	}
+	l.fields = append(fields, logField{key: key, value: value})
+}
+
+// formatFields formats the structured fields to logfmt style string, eg: k1=v1 k2="v 2".
+func (l *Logger) formatFields() string {
+	var builder strings.Builder
+	for i, f := range l.fields {
+		if i > 0 {
+			builder.WriteByte(' ')
+		}
+		var value string
+		if l.isRedactKey(f.key) {
+			value = l.getRedactReplacement()
+		} else if err, ok := f.value.(error); ok {
+			value = err.Error()
+		} else {
+			value = gconv.String(l.redactValue(f.value))
+		}
+		builder.WriteString(f.key)
+		builder.WriteByte('=')
+		if value == "" || strings.ContainsAny(value, " =\"\t\r\n") {
+			value = strconv.Quote(value)
+		}
+		builder.WriteString(value)
+	}
+	return builder.String()
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 )
 
 // Note that the LEVEL_PANI and LEVEL_FATA levels are not used for logging output,
@@ -54,6 +55,95 @@ var levelStringMap = map[string]int{
 	"CRITICAL": LEVEL_CRIT,
 }
 
+var (
+	// levelMu is the mutex for concurrent safety of level registry,
+	// as custom levels and aliases can be registered at runtime.
+	levelMu sync.RWMutex
+
+	// customLevels defines the custom level to its mapping severity level.
+	customLevels = map[int]int{}
+
+	// customLevelNames defines the custom level to its name.
+	customLevelNames = map[int]string{}
+
+	// nextCustomLevel is the level value for the next registered custom level.
+	nextCustomLevel = LEVEL_FATA << 1
+)
+
+// ParseLevel parses and returns the level value from given level string <levelStr>,
+// which is commonly used for configuration files, eg: "info", "WARNING", "prod".
+// The level string is case-insensitive and can be any registered alias or custom level name.
+func ParseLevel(levelStr string) (int, error) {
+	levelMu.RLock()
+	defer levelMu.RUnlock()
+	if level, ok := levelStringMap[strings.ToUpper(strings.TrimSpace(levelStr))]; ok {
+		return level, nil
+	}
+	return 0, errors.New(fmt.Sprintf(`invalid level string: %s`, levelStr))
+}
+
+// AddLevelAlias adds level string <alias> for level value <level>,
+// which can be used in ParseLevel, SetLevelStr and configurations.
+// The parameter <level> is a level value used in SetLevel, eg: LEVEL_WARN | LEVEL_ERRO | LEVEL_CRIT.
+// It overwrites the existing alias of the same name.
+func AddLevelAlias(alias string, level int) {
+	levelMu.Lock()
+	defer levelMu.Unlock()
+	levelStringMap[strings.ToUpper(alias)] = level
+}
+
+// RegisterLevel registers a custom level named <name> mapping onto existing <severity>,
+// and returns the level value for the custom level, which can be used in Log/Logf.
+// The parameter <severity> should be one of LEVEL_DEBU/INFO/NOTI/WARN/ERRO/CRIT.
+//
+// The custom level is output only if its mapping severity is enabled,
+// and the <name> is used as its prefix string unless the prefix is set by SetLevelPrefix.
+// The <name> is also added as level string alias that enables its severity and higher ones,
+// eg: RegisterLevel("AUDIT", LEVEL_NOTI) makes level string "AUDIT" equal to "NOTI".
+//
+// Registering the same <name> again changes its severity and returns the same level value.
+// It returns error if <name> is a built-in level string or an alias, eg: "ERRO",
+// or if the level values for custom levels are used up.
+func RegisterLevel(name string, severity int) (int, error) {
+	name = strings.ToUpper(name)
+	levelMu.Lock()
+	defer levelMu.Unlock()
+	if _, ok := defaultLevelPrefixes[severity]; !ok || severity == LEVEL_PANI || severity == LEVEL_FATA {
+		return 0, errors.New(fmt.Sprintf(`invalid severity level: %d`, severity))
+	}
+	for level, levelName := range customLevelNames {
+		if levelName == name {
+			customLevels[level] = severity
+			levelStringMap[name] = levelStringMap[defaultLevelPrefixes[severity]]
+			return level, nil
+		}
+	}
+	if _, ok := levelStringMap[name]; ok {
+		return 0, errors.New(fmt.Sprintf(`level name is already used: %s`, name))
+	}
+	// The level value overflows int after all its bits are used.
+	if nextCustomLevel <= 0 {
+		return 0, errors.New(fmt.Sprintf(`too many custom levels, cannot register: %s`, name))
+	}
+	level := nextCustomLevel
+	nextCustomLevel <<= 1
+	customLevels[level] = severity
+	customLevelNames[level] = name
+	levelStringMap[name] = levelStringMap[defaultLevelPrefixes[severity]]
+	return level, nil
+}
+
+// getLevelSeverity returns the severity level for <level>.
+// It returns <level> itself if it is not a custom level.
+func getLevelSeverity(level int) int {
+	levelMu.RLock()
+	defer levelMu.RUnlock()
+	if severity, ok := customLevels[level]; ok {
+		return severity
+	}
+	return level
+}
+
 // SetLevel sets the logging level.
 func (l *Logger) SetLevel(level int) {
 	l.config.Level = level
@@ -66,11 +156,11 @@ func (l *Logger) GetLevel() int {
 
 // SetLevelStr sets the logging level by level string.
 func (l *Logger) SetLevelStr(levelStr string) error {
-	if level, ok := levelStringMap[strings.ToUpper(levelStr)]; ok {
-		l.config.Level = level
-	} else {
-		return errors.New(fmt.Sprintf(`invalid level string: %s`, levelStr))
+	level, err := ParseLevel(levelStr)
+	if err != nil {
+		return err
 	}
+	l.config.Level = level
 	return nil
 }
 
@@ -88,12 +178,17 @@ func (l *Logger) SetLevelPrefixes(prefixes map[int]string) {
 
 // GetLevelPrefix returns the prefix string for specified level.
 func (l *Logger) GetLevelPrefix(level int) string {
-	return l.config.LevelPrefixes[level]
+	if s, ok := l.config.LevelPrefixes[level]; ok {
+		return s
+	}
+	levelMu.RLock()
+	defer levelMu.RUnlock()
+	return customLevelNames[level]
 }
 
 // getLevelPrefixWithBrackets returns the prefix string with brackets for specified level.
 func (l *Logger) getLevelPrefixWithBrackets(level int) string {
-	if s, ok := l.config.LevelPrefixes[level]; ok {
+	if s := l.GetLevelPrefix(level); s != "" {
 		return "[" + s + "]"
 	}
 	return ""
@@ -0,0 +1,158 @@
+package glog
+
+import (
+	"bytes"
+	"github.com/ilylx/gconv"
+	"github.com/ilylx/gconv/internal/json"
+	"github.com/ilylx/gconv/internal/utils"
+	"reflect"
+)
+
+const (
+	// defaultRedactReplacement is the replacement string for redacted values
+	// if no replacement is configured.
+	defaultRedactReplacement = "******"
+)
+
+// SetRedaction sets the sensitive <keys> whose values are replaced with <replacement>
+// before they are written, eg: password, token, secret.
+//
+// The redaction applies to context values, map/struct arguments and JSON content.
+// The key matching is case-insensitive and ignores chars '-'/'_'/'.'/' ',
+// which means key "access_token" also matches "AccessToken".
+func (l *Logger) SetRedaction(keys []string, replacement string) {
+	l.config.RedactKeys = keys
+	l.config.RedactReplacement = replacement
+}
+
+// GetRedactKeys returns the sensitive keys for redaction.
+func (l *Logger) GetRedactKeys() []string {
+	return l.config.RedactKeys
+}
+
+// isRedactKey checks and returns whether given <key> is configured as sensitive key.
+func (l *Logger) isRedactKey(key string) bool {
+	for _, k := range l.config.RedactKeys {
+		if utils.EqualFoldWithoutChars(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// getRedactReplacement returns the replacement string for redacted values.
+func (l *Logger) getRedactReplacement() string {
+	if l.config.RedactReplacement != "" {
+		return l.config.RedactReplacement
+	}
+	return defaultRedactReplacement
+}
+
+// redactValue returns the redacted copy of <value> for logging.
+// It returns <value> directly if no redaction is needed.
+func (l *Logger) redactValue(value interface{}) interface{} {
+	if len(l.config.RedactKeys) == 0 || value == nil {
+		return value
+	}
+	switch v := value.(type) {
+	case string:
+		if r, ok := l.redactJson([]byte(v)); ok {
+			return r
+		}
+		return v
+	case []byte:
+		if r, ok := l.redactJson(v); ok {
+			return r
+		}
+		return v
+	case apiString, error:
+		return value
+	}
+	var (
+		rv   = reflect.ValueOf(value)
+		kind = rv.Kind()
+	)
+	for kind == reflect.Ptr {
+		if rv.IsNil() {
+			return value
+		}
+		rv = rv.Elem()
+		kind = rv.Kind()
+	}
+	switch kind {
+	case reflect.Map, reflect.Struct, reflect.Slice, reflect.Array:
+		if r, ok := l.redactJson([]byte(gconv.String(value))); ok {
+			return r
+		}
+	}
+	return value
+}
+
+// redactJson decodes <content> as JSON, redacts the sensitive keys recursively
+// and returns the encoded content. It returns false if <content> is not JSON
+// or there's nothing to redact.
+func (l *Logger) redactJson(content []byte) (string, bool) {
+	content = bytes.TrimSpace(content)
+	if len(content) < 2 || (content[0] != '{' && content[0] != '[') {
+		return "", false
+	}
+	var (
+		data    interface{}
+		decoder = json.NewDecoder(bytes.NewReader(content))
+	)
+	decoder.UseNumber()
+	if err := decoder.Decode(&data); err != nil {
+		return "", false
+	}
+	if !l.redactData(data) {
+		return "", false
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return "", false
+	}
+	return string(b), true
+}
+
+// redactData replaces the values of sensitive keys in <data> in place.
+// It returns true if any value is replaced.
+func (l *Logger) redactData(data interface{}) bool {
+	redacted := false
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if l.isRedactKey(key) {
+				v[key] = l.getRedactReplacement()
+				redacted = true
+			} else if l.redactData(item) {
+				redacted = true
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if l.redactData(item) {
+				redacted = true
+			}
+		}
+	}
+	return redacted
+}
+
+// redactCtxValue returns the redacted context value for given <key>.
+func (l *Logger) redactCtxValue(key interface{}, value interface{}) interface{} {
+	if len(l.config.RedactKeys) == 0 {
+		return value
+	}
+	if s, ok := key.(string); ok && l.isRedactKey(s) {
+		return l.getRedactReplacement()
+	}
+	if s, ok := key.(apiString); ok && l.isRedactKey(s.String()) {
+		return l.getRedactReplacement()
+	}
+	return l.redactValue(value)
+}
+
+// apiString is used for type assert api for String().
+type apiString interface {
+	String() string
+}
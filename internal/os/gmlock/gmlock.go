@@ -1,6 +1,10 @@
 // Package gmlock implements a concurrent-safe memory-based locker.
 package gmlock
 
+import (
+	"time"
+)
+
 var (
 	// Default locker.
 	locker = New()
@@ -25,6 +29,16 @@ func Unlock(key string) {
 	locker.Unlock(key)
 }
 
+// LockWithToken locks the <key> with writing lock, and returns the token for UnlockWithToken.
+func LockWithToken(key string) uint64 {
+	return locker.LockWithToken(key)
+}
+
+// UnlockWithToken unlocks the writing lock of the <key> acquired with <token>.
+func UnlockWithToken(key string, token uint64) {
+	locker.UnlockWithToken(key, token)
+}
+
 // RLock locks the <key> with reading lock.
 // If there's a writing lock on <key>,
 // it will blocks until the writing lock is released.
@@ -43,6 +57,16 @@ func RUnlock(key string) {
 	locker.RUnlock(key)
 }
 
+// RLockWithToken locks the <key> with reading lock, and returns the token for RUnlockWithToken.
+func RLockWithToken(key string) uint64 {
+	return locker.RLockWithToken(key)
+}
+
+// RUnlockWithToken unlocks the reading lock of the <key> acquired with <token>.
+func RUnlockWithToken(key string, token uint64) {
+	locker.RUnlockWithToken(key, token)
+}
+
 // LockFunc locks the <key> with writing lock and callback function <f>.
 // If there's a write/reading lock the <key>,
 // it will blocks until the lock is released.
@@ -81,3 +105,21 @@ func TryRLockFunc(key string, f func()) bool {
 func Remove(key string) {
 	locker.Remove(key)
 }
+
+// SetMaxHold sets the max duration <maxHold> that the lock of <key> can be held, after which
+// the lock is automatically released and the optional callback function <onExpired> is called.
+// It removes the limit if <maxHold> <= 0.
+func SetMaxHold(key string, maxHold time.Duration, onExpired ...func(key string)) {
+	locker.SetMaxHold(key, maxHold, onExpired...)
+}
+
+// SetDiagnostics enables or disables the diagnostics of the default locker, which records
+// the hold time and holder stack of each lock for Snapshot.
+func SetDiagnostics(enabled bool) {
+	locker.SetDiagnostics(enabled)
+}
+
+// Snapshot returns the information of the currently held locks of the default locker.
+func Snapshot() []HoldInfo {
+	return locker.Snapshot()
+}
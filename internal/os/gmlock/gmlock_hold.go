@@ -0,0 +1,269 @@
+package gmlock
+
+import (
+	"github.com/ilylx/gconv/internal/gdebug"
+	"github.com/ilylx/gconv/internal/os/gmutex"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// gFILTER_KEY is used for filtering the stack of this package.
+	gFILTER_KEY = "/os/gmlock/gmlock"
+)
+
+// HoldInfo is the information of a held lock, which is returned by Snapshot.
+type HoldInfo struct {
+	Key      string        // Key of the lock.
+	Write    bool          // Whether it is a writing lock, or else a reading lock.
+	Since    time.Time     // Time when the lock is acquired, which is zero if diagnostics is disabled.
+	HoldTime time.Duration // Duration the lock has been held, which is 0 if diagnostics is disabled.
+	Stack    string        // Stack of the holder acquiring the lock, which is empty if diagnostics is disabled.
+}
+
+// lockHolder is a holder of the lock of a key.
+type lockHolder struct {
+	token   uint64      // Token identifying the acquisition of the lock.
+	write   bool        // Whether it is a writing lock.
+	expired bool        // Whether the lock is auto-released, whose unlocking should be ignored.
+	since   time.Time   // Time when the lock is acquired.
+	stack   string      // Stack of the holder, which is only recorded if diagnostics is enabled.
+	timer   *time.Timer // Timer for auto-releasing the lock, which is nil if there's no max hold duration.
+}
+
+// lockState is the tracking state of the lock of a key.
+type lockState struct {
+	maxHold   time.Duration    // Max duration the lock can be held, 0 means no limits.
+	onExpired func(key string) // Callback function called after the lock is auto-released.
+	holders   []*lockHolder    // Current holders of the lock, including the auto-released ones not unlocked yet.
+}
+
+// lockTracker tracks the holders of the locks for max hold duration and diagnostics.
+type lockTracker struct {
+	mu          sync.Mutex
+	enabled     bool                  // Whether the tracking is enabled, which is true if diagnostics or any max hold duration is set.
+	diagnostics bool                  // Whether the diagnostics is enabled.
+	states      map[string]*lockState // Tracking states of the locks.
+	lastToken   uint64                // Last token assigned to the holder.
+}
+
+// SetMaxHold sets the max duration <maxHold> that the lock of <key> can be held, after which
+// the lock is automatically released and the optional callback function <onExpired> is called.
+// It removes the limit if <maxHold> <= 0.
+//
+// Note that the later unlocking of the auto-released lock by its original holder is ignored.
+// The holder is identified by the token returned by LockWithToken/RLockWithToken, which is used
+// internally by LockFunc/RLockFunc/TryLockFunc/TryRLockFunc. The Unlock/RUnlock without token
+// releases the latest live holder of the lock if there's any, or else it is considered as the
+// late unlocking of an auto-released holder and ignored. So the holder should use the token
+// or the Func variants if it may hold the lock longer than <maxHold>.
+func (l *Locker) SetMaxHold(key string, maxHold time.Duration, onExpired ...func(key string)) {
+	l.tracker.mu.Lock()
+	defer l.tracker.mu.Unlock()
+	state := l.getOrNewStateWithoutLock(key)
+	if maxHold > 0 {
+		state.maxHold = maxHold
+		state.onExpired = nil
+		if len(onExpired) > 0 {
+			state.onExpired = onExpired[0]
+		}
+		l.tracker.enabled = true
+	} else {
+		state.maxHold = 0
+		state.onExpired = nil
+		l.cleanStateWithoutLock(key, state)
+	}
+}
+
+// SetDiagnostics enables or disables the diagnostics of the locker, which records the hold time
+// and holder stack of each lock for Snapshot. It is disabled in default, as recording the stack
+// has performance cost.
+func (l *Locker) SetDiagnostics(enabled bool) {
+	l.tracker.mu.Lock()
+	defer l.tracker.mu.Unlock()
+	l.tracker.diagnostics = enabled
+	if enabled {
+		l.tracker.enabled = true
+	}
+}
+
+// Snapshot returns the information of the currently held locks, which is sorted by key.
+// The hold time and holder stack are only available if diagnostics is enabled using SetDiagnostics,
+// or else it only reports the keys and lock types of the held locks.
+func (l *Locker) Snapshot() []HoldInfo {
+	var (
+		now   = time.Now()
+		infos = make([]HoldInfo, 0)
+	)
+	l.tracker.mu.Lock()
+	if l.tracker.diagnostics {
+		for key, state := range l.tracker.states {
+			for _, holder := range state.holders {
+				if holder.expired {
+					continue
+				}
+				infos = append(infos, HoldInfo{
+					Key:      key,
+					Write:    holder.write,
+					Since:    holder.since,
+					HoldTime: now.Sub(holder.since),
+					Stack:    holder.stack,
+				})
+			}
+		}
+	}
+	l.tracker.mu.Unlock()
+	if len(infos) == 0 {
+		l.m.Iterator(func(key string, v interface{}) bool {
+			if mu := v.(*gmutex.Mutex); mu.IsWLocked() {
+				infos = append(infos, HoldInfo{Key: key, Write: true})
+			} else if mu.IsRLocked() {
+				infos = append(infos, HoldInfo{Key: key})
+			}
+			return true
+		})
+	}
+	sort.SliceStable(infos, func(i, j int) bool {
+		if infos[i].Key != infos[j].Key {
+			return infos[i].Key < infos[j].Key
+		}
+		return infos[i].Since.Before(infos[j].Since)
+	})
+	return infos
+}
+
+// onLocked records the holder of the lock of <key> after it is acquired.
+// It returns the token of the holder, which is 0 if the lock is not tracked.
+func (l *Locker) onLocked(key string, write bool) (token uint64) {
+	l.tracker.mu.Lock()
+	defer l.tracker.mu.Unlock()
+	if !l.tracker.enabled {
+		return 0
+	}
+	state := l.tracker.states[key]
+	if state == nil && !l.tracker.diagnostics {
+		return 0
+	}
+	if state == nil {
+		state = l.getOrNewStateWithoutLock(key)
+	}
+	l.tracker.lastToken++
+	holder := &lockHolder{
+		token: l.tracker.lastToken,
+		write: write,
+		since: time.Now(),
+	}
+	if l.tracker.diagnostics {
+		holder.stack = gdebug.StackWithFilter(gFILTER_KEY)
+	}
+	if state.maxHold > 0 {
+		holder.timer = time.AfterFunc(state.maxHold, func() {
+			l.expire(key, holder)
+		})
+	}
+	state.holders = append(state.holders, holder)
+	return holder.token
+}
+
+// onUnlocking removes the holder of the lock of <key> before it is released.
+// The holder is identified by <token> if it is not 0, or else it removes the latest live holder
+// of the same lock type, and the auto-released one only if there's no live holder.
+// It returns true if the unlocking should be ignored, as the lock is already auto-released.
+func (l *Locker) onUnlocking(key string, write bool, token uint64) (ignored bool) {
+	l.tracker.mu.Lock()
+	defer l.tracker.mu.Unlock()
+	if !l.tracker.enabled {
+		return false
+	}
+	state := l.tracker.states[key]
+	if state == nil {
+		return false
+	}
+	index := -1
+	if token != 0 {
+		for i, holder := range state.holders {
+			if holder.token == token {
+				index = i
+				break
+			}
+		}
+	} else {
+		// The holder goroutine cannot be identified without token,
+		// it prefers the latest live holder of the same lock type.
+		for i := len(state.holders) - 1; i >= 0; i-- {
+			if holder := state.holders[i]; holder.write == write {
+				if !holder.expired {
+					index = i
+					break
+				}
+				if index == -1 {
+					index = i
+				}
+			}
+		}
+	}
+	if index == -1 {
+		return false
+	}
+	holder := state.holders[index]
+	if holder.timer != nil {
+		holder.timer.Stop()
+	}
+	state.holders = append(state.holders[:index], state.holders[index+1:]...)
+	l.cleanStateWithoutLock(key, state)
+	return holder.expired
+}
+
+// expire auto-releases the lock of <key> held by <holder> which exceeds the max hold duration.
+func (l *Locker) expire(key string, holder *lockHolder) {
+	l.tracker.mu.Lock()
+	var (
+		state = l.tracker.states[key]
+		found = false
+	)
+	if state != nil {
+		for _, v := range state.holders {
+			if v == holder && !v.expired {
+				// The holder is kept for ignoring its later unlocking.
+				v.expired = true
+				found = true
+				break
+			}
+		}
+	}
+	l.tracker.mu.Unlock()
+	if !found {
+		return
+	}
+	if v := l.m.Get(key); v != nil {
+		if holder.write {
+			v.(*gmutex.Mutex).Unlock()
+		} else {
+			v.(*gmutex.Mutex).RUnlock()
+		}
+	}
+	if state.onExpired != nil {
+		state.onExpired(key)
+	}
+}
+
+// getOrNewStateWithoutLock returns the tracking state of <key>, or creates a new one if it does not exist.
+func (l *Locker) getOrNewStateWithoutLock(key string) *lockState {
+	if l.tracker.states == nil {
+		l.tracker.states = make(map[string]*lockState)
+	}
+	state := l.tracker.states[key]
+	if state == nil {
+		state = &lockState{}
+		l.tracker.states[key] = state
+	}
+	return state
+}
+
+// cleanStateWithoutLock removes the tracking state of <key> if it is no longer needed.
+func (l *Locker) cleanStateWithoutLock(key string, state *lockState) {
+	if state.maxHold == 0 && len(state.holders) == 0 {
+		delete(l.tracker.states, key)
+	}
+}
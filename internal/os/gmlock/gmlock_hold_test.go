@@ -0,0 +1,138 @@
+package gmlock
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitExpired waits until <count> reaches <n>, or fails the test after timeout.
+func waitExpired(t *testing.T, count *int32, n int32) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(count) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("lock is not auto-released, expired count: %d", atomic.LoadInt32(count))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func Test_Locker_MaxHold_OtherHolderAfterExpired(t *testing.T) {
+	var (
+		l       = New()
+		key     = "key"
+		expired int32
+	)
+	l.SetMaxHold(key, 50*time.Millisecond, func(key string) {
+		atomic.AddInt32(&expired, 1)
+	})
+	// A holds the lock too long and it is auto-released.
+	l.Lock(key)
+	waitExpired(t, &expired, 1)
+
+	// B locks and unlocks, which should really release the lock of B.
+	l.Lock(key)
+	l.Unlock(key)
+	if !l.TryLock(key) {
+		t.Fatal("lock of B is not released by its unlocking")
+	}
+	l.Unlock(key)
+
+	// The late unlocking of A is ignored.
+	l.Unlock(key)
+	if !l.TryLock(key) {
+		t.Fatal("lock should be available after the late unlocking")
+	}
+	l.Unlock(key)
+
+	// B did nothing wrong, so the callback is not called for B.
+	time.Sleep(100 * time.Millisecond)
+	if n := atomic.LoadInt32(&expired); n != 1 {
+		t.Fatalf("expired callback called %d times, expected 1", n)
+	}
+}
+
+func Test_Locker_MaxHold_LateUnlockWithToken(t *testing.T) {
+	var (
+		l       = New()
+		key     = "key"
+		expired int32
+	)
+	l.SetMaxHold(key, 50*time.Millisecond, func(key string) {
+		atomic.AddInt32(&expired, 1)
+	})
+	tokenA := l.LockWithToken(key)
+	waitExpired(t, &expired, 1)
+
+	tokenB := l.LockWithToken(key)
+	// The late unlocking of A does not release the lock held by B.
+	l.UnlockWithToken(key, tokenA)
+	if l.TryLock(key) {
+		t.Fatal("late unlocking of the auto-released holder released the lock of another holder")
+	}
+	l.UnlockWithToken(key, tokenB)
+	if !l.TryLock(key) {
+		t.Fatal("lock is not released by its holder")
+	}
+	l.Unlock(key)
+	if n := atomic.LoadInt32(&expired); n != 1 {
+		t.Fatalf("expired callback called %d times, expected 1", n)
+	}
+}
+
+func Test_Locker_MaxHold_LockFunc(t *testing.T) {
+	var (
+		l       = New()
+		key     = "key"
+		expired int32
+		entered = make(chan struct{})
+		release = make(chan struct{})
+		done    = make(chan struct{})
+	)
+	l.SetMaxHold(key, 50*time.Millisecond, func(key string) {
+		atomic.AddInt32(&expired, 1)
+	})
+	go func() {
+		l.LockFunc(key, func() {
+			close(entered)
+			<-release
+		})
+		close(done)
+	}()
+	<-entered
+	waitExpired(t, &expired, 1)
+	l.RLock(key)
+	close(release)
+	<-done
+	// The RLock is still held after the LockFunc returns.
+	if l.TryLock(key) {
+		t.Fatal("reading lock is released by the late unlocking of LockFunc")
+	}
+	l.RUnlock(key)
+	if !l.TryLock(key) {
+		t.Fatal("lock is not released")
+	}
+	l.Unlock(key)
+}
+
+func Test_Locker_Snapshot_SkipsExpired(t *testing.T) {
+	var (
+		l       = New()
+		key     = "key"
+		expired int32
+	)
+	l.SetDiagnostics(true)
+	l.SetMaxHold(key, 50*time.Millisecond, func(key string) {
+		atomic.AddInt32(&expired, 1)
+	})
+	token := l.LockWithToken(key)
+	if infos := l.Snapshot(); len(infos) != 1 || infos[0].Key != key || !infos[0].Write {
+		t.Fatalf("unexpected snapshot: %+v", infos)
+	}
+	waitExpired(t, &expired, 1)
+	if infos := l.Snapshot(); len(infos) != 0 {
+		t.Fatalf("auto-released lock in snapshot: %+v", infos)
+	}
+	l.UnlockWithToken(key, token)
+}
@@ -9,7 +9,8 @@ import (
 // Note that there's no cache expire mechanism for mutex in locker.
 // You need remove certain mutex manually when you do not want use it any more.
 type Locker struct {
-	m *gmap.StrAnyMap
+	m       *gmap.StrAnyMap
+	tracker lockTracker // Tracker for max hold duration and diagnostics.
 }
 
 // New creates and returns a new memory locker.
@@ -25,17 +26,41 @@ func New() *Locker {
 // it will blocks until the lock is released.
 func (l *Locker) Lock(key string) {
 	l.getOrNewMutex(key).Lock()
+	l.onLocked(key, true)
 }
 
 // TryLock tries locking the <key> with writing lock,
 // it returns true if success, or it returns false if there's a writing/reading lock the <key>.
 func (l *Locker) TryLock(key string) bool {
-	return l.getOrNewMutex(key).TryLock()
+	if l.getOrNewMutex(key).TryLock() {
+		l.onLocked(key, true)
+		return true
+	}
+	return false
 }
 
 // Unlock unlocks the writing lock of the <key>.
 func (l *Locker) Unlock(key string) {
+	l.UnlockWithToken(key, 0)
+}
+
+// LockWithToken locks the <key> with writing lock like Lock, and returns the token
+// identifying this acquisition for UnlockWithToken.
+// The token is used for ignoring exactly the late unlocking of the lock auto-released
+// by max hold duration, see SetMaxHold.
+func (l *Locker) LockWithToken(key string) (token uint64) {
+	l.getOrNewMutex(key).Lock()
+	return l.onLocked(key, true)
+}
+
+// UnlockWithToken unlocks the writing lock of the <key> acquired with <token>,
+// which is returned by LockWithToken. It does nothing if the lock is already auto-released.
+// The token 0 means no token, which is the same as Unlock.
+func (l *Locker) UnlockWithToken(key string, token uint64) {
 	if v := l.m.Get(key); v != nil {
+		if l.onUnlocking(key, true, token) {
+			return
+		}
 		v.(*gmutex.Mutex).Unlock()
 	}
 }
@@ -45,17 +70,39 @@ func (l *Locker) Unlock(key string) {
 // it will blocks until the writing lock is released.
 func (l *Locker) RLock(key string) {
 	l.getOrNewMutex(key).RLock()
+	l.onLocked(key, false)
 }
 
 // TryRLock tries locking the <key> with reading lock.
 // It returns true if success, or if there's a writing lock on <key>, it returns false.
 func (l *Locker) TryRLock(key string) bool {
-	return l.getOrNewMutex(key).TryRLock()
+	if l.getOrNewMutex(key).TryRLock() {
+		l.onLocked(key, false)
+		return true
+	}
+	return false
 }
 
 // RUnlock unlocks the reading lock of the <key>.
 func (l *Locker) RUnlock(key string) {
+	l.RUnlockWithToken(key, 0)
+}
+
+// RLockWithToken locks the <key> with reading lock like RLock, and returns the token
+// identifying this acquisition for RUnlockWithToken.
+func (l *Locker) RLockWithToken(key string) (token uint64) {
+	l.getOrNewMutex(key).RLock()
+	return l.onLocked(key, false)
+}
+
+// RUnlockWithToken unlocks the reading lock of the <key> acquired with <token>,
+// which is returned by RLockWithToken. It does nothing if the lock is already auto-released.
+// The token 0 means no token, which is the same as RUnlock.
+func (l *Locker) RUnlockWithToken(key string, token uint64) {
 	if v := l.m.Get(key); v != nil {
+		if l.onUnlocking(key, false, token) {
+			return
+		}
 		v.(*gmutex.Mutex).RUnlock()
 	}
 }
@@ -66,8 +113,8 @@ func (l *Locker) RUnlock(key string) {
 //
 // It releases the lock after <f> is executed.
 func (l *Locker) LockFunc(key string, f func()) {
-	l.Lock(key)
-	defer l.Unlock(key)
+	token := l.LockWithToken(key)
+	defer l.UnlockWithToken(key, token)
 	f()
 }
 
@@ -77,8 +124,8 @@ func (l *Locker) LockFunc(key string, f func()) {
 //
 // It releases the lock after <f> is executed.
 func (l *Locker) RLockFunc(key string, f func()) {
-	l.RLock(key)
-	defer l.RUnlock(key)
+	token := l.RLockWithToken(key)
+	defer l.RUnlockWithToken(key, token)
 	f()
 }
 
@@ -87,8 +134,8 @@ func (l *Locker) RLockFunc(key string, f func()) {
 //
 // It releases the lock after <f> is executed.
 func (l *Locker) TryLockFunc(key string, f func()) bool {
-	if l.TryLock(key) {
-		defer l.Unlock(key)
+	if l.getOrNewMutex(key).TryLock() {
+		defer l.UnlockWithToken(key, l.onLocked(key, true))
 		f()
 		return true
 	}
@@ -100,8 +147,8 @@ func (l *Locker) TryLockFunc(key string, f func()) bool {
 //
 // It releases the lock after <f> is executed.
 func (l *Locker) TryRLockFunc(key string, f func()) bool {
-	if l.TryRLock(key) {
-		defer l.RUnlock(key)
+	if l.getOrNewMutex(key).TryRLock() {
+		defer l.RUnlockWithToken(key, l.onLocked(key, false))
 		f()
 		return true
 	}
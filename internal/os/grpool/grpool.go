@@ -2,32 +2,66 @@
 package grpool
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/ilylx/gconv/container/glist"
 	"github.com/ilylx/gconv/container/gtype"
+	"sync"
+	"time"
 )
 
 // Goroutine Pool
 type Pool struct {
-	limit  int         // Max goroutine count limit.
-	count  *gtype.Int  // Current running goroutine count.
-	list   *glist.List // Job list for asynchronous job adding purpose.
-	closed *gtype.Bool // Is pool closed or not.
+	limit          int              // Max goroutine count limit.
+	count          *gtype.Int       // Current running goroutine count.
+	lists          []*glist.List    // Job lists of the priorities for asynchronous job adding purpose, from the lowest priority.
+	closed         *gtype.Bool      // Is pool closed or not.
+	scaleLimit     *gtype.Int       // Max goroutine count limit when the jobs burst, no scaling if it is <= limit.
+	scaleThreshold *gtype.Int       // Job count exceeding which the goroutines grow up to scaleLimit.
+	maxJobs        *gtype.Int       // Max queued job count, no limit if it is <= 0.
+	overflow       *gtype.Int       // Overflow policy if the max queued job count is reached.
+	queueMu        sync.Mutex       // Mutex for queuing jobs if the max queued job count is set.
+	queueNotify    chan struct{}    // Closed and recreated when any job is popped, for blocked queuing.
+	panicHandler   *gtype.Interface // Handler for the panics of jobs, which is type of PanicHandler.
+	stats          *poolStats       // Statistics of the pool.
+	shutdown       *gtype.Bool      // Whether the pool is shutting down, which stops accepting jobs.
 }
 
-// Default goroutine pool.
-var pool = New()
+// poolJob is the job queued in the pool.
+type poolJob struct {
+	f      func()    // Job function.
+	queued time.Time // Time when the job is queued.
+	index  int       // Index of the job list of its priority.
+	future *Future   // Future of the job added by Submit, which is nil for the others.
+}
+
+var (
+	// Default goroutine pool.
+	pool = New()
+
+	// ErrPoolClosed is returned by Add if the pool is closed or shutting down,
+	// and it is also the error of the Futures whose jobs are discarded by closing.
+	ErrPoolClosed = errors.New("pool closed")
+)
 
 // New creates and returns a new goroutine pool object.
 // The parameter <limit> is used to limit the max goroutine count,
 // which is not limited in default.
 func New(limit ...int) *Pool {
 	p := &Pool{
-		limit:  -1,
-		count:  gtype.NewInt(),
-		list:   glist.New(true),
-		closed: gtype.NewBool(),
+		limit:          -1,
+		count:          gtype.NewInt(),
+		lists:          newPriorityLists(),
+		closed:         gtype.NewBool(),
+		scaleLimit:     gtype.NewInt(),
+		scaleThreshold: gtype.NewInt(),
+		maxJobs:        gtype.NewInt(),
+		overflow:       gtype.NewInt(OVERFLOW_BLOCK),
+		queueNotify:    make(chan struct{}),
+		panicHandler:   gtype.NewInterface(PanicHandler(defaultPanicHandler)),
+		stats:          newPoolStats(),
+		shutdown:       gtype.NewBool(),
 	}
 	if len(limit) > 0 && limit[0] > 0 {
 		p.limit = limit[0]
@@ -61,16 +95,47 @@ func Jobs() int {
 
 // Add pushes a new job to the pool.
 // The job will be executed asynchronously.
+//
+// If the max job count of the pool is set and reached, it blocks, returns ErrQueueFull or
+// drops the oldest job according to the overflow policy. See SetMaxJobs.
 func (p *Pool) Add(f func()) error {
-	for p.closed.Val() {
-		return errors.New("pool closed")
+	return p.AddCtx(context.Background(), f)
+}
+
+// AddCtx pushes a new job to the pool, just like Add.
+// It returns the error of <ctx> if <ctx> is done before the job is pushed, which only happens
+// if it blocks on the max job count of the pool using the OVERFLOW_BLOCK policy.
+func (p *Pool) AddCtx(ctx context.Context, f func()) error {
+	return p.add(ctx, newPoolJob(PRIORITY_NORMAL, f))
+}
+
+// newPoolJob creates and returns a new job <f> of <priority>.
+func newPoolJob(priority int, f func()) *poolJob {
+	return &poolJob{
+		f:      f,
+		queued: time.Now(),
+		index:  getPriorityIndex(priority),
+	}
+}
+
+// add pushes <job> to the pool, and forks new goroutine if necessary.
+func (p *Pool) add(ctx context.Context, job *poolJob) error {
+	for p.closed.Val() || p.shutdown.Val() {
+		return ErrPoolClosed
+	}
+	if err := p.push(ctx, job); err != nil {
+		return err
+	}
+	// The pool might be closed during pushing, in which case the job is never executed.
+	if p.closed.Val() {
+		p.discardJobs(ErrPoolClosed)
+		return nil
 	}
-	p.list.PushFront(f)
 	// Check whether fork new goroutine or not.
 	var n int
 	for {
 		n = p.count.Val()
-		if p.limit != -1 && n >= p.limit {
+		if p.limit != -1 && n >= p.limit && !p.canScaleUp(n) {
 			// No need fork new goroutine.
 			return nil
 		}
@@ -115,20 +180,36 @@ func (p *Pool) Size() int {
 // Jobs returns current job count of the pool.
 // Note that, it does not return worker/goroutine count but the job/task count.
 func (p *Pool) Jobs() int {
-	return p.list.Size()
+	return p.jobCount()
 }
 
 // fork creates a new goroutine worker.
-// Note that the worker dies if the job function panics.
+// The panics of the jobs are recovered and handled by the panic handler of the pool,
+// and the worker continues executing the next jobs.
 func (p *Pool) fork() {
 	go func() {
-		defer p.count.Add(-1)
-
-		var job interface{}
+		var (
+			job        interface{}
+			scaledDown = false
+		)
+		defer func() {
+			if !scaledDown {
+				p.count.Add(-1)
+			}
+		}()
 		for !p.closed.Val() {
-			if job = p.list.PopBack(); job != nil {
-				job.(func())()
+			// The extra goroutine exits if the jobs are no longer bursting.
+			if scaledDown = p.tryScaleDown(); scaledDown {
+				return
+			}
+			// The goroutine is marked active before popping the job,
+			// so the popped job is always counted by either the queue or the active goroutines.
+			p.stats.active.Add(1)
+			if job = p.popJob(); job != nil {
+				p.notifyQueue()
+				p.runJob(job.(*poolJob))
 			} else {
+				p.stats.active.Add(-1)
 				return
 			}
 		}
@@ -141,6 +222,31 @@ func (p *Pool) IsClosed() bool {
 }
 
 // Close closes the goroutine pool, which makes all goroutines exit.
+// The queued jobs are discarded without executing, and their Futures are done with
+// ErrPoolClosed. Use Shutdown for graceful closing.
 func (p *Pool) Close() {
 	p.closed.Set(true)
+	p.discardJobs(ErrPoolClosed)
+	// Waking up the blocked queuing.
+	p.queueMu.Lock()
+	p.broadcastQueue()
+	p.queueMu.Unlock()
+}
+
+// discardJobs removes all the queued jobs without executing, and the Futures of the
+// removed jobs are done with <err>. It returns the count of the removed jobs.
+func (p *Pool) discardJobs(err error) int {
+	count := 0
+	for job := p.popJob(); job != nil; job = p.popJob() {
+		job.(*poolJob).discard(err)
+		count++
+	}
+	return count
+}
+
+// discard completes the Future of the job with <err> if the job is discarded without executing.
+func (job *poolJob) discard(err error) {
+	if job.future != nil {
+		job.future.complete(nil, err)
+	}
 }
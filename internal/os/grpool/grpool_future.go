@@ -0,0 +1,107 @@
+package grpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Future is the result of the job submitted by Submit, which is available after the job is done.
+type Future struct {
+	once  sync.Once     // Ensures the Future is completed only once.
+	done  chan struct{} // Closed when the job is done.
+	value interface{}   // Result value of the job.
+	err   error         // Result error of the job.
+}
+
+var (
+	// ErrFutureTimeout is returned by Future.WaitTimeout if the job is not done in time.
+	ErrFutureTimeout = errors.New("future wait timeout")
+)
+
+// Submit pushes a new job <f> to the default goroutine pool, and returns the Future
+// for retrieving the result of <f>. The job will be executed asynchronously.
+func Submit(f func() (interface{}, error)) *Future {
+	return pool.Submit(f)
+}
+
+// Submit pushes a new job <f> to the pool, and returns the Future for retrieving the result of <f>.
+// The job will be executed asynchronously.
+//
+// The panic of <f> is recovered and returned as the error of the Future. The returned Future
+// is done with error immediately if the job cannot be added, eg: the pool is closed, and it is
+// done with ErrPoolClosed if the pool is closed before the job is executed, so Wait always returns.
+//
+// Eg:
+//
+//	future := pool.Submit(func() (interface{}, error) {
+//	    return http.Get(url)
+//	})
+//	result, err := future.WaitTimeout(time.Second)
+func (p *Pool) Submit(f func() (interface{}, error)) *Future {
+	future := &Future{
+		done: make(chan struct{}),
+	}
+	job := newPoolJob(PRIORITY_NORMAL, func() {
+		var (
+			value interface{}
+			err   error
+		)
+		defer func() {
+			if exception := recover(); exception != nil {
+				err = errors.New(fmt.Sprintf(`%v`, exception))
+			}
+			future.complete(value, err)
+		}()
+		value, err = f()
+	})
+	job.future = future
+	if err := p.add(context.Background(), job); err != nil {
+		future.complete(nil, err)
+	}
+	return future
+}
+
+// complete sets the result of the Future and marks it done, which only takes effect once.
+func (f *Future) complete(value interface{}, err error) {
+	f.once.Do(func() {
+		f.value, f.err = value, err
+		close(f.done)
+	})
+}
+
+// Done returns a channel that is closed when the job is done.
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
+
+// IsDone checks and returns whether the job is done.
+func (f *Future) IsDone() bool {
+	select {
+	case <-f.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Wait blocks until the job is done, and returns the result of the job.
+func (f *Future) Wait() (interface{}, error) {
+	<-f.done
+	return f.value, f.err
+}
+
+// WaitTimeout blocks until the job is done or <timeout> elapses, and returns the result of the job.
+// It returns ErrFutureTimeout if the job is not done in <timeout>.
+func (f *Future) WaitTimeout(timeout time.Duration) (interface{}, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-timer.C:
+		return nil, ErrFutureTimeout
+	}
+}
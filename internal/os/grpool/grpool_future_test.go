@@ -0,0 +1,99 @@
+package grpool
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Future(t *testing.T) {
+	p := New(2)
+	defer p.Close()
+	errJob := errors.New("job error")
+	tests := []struct {
+		name    string
+		f       func() (interface{}, error)
+		value   interface{}
+		errText string
+	}{
+		{"value", func() (interface{}, error) { return 1, nil }, 1, ""},
+		{"error", func() (interface{}, error) { return nil, errJob }, nil, "job error"},
+		{"panic", func() (interface{}, error) { panic("job panics") }, nil, "job panics"},
+	}
+	for _, test := range tests {
+		value, err := p.Submit(test.f).WaitTimeout(time.Second)
+		if test.errText != "" {
+			if err == nil || !strings.Contains(err.Error(), test.errText) {
+				t.Errorf("%s: expected error containing %q, got %v", test.name, test.errText, err)
+			}
+			continue
+		}
+		if err != nil || value != test.value {
+			t.Errorf("%s: expected %v, got %v, %v", test.name, test.value, value, err)
+		}
+	}
+}
+
+func Test_Future_Wait(t *testing.T) {
+	var (
+		p     = New(1)
+		block = make(chan struct{})
+	)
+	defer p.Close()
+	future := p.Submit(func() (interface{}, error) {
+		<-block
+		return "done", nil
+	})
+	if future.IsDone() {
+		t.Error("expected future not done")
+	}
+	if _, err := future.WaitTimeout(20 * time.Millisecond); err != ErrFutureTimeout {
+		t.Errorf("expected ErrFutureTimeout, got %v", err)
+	}
+	close(block)
+	if value, err := future.Wait(); err != nil || value != "done" {
+		t.Errorf("expected done, got %v, %v", value, err)
+	}
+	if !future.IsDone() {
+		t.Error("expected future done")
+	}
+	select {
+	case <-future.Done():
+	default:
+		t.Error("expected done channel closed")
+	}
+}
+
+func Test_Future_ClosedPool(t *testing.T) {
+	p := New(1)
+	p.Close()
+	future := p.Submit(func() (interface{}, error) {
+		return 1, nil
+	})
+	if !future.IsDone() {
+		t.Fatal("expected future done immediately for closed pool")
+	}
+	if _, err := future.Wait(); err != ErrPoolClosed {
+		t.Errorf("expected ErrPoolClosed, got %v", err)
+	}
+}
+
+func Test_Future_PoolClosedBeforeExecuting(t *testing.T) {
+	p := New(1)
+	release := blockWorker(t, p)
+	defer release()
+	futures := []*Future{
+		p.Submit(func() (interface{}, error) { return 1, nil }),
+		p.Submit(func() (interface{}, error) { return 2, nil }),
+	}
+	p.Close()
+	for i, future := range futures {
+		if _, err := future.WaitTimeout(time.Second); err != ErrPoolClosed {
+			t.Errorf("future %d: expected ErrPoolClosed, got %v", i, err)
+		}
+	}
+	if n := p.Jobs(); n != 0 {
+		t.Errorf("expected no queued job, got %d", n)
+	}
+}
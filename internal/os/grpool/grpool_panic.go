@@ -0,0 +1,56 @@
+package grpool
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"time"
+)
+
+// PanicHandler is the handler for the panic of job, in which <recovered> is the recovered
+// value of the panic and <stack> is the stack of the panicking goroutine.
+type PanicHandler = func(recovered interface{}, stack string)
+
+// SetPanicHandler sets the handler for the panics of the jobs in the default goroutine pool.
+// See Pool.SetPanicHandler.
+func SetPanicHandler(handler PanicHandler) {
+	pool.SetPanicHandler(handler)
+}
+
+// SetPanicHandler sets the handler for the panics of the jobs in the pool. The panicking job
+// does not kill its worker goroutine, which continues executing the next jobs after <handler>
+// is called.
+//
+// The default handler always prints the panic and its stack to stderr, so the panics are not
+// silently swallowed. It resets to the default handler if <handler> is nil.
+func (p *Pool) SetPanicHandler(handler PanicHandler) {
+	if handler == nil {
+		handler = defaultPanicHandler
+	}
+	p.panicHandler.Set(handler)
+}
+
+// runJob executes <job> and handles its panic using the panic handler.
+// It also records the statistics of the job, and decreases the active goroutine count
+// which is increased before the job is popped.
+func (p *Pool) runJob(job *poolJob) {
+	start := time.Now()
+	p.stats.wait.Record(start.Sub(job.queued))
+	defer func() {
+		p.stats.exec.Record(time.Since(start))
+		p.stats.completed.Add(1)
+		if exception := recover(); exception != nil {
+			p.stats.panics.Add(1)
+			p.panicHandler.Val().(PanicHandler)(exception, string(debug.Stack()))
+		}
+		p.stats.active.Add(-1)
+		p.notifyShutdown()
+	}()
+	job.f()
+}
+
+// defaultPanicHandler prints the panic and its stack to stderr.
+// Note that it cannot use glog, as glog depends on this package.
+func defaultPanicHandler(recovered interface{}, stack string) {
+	fmt.Fprintf(os.Stderr, "grpool job panics: %v\n%s\n", recovered, stack)
+}
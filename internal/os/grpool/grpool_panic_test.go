@@ -0,0 +1,65 @@
+package grpool
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func Test_Pool_PanicHandler(t *testing.T) {
+	var (
+		p         = New(1)
+		recovered = make(chan interface{}, 1)
+		done      = make(chan struct{})
+	)
+	defer p.Close()
+	p.SetPanicHandler(func(r interface{}, stack string) {
+		if !strings.Contains(stack, "Test_Pool_PanicHandler") {
+			t.Errorf("stack of the panicking job is missing: %s", stack)
+		}
+		recovered <- r
+	})
+	p.Add(func() {
+		panic("job panics")
+	})
+	// The worker continues executing the next job after the panic.
+	p.Add(func() {
+		close(done)
+	})
+	<-done
+	if r := <-recovered; r != "job panics" {
+		t.Fatalf("unexpected recovered value: %v", r)
+	}
+	if n := p.Stats().Panics; n != 1 {
+		t.Fatalf("unexpected panic count: %d", n)
+	}
+}
+
+func Test_Pool_DefaultPanicHandler(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = writer
+	var (
+		p    = New(1)
+		done = make(chan struct{})
+	)
+	p.Add(func() {
+		panic("job panics")
+	})
+	p.Add(func() {
+		close(done)
+	})
+	<-done
+	p.Close()
+	os.Stderr = stderr
+	writer.Close()
+	output, _ := io.ReadAll(reader)
+	// The panic is always reported, whether the debug mode is enabled or not.
+	if !strings.Contains(string(output), "grpool job panics: job panics") {
+		t.Fatalf("panic is not reported to stderr, output: %s", output)
+	}
+}
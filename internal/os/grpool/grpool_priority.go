@@ -0,0 +1,88 @@
+package grpool
+
+import (
+	"context"
+	"github.com/ilylx/gconv/container/glist"
+)
+
+// Priorities of the jobs. The queued jobs of higher priority are executed before
+// the ones of lower priority, and the jobs of the same priority are executed in order.
+const (
+	PRIORITY_LOW    = -1 // For bulk background jobs.
+	PRIORITY_NORMAL = 0  // Default priority of the jobs added by Add.
+	PRIORITY_HIGH   = 1  // For latency-critical jobs.
+)
+
+// AddWithPriority pushes a new job of <priority> to the default goroutine pool.
+// See Pool.AddWithPriority.
+func AddWithPriority(priority int, f func()) error {
+	return pool.AddWithPriority(priority, f)
+}
+
+// AddWithPriority pushes a new job of <priority> to the pool, which jumps ahead of the queued
+// jobs of lower priority. The <priority> is one of PRIORITY_LOW, PRIORITY_NORMAL and
+// PRIORITY_HIGH, and the value beyond the range is treated as the nearest one.
+// The job will be executed asynchronously.
+//
+// Eg:
+//
+//	pool.AddWithPriority(grpool.PRIORITY_HIGH, flushOnShutdown)
+func (p *Pool) AddWithPriority(priority int, f func()) error {
+	return p.add(context.Background(), newPoolJob(priority, f))
+}
+
+// newPriorityLists creates and returns the job lists of all priorities.
+func newPriorityLists() []*glist.List {
+	lists := make([]*glist.List, PRIORITY_HIGH-PRIORITY_LOW+1)
+	for i := range lists {
+		lists[i] = glist.New(true)
+	}
+	return lists
+}
+
+// getPriorityIndex returns the index of the job list of <priority>.
+func getPriorityIndex(priority int) int {
+	if priority < PRIORITY_LOW {
+		priority = PRIORITY_LOW
+	}
+	if priority > PRIORITY_HIGH {
+		priority = PRIORITY_HIGH
+	}
+	return priority - PRIORITY_LOW
+}
+
+// jobCount returns the count of queued jobs of all priorities.
+func (p *Pool) jobCount() int {
+	count := 0
+	for _, list := range p.lists {
+		count += list.Size()
+	}
+	return count
+}
+
+// pushJob pushes <job> to the job list of its priority.
+func (p *Pool) pushJob(job *poolJob) {
+	p.lists[job.index].PushFront(job)
+}
+
+// popJob pops and returns the oldest job of the highest priority.
+// It returns nil if there's no queued job.
+func (p *Pool) popJob() interface{} {
+	for i := len(p.lists) - 1; i >= 0; i-- {
+		if job := p.lists[i].PopBack(); job != nil {
+			return job
+		}
+	}
+	return nil
+}
+
+// dropOldestJob drops and returns the oldest job of the lowest priority.
+// It returns nil if there's no queued job.
+func (p *Pool) dropOldestJob() *poolJob {
+	for _, list := range p.lists {
+		if job := list.PopBack(); job != nil {
+			return job.(*poolJob)
+		}
+	}
+	return nil
+}
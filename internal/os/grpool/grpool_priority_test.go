@@ -0,0 +1,79 @@
+package grpool
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func Test_Pool_Priority(t *testing.T) {
+	var (
+		p        = New(1)
+		mu       sync.Mutex
+		executed []string
+		wg       sync.WaitGroup
+	)
+	defer p.Close()
+	release := blockWorker(t, p)
+	jobs := []struct {
+		name     string
+		priority int
+	}{
+		{"low1", PRIORITY_LOW},
+		{"normal1", PRIORITY_NORMAL},
+		{"high1", PRIORITY_HIGH},
+		{"low2", -100},
+		{"normal2", PRIORITY_NORMAL},
+		{"high2", 100},
+	}
+	for _, job := range jobs {
+		name := job.name
+		wg.Add(1)
+		if err := p.AddWithPriority(job.priority, func() {
+			defer wg.Done()
+			mu.Lock()
+			executed = append(executed, name)
+			mu.Unlock()
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	release()
+	wg.Wait()
+	expect := []string{"high1", "high2", "normal1", "normal2", "low1", "low2"}
+	if !reflect.DeepEqual(executed, expect) {
+		t.Errorf("expected order %v, got %v", expect, executed)
+	}
+}
+
+func Test_Pool_Priority_DropOldest(t *testing.T) {
+	var (
+		p        = New(1)
+		mu       sync.Mutex
+		executed []string
+		wg       sync.WaitGroup
+	)
+	defer p.Close()
+	release := blockWorker(t, p)
+	p.SetMaxJobs(2, OVERFLOW_DROP_OLDEST)
+	add := func(name string, priority int) {
+		if err := p.AddWithPriority(priority, func() {
+			defer wg.Done()
+			mu.Lock()
+			executed = append(executed, name)
+			mu.Unlock()
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wg.Add(2)
+	add("high", PRIORITY_HIGH)
+	add("low", PRIORITY_LOW)
+	// The oldest job of the lowest priority is dropped, not the oldest job.
+	add("normal", PRIORITY_NORMAL)
+	release()
+	wg.Wait()
+	if expect := []string{"high", "normal"}; !reflect.DeepEqual(executed, expect) {
+		t.Errorf("expected executed jobs %v, got %v", expect, executed)
+	}
+}
@@ -0,0 +1,111 @@
+package grpool
+
+import (
+	"context"
+	"errors"
+)
+
+// Overflow policies if the max queued job count of the pool is reached.
+const (
+	OVERFLOW_BLOCK       = iota // Blocking until any queued job is popped or the context is done.
+	OVERFLOW_ERROR              // Returning ErrQueueFull.
+	OVERFLOW_DROP_OLDEST        // Dropping the oldest queued job of the lowest priority.
+)
+
+var (
+	// ErrQueueFull is returned by Add if the max queued job count of the pool is reached
+	// using the OVERFLOW_ERROR policy.
+	ErrQueueFull = errors.New("pool queue is full")
+
+	// ErrJobDropped is the error of the Future whose job is dropped by the OVERFLOW_DROP_OLDEST policy.
+	ErrJobDropped = errors.New("pool job is dropped")
+)
+
+// SetMaxJobs sets the max queued job count of the pool, and the overflow policy <overflow>
+// if the max count is reached, which is OVERFLOW_BLOCK in default. There's no limit if
+// <maxJobs> <= 0, which is the default.
+//
+// It protects the memory from being exhausted when the producers outpace the workers.
+// The Future of the job dropped by OVERFLOW_DROP_OLDEST is done with ErrJobDropped.
+//
+// Eg:
+//
+//	pool := grpool.New(1)
+//	pool.SetMaxJobs(10000, grpool.OVERFLOW_DROP_OLDEST)
+func (p *Pool) SetMaxJobs(maxJobs int, overflow ...int) {
+	if len(overflow) > 0 {
+		p.overflow.Set(overflow[0])
+	}
+	p.maxJobs.Set(maxJobs)
+	// Waking up the blocked queuing as the max count might be increased.
+	p.queueMu.Lock()
+	p.broadcastQueue()
+	p.queueMu.Unlock()
+}
+
+// MaxJobs returns the max queued job count of the pool, which is 0 if there's no limit.
+func (p *Pool) MaxJobs() int {
+	if n := p.maxJobs.Val(); n > 0 {
+		return n
+	}
+	return 0
+}
+
+// push pushes <job> to the queue according to the max queued job count and overflow policy.
+func (p *Pool) push(ctx context.Context, job *poolJob) error {
+	if p.maxJobs.Val() <= 0 {
+		p.pushJob(job)
+		return nil
+	}
+	for {
+		// The count checking and pushing should be atomic among the producers.
+		p.queueMu.Lock()
+		maxJobs := p.maxJobs.Val()
+		if maxJobs <= 0 || p.jobCount() < maxJobs {
+			p.pushJob(job)
+			p.queueMu.Unlock()
+			return nil
+		}
+		switch p.overflow.Val() {
+		case OVERFLOW_ERROR:
+			p.queueMu.Unlock()
+			return ErrQueueFull
+
+		case OVERFLOW_DROP_OLDEST:
+			if job := p.dropOldestJob(); job != nil {
+				job.discard(ErrJobDropped)
+				p.stats.dropped.Add(1)
+			}
+			p.pushJob(job)
+			p.queueMu.Unlock()
+			return nil
+		}
+		notify := p.queueNotify
+		p.queueMu.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-notify:
+			if p.closed.Val() || p.shutdown.Val() {
+				return ErrPoolClosed
+			}
+		}
+	}
+}
+
+// notifyQueue wakes up the blocked queuing after any job is popped.
+func (p *Pool) notifyQueue() {
+	if p.maxJobs.Val() <= 0 {
+		return
+	}
+	p.queueMu.Lock()
+	p.broadcastQueue()
+	p.queueMu.Unlock()
+}
+
+// broadcastQueue wakes up all the blocked queuing.
+// It should be called within queueMu lock.
+func (p *Pool) broadcastQueue() {
+	close(p.queueNotify)
+	p.queueNotify = make(chan struct{})
+}
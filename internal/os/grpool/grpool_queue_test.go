@@ -0,0 +1,132 @@
+package grpool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func Test_Pool_Overflow_Error(t *testing.T) {
+	p := New(1)
+	defer p.Close()
+	release := blockWorker(t, p)
+	defer release()
+	p.SetMaxJobs(2, OVERFLOW_ERROR)
+	if n := p.MaxJobs(); n != 2 {
+		t.Errorf("expected max jobs 2, got %d", n)
+	}
+	for i := 0; i < 2; i++ {
+		if err := p.Add(func() {}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := p.Add(func() {}); err != ErrQueueFull {
+		t.Errorf("expected ErrQueueFull, got %v", err)
+	}
+	if n := p.Jobs(); n != 2 {
+		t.Errorf("expected 2 queued jobs, got %d", n)
+	}
+}
+
+func Test_Pool_Overflow_DropOldest(t *testing.T) {
+	p := New(1)
+	defer p.Close()
+	release := blockWorker(t, p)
+	p.SetMaxJobs(2, OVERFLOW_DROP_OLDEST)
+	futures := make([]*Future, 4)
+	for i := range futures {
+		i := i
+		futures[i] = p.Submit(func() (interface{}, error) {
+			return i, nil
+		})
+	}
+	release()
+	// The two oldest jobs are dropped, and their Futures are done with ErrJobDropped.
+	for i, future := range futures {
+		value, err := future.WaitTimeout(time.Second)
+		if i < 2 {
+			if err != ErrJobDropped {
+				t.Errorf("future %d: expected ErrJobDropped, got %v, %v", i, value, err)
+			}
+			continue
+		}
+		if err != nil || value != i {
+			t.Errorf("future %d: expected %d, got %v, %v", i, i, value, err)
+		}
+	}
+	if n := p.Stats().Dropped; n != 2 {
+		t.Errorf("expected 2 dropped jobs, got %d", n)
+	}
+}
+
+func Test_Pool_Overflow_Block(t *testing.T) {
+	var (
+		p      = New(1)
+		result = make(chan error, 1)
+	)
+	defer p.Close()
+	release := blockWorker(t, p)
+	p.SetMaxJobs(1)
+	if err := p.Add(func() {}); err != nil {
+		t.Fatal(err)
+	}
+	// The context is done before the queue has room.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := p.AddCtx(ctx, func() {}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	go func() {
+		result <- p.Add(func() {})
+	}()
+	select {
+	case err := <-result:
+		t.Fatalf("expected add blocked on the full queue, got %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	// The blocked add returns after the queued job is popped.
+	release()
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(time.Second):
+		t.Error("expected blocked add returned after the queue has room")
+	}
+}
+
+func Test_Pool_MaxJobs_Raised(t *testing.T) {
+	var (
+		p      = New(1)
+		result = make(chan error, 1)
+	)
+	defer p.Close()
+	release := blockWorker(t, p)
+	defer release()
+	p.SetMaxJobs(1)
+	p.Add(func() {})
+	go func() {
+		result <- p.Add(func() {})
+	}()
+	select {
+	case err := <-result:
+		t.Fatalf("expected add blocked on the full queue, got %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	// Raising the max count wakes up the blocked add.
+	p.SetMaxJobs(2)
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(time.Second):
+		t.Error("expected blocked add returned after raising the max jobs")
+	}
+	p.SetMaxJobs(-1)
+	if n := p.MaxJobs(); n != 0 {
+		t.Errorf("expected no limit, got %d", n)
+	}
+}
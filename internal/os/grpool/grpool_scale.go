@@ -0,0 +1,59 @@
+package grpool
+
+// SetScaling enables the dynamic goroutine scaling of the pool, which allows the goroutine
+// count growing beyond the limit of the pool up to <scaleLimit> when the count of queued jobs
+// exceeds <threshold>. The extra goroutines exit after the queued jobs drop back to <threshold>,
+// so the pool shrinks back to its limit when it is idle.
+//
+// It is useful for bursty workloads that should not queue behind an undersized pool.
+// It disables the scaling if <scaleLimit> is not greater than the limit of the pool.
+// Note that it has no effect on the pool without limit.
+//
+// Eg:
+//
+//	pool := grpool.New(10)
+//	// Growing up to 100 goroutines when more than 1000 jobs are queued.
+//	pool.SetScaling(100, 1000)
+func (p *Pool) SetScaling(scaleLimit int, threshold int) {
+	if threshold < 0 {
+		threshold = 0
+	}
+	p.scaleThreshold.Set(threshold)
+	p.scaleLimit.Set(scaleLimit)
+}
+
+// ScaleLimit returns the max goroutine count limit of the pool when the jobs burst.
+// It returns the limit of the pool if the scaling is not enabled.
+func (p *Pool) ScaleLimit() int {
+	if scaleLimit := p.scaleLimit.Val(); p.limit != -1 && scaleLimit > p.limit {
+		return scaleLimit
+	}
+	return p.limit
+}
+
+// canScaleUp checks whether the pool can fork an extra goroutine beyond its limit,
+// in which <n> is the current goroutine count.
+func (p *Pool) canScaleUp(n int) bool {
+	scaleLimit := p.scaleLimit.Val()
+	if p.limit == -1 || scaleLimit <= p.limit || n >= scaleLimit {
+		return false
+	}
+	return p.jobCount() > p.scaleThreshold.Val()
+}
+
+// tryScaleDown decreases the goroutine count if current goroutine is extra and the jobs
+// are no longer bursting. It returns true if current goroutine should exit.
+func (p *Pool) tryScaleDown() bool {
+	if p.limit == -1 || p.scaleLimit.Val() <= p.limit {
+		return false
+	}
+	for {
+		n := p.count.Val()
+		if n <= p.limit || p.jobCount() > p.scaleThreshold.Val() {
+			return false
+		}
+		if p.count.Cas(n, n-1) {
+			return true
+		}
+	}
+}
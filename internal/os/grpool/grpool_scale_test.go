@@ -0,0 +1,100 @@
+package grpool
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_Pool_Scale(t *testing.T) {
+	var (
+		p       = New(1)
+		started = make(chan struct{}, 10)
+		block   = make(chan struct{})
+		wg      sync.WaitGroup
+	)
+	defer p.Close()
+	if n := p.ScaleLimit(); n != 1 {
+		t.Errorf("expected scale limit 1 without scaling, got %d", n)
+	}
+	p.SetScaling(3, 2)
+	if n := p.ScaleLimit(); n != 3 {
+		t.Errorf("expected scale limit 3, got %d", n)
+	}
+	job := func() {
+		defer wg.Done()
+		started <- struct{}{}
+		<-block
+	}
+	wg.Add(1)
+	p.Add(job)
+	<-started
+	// The pool grows beyond its limit only if the queued jobs exceed the threshold.
+	wg.Add(2)
+	p.Add(job)
+	p.Add(job)
+	if n := p.Size(); n != 1 {
+		t.Errorf("expected 1 worker under threshold, got %d", n)
+	}
+	wg.Add(4)
+	for i := 0; i < 4; i++ {
+		p.Add(job)
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-started:
+		case <-time.After(time.Second):
+			t.Fatal("expected extra workers started")
+		}
+	}
+	// It never grows beyond the scale limit.
+	select {
+	case <-started:
+		t.Error("expected no more workers beyond the scale limit")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if n := p.Size(); n != 3 {
+		t.Errorf("expected 3 workers, got %d", n)
+	}
+	close(block)
+	wg.Wait()
+	deadline := time.Now().Add(time.Second)
+	for p.Size() > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := p.Size(); n != 0 {
+		t.Errorf("expected workers exited when idle, got %d", n)
+	}
+}
+
+func Test_Pool_ScaleDown(t *testing.T) {
+	p := New(1)
+	defer p.Close()
+	p.SetScaling(3, 2)
+	p.count.Set(3)
+	for i := 0; i < 3; i++ {
+		p.pushJob(&poolJob{f: func() {}})
+	}
+	// The extra goroutines keep working while the jobs are bursting.
+	if p.tryScaleDown() {
+		t.Error("expected no scaling down while the jobs exceed the threshold")
+	}
+	p.popJob()
+	if !p.tryScaleDown() || !p.tryScaleDown() {
+		t.Error("expected extra goroutines scaling down")
+	}
+	// The goroutines within the limit never scale down.
+	if p.tryScaleDown() {
+		t.Error("expected no scaling down within the limit")
+	}
+	if n := p.Size(); n != 1 {
+		t.Errorf("expected 1 worker, got %d", n)
+	}
+	// Scaling is disabled if the scale limit is not greater than the limit.
+	p.SetScaling(1, 0)
+	p.count.Set(2)
+	if p.tryScaleDown() || p.canScaleUp(1) {
+		t.Error("expected scaling disabled")
+	}
+	p.count.Set(0)
+}
@@ -0,0 +1,61 @@
+package grpool
+
+import (
+	"context"
+)
+
+// Shutdown gracefully closes the pool. It stops accepting new jobs immediately, and then
+// executes the queued jobs if <drain> is true, or else it discards the queued jobs. It waits
+// for the executing jobs and the draining jobs done, or <ctx> done.
+//
+// It returns the count of abandoned jobs, which are the discarded jobs, and the queued and
+// executing jobs not done before <ctx> is done. It returns the error of <ctx> if <ctx> is done
+// before all jobs done. The Futures of the discarded and the abandoned queued jobs are done with
+// ErrPoolClosed. The pool is closed after Shutdown returns.
+//
+// Note that it is a standalone method instead of Close with context, as changing the signature
+// of Close breaks its callers, like the ones using Close as func() value.
+//
+// Eg:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//	abandoned, err := pool.Shutdown(ctx, true)
+func (p *Pool) Shutdown(ctx context.Context, drain bool) (abandoned int, err error) {
+	p.shutdown.Set(true)
+	// Waking up the blocked queuing, which returns error as the pool is shutting down.
+	p.queueMu.Lock()
+	p.broadcastQueue()
+	p.queueMu.Unlock()
+	defer p.Close()
+	if !drain {
+		abandoned = p.discardJobs(ErrPoolClosed)
+	}
+	for {
+		p.queueMu.Lock()
+		notify := p.queueNotify
+		p.queueMu.Unlock()
+		// The notification channel is retrieved before checking,
+		// so the job done after checking is not missed.
+		jobs, active := p.jobCount(), p.stats.active.Val()
+		if jobs == 0 && active == 0 {
+			return abandoned, nil
+		}
+		select {
+		case <-ctx.Done():
+			p.closed.Set(true)
+			return abandoned + p.jobCount() + p.stats.active.Val(), ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+// notifyShutdown wakes up the waiting Shutdown after any job is done.
+func (p *Pool) notifyShutdown() {
+	if !p.shutdown.Val() {
+		return
+	}
+	p.queueMu.Lock()
+	p.broadcastQueue()
+	p.queueMu.Unlock()
+}
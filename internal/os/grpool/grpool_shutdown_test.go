@@ -0,0 +1,228 @@
+package grpool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockWorker adds a job blocking the worker of <p> until the returned function is called.
+// It returns after the job is started.
+func blockWorker(t *testing.T, p *Pool) (release func()) {
+	var (
+		started = make(chan struct{})
+		block   = make(chan struct{})
+	)
+	if err := p.Add(func() {
+		close(started)
+		<-block
+	}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("blocking job is not started")
+	}
+	return func() { close(block) }
+}
+
+// waitShuttingDown waits until the Shutdown of <p> stops accepting jobs.
+func waitShuttingDown(t *testing.T, p *Pool) {
+	deadline := time.Now().Add(time.Second)
+	for !p.shutdown.Val() {
+		if time.Now().After(deadline) {
+			t.Fatal("pool is not shutting down")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func Test_Pool_Shutdown_Drain(t *testing.T) {
+	var (
+		p        = New(1)
+		executed int32
+		result   = make(chan int, 1)
+	)
+	release := blockWorker(t, p)
+	for i := 0; i < 3; i++ {
+		p.Add(func() { atomic.AddInt32(&executed, 1) })
+	}
+	go func() {
+		abandoned, err := p.Shutdown(context.Background(), true)
+		if err != nil {
+			t.Error(err)
+		}
+		result <- abandoned
+	}()
+	waitShuttingDown(t, p)
+	if err := p.Add(func() {}); err == nil {
+		t.Error("expected error adding job to the shutting down pool")
+	}
+	release()
+	if abandoned := <-result; abandoned != 0 {
+		t.Errorf("expected no abandoned job, got %d", abandoned)
+	}
+	if n := atomic.LoadInt32(&executed); n != 3 {
+		t.Errorf("expected 3 drained jobs, got %d", n)
+	}
+	if !p.IsClosed() {
+		t.Error("expected pool closed after shutdown")
+	}
+}
+
+func Test_Pool_Shutdown_Discard(t *testing.T) {
+	var (
+		p        = New(1)
+		executed int32
+		result   = make(chan int, 1)
+	)
+	release := blockWorker(t, p)
+	for i := 0; i < 3; i++ {
+		p.Add(func() { atomic.AddInt32(&executed, 1) })
+	}
+	go func() {
+		abandoned, err := p.Shutdown(context.Background(), false)
+		if err != nil {
+			t.Error(err)
+		}
+		result <- abandoned
+	}()
+	waitShuttingDown(t, p)
+	// The executing job is still waited.
+	select {
+	case <-result:
+		t.Fatal("expected shutdown waiting for the executing job")
+	case <-time.After(50 * time.Millisecond):
+	}
+	release()
+	if abandoned := <-result; abandoned != 3 {
+		t.Errorf("expected 3 abandoned jobs, got %d", abandoned)
+	}
+	if n := atomic.LoadInt32(&executed); n != 0 {
+		t.Errorf("expected no discarded job executed, got %d", n)
+	}
+	if n := p.Jobs(); n != 0 {
+		t.Errorf("expected no queued job, got %d", n)
+	}
+}
+
+func Test_Pool_Shutdown_Deadline(t *testing.T) {
+	var (
+		p        = New(1)
+		executed int32
+	)
+	release := blockWorker(t, p)
+	for i := 0; i < 2; i++ {
+		p.Add(func() { atomic.AddInt32(&executed, 1) })
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	abandoned, err := p.Shutdown(ctx, true)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	// The queued jobs and the executing job are abandoned.
+	if abandoned != 3 {
+		t.Errorf("expected 3 abandoned jobs, got %d", abandoned)
+	}
+	if !p.IsClosed() {
+		t.Error("expected pool closed after shutdown")
+	}
+	// The worker exits after the executing job without executing the queued jobs.
+	release()
+	deadline := time.Now().Add(time.Second)
+	for p.Size() > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := p.Size(); n != 0 {
+		t.Errorf("expected worker exited, got %d workers", n)
+	}
+	if n := atomic.LoadInt32(&executed); n != 0 {
+		t.Errorf("expected no queued job executed, got %d", n)
+	}
+}
+
+func Test_Pool_Shutdown_WakesBlockedAdd(t *testing.T) {
+	var (
+		p      = New(1)
+		result = make(chan error, 1)
+	)
+	release := blockWorker(t, p)
+	p.SetMaxJobs(1)
+	p.Add(func() {})
+	go func() {
+		result <- p.Add(func() {})
+	}()
+	select {
+	case err := <-result:
+		t.Fatalf("expected add blocked on the full queue, got %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	go p.Shutdown(context.Background(), false)
+	select {
+	case err := <-result:
+		if err == nil {
+			t.Error("expected error for the blocked add woken by shutdown")
+		}
+	case <-time.After(time.Second):
+		t.Error("expected blocked add woken by shutdown")
+	}
+	release()
+}
+
+func Test_Pool_Shutdown_Futures(t *testing.T) {
+	for _, drain := range []bool{true, false} {
+		var (
+			p       = New(1)
+			result  = make(chan int, 1)
+			futures = make([]*Future, 2)
+		)
+		release := blockWorker(t, p)
+		for i := range futures {
+			i := i
+			futures[i] = p.Submit(func() (interface{}, error) {
+				return i, nil
+			})
+		}
+		go func() {
+			abandoned, _ := p.Shutdown(context.Background(), drain)
+			result <- abandoned
+		}()
+		waitShuttingDown(t, p)
+		release()
+		<-result
+		for i, future := range futures {
+			value, err := future.WaitTimeout(time.Second)
+			if !drain {
+				if err != ErrPoolClosed {
+					t.Errorf("discard future %d: expected ErrPoolClosed, got %v, %v", i, value, err)
+				}
+				continue
+			}
+			if err != nil || value != i {
+				t.Errorf("drain future %d: expected %d, got %v, %v", i, i, value, err)
+			}
+		}
+	}
+}
+
+func Test_Pool_Shutdown_DeadlineFutures(t *testing.T) {
+	p := New(1)
+	release := blockWorker(t, p)
+	defer release()
+	future := p.Submit(func() (interface{}, error) {
+		return 1, nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := p.Shutdown(ctx, true); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	// The queued job abandoned by the deadline is done with ErrPoolClosed.
+	if _, err := future.WaitTimeout(time.Second); err != ErrPoolClosed {
+		t.Errorf("expected ErrPoolClosed, got %v", err)
+	}
+}
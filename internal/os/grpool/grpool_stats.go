@@ -0,0 +1,129 @@
+package grpool
+
+import (
+	"github.com/ilylx/gconv/container/gtype"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Stats is the statistics of the pool.
+type Stats struct {
+	Workers   int     // Current goroutine count.
+	Active    int     // Count of goroutines executing jobs.
+	Jobs      int     // Count of queued jobs.
+	Completed int64   // Total count of executed jobs, including the panicking ones.
+	Panics    int64   // Total count of panicking jobs.
+	Dropped   int64   // Total count of jobs dropped by OVERFLOW_DROP_OLDEST policy.
+	Wait      Latency // Latency of jobs waiting in the queue.
+	Exec      Latency // Latency of jobs execution.
+}
+
+// Latency is the latency percentiles of the recent jobs.
+type Latency struct {
+	P50 time.Duration // 50th percentile.
+	P90 time.Duration // 90th percentile.
+	P99 time.Duration // 99th percentile.
+	Max time.Duration // Max latency.
+}
+
+// poolStats is the internal counters of the pool.
+type poolStats struct {
+	active    *gtype.Int       // Count of goroutines executing jobs.
+	completed *gtype.Int64     // Total count of executed jobs.
+	panics    *gtype.Int64     // Total count of panicking jobs.
+	dropped   *gtype.Int64     // Total count of dropped jobs.
+	wait      *latencyRecorder // Recorder of jobs waiting latency.
+	exec      *latencyRecorder // Recorder of jobs execution latency.
+}
+
+// latencyRecorder records the latencies of the recent jobs in a ring buffer.
+type latencyRecorder struct {
+	mu      sync.Mutex      // Mutex for concurrent safety.
+	samples []time.Duration // Ring buffer of the latencies.
+	index   int             // Next writing index of <samples>.
+	full    bool            // Whether <samples> is full.
+}
+
+const (
+	// Count of the recent jobs whose latencies are used for the percentiles calculation.
+	latencySampleSize = 1024
+)
+
+// GetStats returns the statistics of the default goroutine pool.
+func GetStats() Stats {
+	return pool.Stats()
+}
+
+// Stats returns the statistics of the pool, which is used for monitoring the pool.
+// The latency percentiles are calculated using the recent jobs.
+func (p *Pool) Stats() Stats {
+	return Stats{
+		Workers:   p.count.Val(),
+		Active:    p.stats.active.Val(),
+		Jobs:      p.jobCount(),
+		Completed: p.stats.completed.Val(),
+		Panics:    p.stats.panics.Val(),
+		Dropped:   p.stats.dropped.Val(),
+		Wait:      p.stats.wait.Latency(),
+		Exec:      p.stats.exec.Latency(),
+	}
+}
+
+// newPoolStats creates and returns the internal counters of the pool.
+func newPoolStats() *poolStats {
+	return &poolStats{
+		active:    gtype.NewInt(),
+		completed: gtype.NewInt64(),
+		panics:    gtype.NewInt64(),
+		dropped:   gtype.NewInt64(),
+		wait:      newLatencyRecorder(),
+		exec:      newLatencyRecorder(),
+	}
+}
+
+// newLatencyRecorder creates and returns a latency recorder.
+func newLatencyRecorder() *latencyRecorder {
+	return &latencyRecorder{
+		samples: make([]time.Duration, latencySampleSize),
+	}
+}
+
+// Record records latency <d>.
+func (r *latencyRecorder) Record(d time.Duration) {
+	r.mu.Lock()
+	r.samples[r.index] = d
+	r.index++
+	if r.index == len(r.samples) {
+		r.index = 0
+		r.full = true
+	}
+	r.mu.Unlock()
+}
+
+// Latency calculates and returns the latency percentiles of the recorded latencies.
+func (r *latencyRecorder) Latency() Latency {
+	r.mu.Lock()
+	size := r.index
+	if r.full {
+		size = len(r.samples)
+	}
+	samples := make([]time.Duration, size)
+	copy(samples, r.samples[:size])
+	r.mu.Unlock()
+	if size == 0 {
+		return Latency{}
+	}
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i] < samples[j]
+	})
+	percentile := func(p int) time.Duration {
+		return samples[(size-1)*p/100]
+	}
+	return Latency{
+		P50: percentile(50),
+		P90: percentile(90),
+		P99: percentile(99),
+		Max: samples[size-1],
+	}
+}
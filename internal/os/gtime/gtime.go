@@ -4,8 +4,8 @@
 package gtime
 
 import (
+	"bytes"
 	"errors"
-	"fmt"
 	"github.com/ilylx/gconv/internal/gerror"
 	"github.com/ilylx/gconv/internal/gregex"
 	"github.com/ilylx/gconv/internal/utils"
@@ -95,7 +95,7 @@ var (
 // known installation locations on Unix systems,
 // and finally looks in $GOROOT/lib/time/zoneinfo.zip.
 func SetTimeZone(zone string) error {
-	location, err := time.LoadLocation(zone)
+	location, err := LoadLocation(zone)
 	if err == nil {
 		time.Local = location
 	}
@@ -331,19 +331,20 @@ func StrToTime(str string, format ...string) (*Time, error) {
 
 // ConvertZone converts time in string <strTime> from <fromZone> to <toZone>.
 // The parameter <fromZone> is unnecessary, it is current time zone in default.
+// It uses the default zone of SetDefaultZone if <toZone> is empty.
 func ConvertZone(strTime string, toZone string, fromZone ...string) (*Time, error) {
 	t, err := StrToTime(strTime)
 	if err != nil {
 		return nil, err
 	}
 	if len(fromZone) > 0 {
-		if l, err := time.LoadLocation(fromZone[0]); err != nil {
+		if l, err := LoadLocation(fromZone[0]); err != nil {
 			return nil, err
 		} else {
 			t.Time = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Time.Second(), t.Time.Nanosecond(), l)
 		}
 	}
-	if l, err := time.LoadLocation(toZone); err != nil {
+	if l, err := getZoneLocation(toZone); err != nil {
 		return nil, err
 	} else {
 		return t.ToLocation(l), nil
@@ -388,10 +389,11 @@ func ParseTimeFromContent(content string, format ...string) *Time {
 // ParseDuration parses a duration string.
 // A duration string is a possibly signed sequence of
 // decimal numbers, each with optional fraction and a unit suffix,
-// such as "300ms", "-1.5h", "1d" or "2h45m".
-// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h", "d".
+// such as "300ms", "-1.5h", "1d", "2w" or "1d12h30m".
+// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h", "d", "w".
 //
-// Very note that it supports unit "d" more than function time.ParseDuration.
+// Very note that it supports unit "d"(day) and "w"(week) more than function time.ParseDuration,
+// and the string of a pure number is parsed as nanoseconds.
 func ParseDuration(s string) (time.Duration, error) {
 	if utils.IsNumeric(s) {
 		v, err := strconv.ParseInt(s, 10, 64)
@@ -400,18 +402,59 @@ func ParseDuration(s string) (time.Duration, error) {
 		}
 		return time.Duration(v), nil
 	}
-	match, err := gregex.MatchString(`^([\-\d]+)[dD](.*)$`, s)
-	if err != nil {
-		return 0, err
+	if !strings.ContainsAny(s, "dDwW") {
+		return time.ParseDuration(s)
 	}
-	if len(match) == 3 {
-		v, err := strconv.ParseInt(match[1], 10, 64)
-		if err != nil {
-			return 0, err
+	var (
+		sign   = ""
+		buffer = bytes.NewBuffer(nil)
+		remain = s
+	)
+	if remain != "" && (remain[0] == '-' || remain[0] == '+') {
+		sign, remain = remain[:1], remain[1:]
+	}
+	// It converts the day and week units to hours for time.ParseDuration.
+	for len(remain) > 0 {
+		i := 0
+		for i < len(remain) && isDurationNumberChar(remain[i]) {
+			i++
+		}
+		j := i
+		for j < len(remain) && !isDurationNumberChar(remain[j]) {
+			j++
+		}
+		number, unit := remain[:i], remain[i:j]
+		remain = remain[j:]
+		if number == "" {
+			return 0, gerror.Newf(`invalid duration "%s"`, s)
+		}
+		switch unit {
+		case "d", "D", "w", "W":
+			v, err := strconv.ParseFloat(number, 64)
+			if err != nil {
+				return 0, gerror.Newf(`invalid duration "%s"`, s)
+			}
+			hours := float64(24)
+			if unit == "w" || unit == "W" {
+				hours = 24 * 7
+			}
+			buffer.WriteString(strconv.FormatFloat(v*hours, 'f', -1, 64))
+			buffer.WriteString("h")
+		default:
+			buffer.WriteString(number)
+			buffer.WriteString(unit)
 		}
-		return time.ParseDuration(fmt.Sprintf(`%dh%s`, v*24, match[2]))
 	}
-	return time.ParseDuration(s)
+	d, err := time.ParseDuration(sign + buffer.String())
+	if err != nil {
+		return 0, gerror.Newf(`invalid duration "%s"`, s)
+	}
+	return d, nil
+}
+
+// isDurationNumberChar checks whether <c> is a char of the number part of duration string.
+func isDurationNumberChar(c byte) bool {
+	return c == '.' || (c >= '0' && c <= '9')
 }
 
 // FuncCost calculates the cost time of function <f> in nanoseconds.
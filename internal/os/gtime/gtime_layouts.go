@@ -0,0 +1,67 @@
+package gtime
+
+import (
+	"github.com/ilylx/gconv/internal/gerror"
+	"strings"
+	"sync"
+)
+
+var (
+	// layoutsMu protects the global layout list.
+	layoutsMu = sync.RWMutex{}
+	// layouts is the global layout list, which is consulted by ParseLayouts if no layout
+	// is given, and by gconv.Time/GTime before parsing the string as standard datetime.
+	layouts []string
+)
+
+// SetLayouts sets the global layout list, which is in stdlib format like "2006-01-02 15:04:05".
+// The global layout list is consulted by ParseLayouts if no layout is given, and by
+// gconv.Time/GTime for converting string without given format, in which the layouts are
+// tried before the standard datetime parsing of StrToTime. It clears the list if no
+// layout given.
+//
+// Eg:
+//
+//	gtime.SetLayouts("01/02/2006", "02-Jan-2006 15:04")
+func SetLayouts(layout ...string) {
+	layoutsMu.Lock()
+	layouts = append([]string(nil), layout...)
+	layoutsMu.Unlock()
+}
+
+// AddLayouts appends <layout> to the global layout list.
+func AddLayouts(layout ...string) {
+	layoutsMu.Lock()
+	layouts = append(layouts, layout...)
+	layoutsMu.Unlock()
+}
+
+// GetLayouts returns a copy of the global layout list.
+func GetLayouts() []string {
+	layoutsMu.RLock()
+	defer layoutsMu.RUnlock()
+	return append([]string(nil), layouts...)
+}
+
+// ParseLayouts parses string <str> to *Time object by trying given <layout> in order, and
+// returns the result of the first matched layout. The layouts are in stdlib format like
+// "2006-01-02 15:04:05". It uses the global layout list if no <layout> is given.
+//
+// Eg:
+//
+//	t, err := gtime.ParseLayouts("06-Jul-2021", "2006/01/02", time.RFC3339, "02-Jan-2006")
+func ParseLayouts(str string, layout ...string) (*Time, error) {
+	if len(layout) == 0 {
+		layout = GetLayouts()
+	}
+	if len(layout) == 0 {
+		return nil, gerror.Newf(`no layout given for parsing time string "%s"`, str)
+	}
+	str = strings.TrimSpace(str)
+	for _, v := range layout {
+		if t, err := StrToTimeLayout(str, v); err == nil {
+			return t, nil
+		}
+	}
+	return nil, gerror.Newf(`time string "%s" does not match any of the layouts: %s`, str, strings.Join(layout, ", "))
+}
@@ -0,0 +1,142 @@
+package gtime
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ilylx/gconv/internal/gerror"
+	"strconv"
+)
+
+var (
+	// strftimeLayouts maps the strftime directives to stdlib layouts,
+	// which are supported in both formatting and parsing.
+	// Refer: https://man7.org/linux/man-pages/man3/strftime.3.html
+	strftimeLayouts = map[byte]string{
+		'a': "Mon",                      // Abbreviated weekday name. Eg: Sun.
+		'A': "Monday",                   // Full weekday name. Eg: Sunday.
+		'b': "Jan",                      // Abbreviated month name. Eg: Jan.
+		'B': "January",                  // Full month name. Eg: January.
+		'c': "Mon Jan _2 15:04:05 2006", // Date and time representation. Eg: Thu Aug 23 14:55:02 2001.
+		'd': "02",                       // Day of the month with leading zeros. Eg: 01 to 31.
+		'D': "01/02/06",                 // Equivalent to %m/%d/%y.
+		'e': "_2",                       // Day of the month with leading space. Eg: " 1" to "31".
+		'F': "2006-01-02",               // Equivalent to %Y-%m-%d.
+		'f': "000000",                   // Microseconds with leading zeros, usually after "%S.". Eg: 000000 to 999999.
+		'h': "Jan",                      // Equivalent to %b.
+		'H': "15",                       // Hour in 24-hour clock with leading zeros. Eg: 00 to 23.
+		'I': "03",                       // Hour in 12-hour clock with leading zeros. Eg: 01 to 12.
+		'L': "000",                      // Milliseconds with leading zeros, usually after "%S.". Eg: 000 to 999.
+		'm': "01",                       // Month with leading zeros. Eg: 01 to 12.
+		'M': "04",                       // Minute with leading zeros. Eg: 00 to 59.
+		'n': "\n",                       // Newline char.
+		'p': "PM",                       // AM or PM.
+		'r': "03:04:05 PM",              // Equivalent to %I:%M:%S %p.
+		'R': "15:04",                    // Equivalent to %H:%M.
+		'S': "05",                       // Second with leading zeros. Eg: 00 to 59.
+		't': "\t",                       // Tab char.
+		'T': "15:04:05",                 // Equivalent to %H:%M:%S.
+		'x': "01/02/06",                 // Date representation. Eg: 08/23/01.
+		'X': "15:04:05",                 // Time representation. Eg: 14:55:02.
+		'y': "06",                       // Year without century. Eg: 00 to 99.
+		'Y': "2006",                     // Year with century. Eg: 2001.
+		'z': "-0700",                    // Offset from UTC. Eg: +0800.
+		'Z': "MST",                      // Timezone abbreviation. Eg: CST.
+		'%': "%",                        // A literal '%' char.
+	}
+)
+
+// Strftime formats and returns the formatted result with strftime <format>,
+// like "%Y-%m-%d %H:%M:%S". Besides the directives of StrftimeToLayout, it also supports
+// the directives that are only available in formatting:
+// %C: century number (year/100) as a 2-digit integer;
+// %j: day of the year as a 3-digit decimal number, 001 to 366;
+// %s: seconds since the Unix Epoch;
+// %u: day of the week as a decimal, 1 to 7, Monday being 1;
+// %w: day of the week as a decimal, 0 to 6, Sunday being 0;
+// %V: ISO 8601 week number of the year, 01 to 53.
+// The unknown directives are kept as they are.
+func (t *Time) Strftime(format string) string {
+	if t == nil {
+		return ""
+	}
+	buffer := bytes.NewBuffer(nil)
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i == len(format)-1 {
+			buffer.WriteByte(format[i])
+			continue
+		}
+		i++
+		directive := format[i]
+		if layout, ok := strftimeLayouts[directive]; ok {
+			switch directive {
+			case '%', 'n', 't':
+				buffer.WriteString(layout)
+			case 'f', 'L':
+				// Fractional seconds can only be formatted after a dot in stdlib layout.
+				buffer.WriteString(t.Time.Format("." + layout)[1:])
+			default:
+				buffer.WriteString(t.Time.Format(layout))
+			}
+			continue
+		}
+		switch directive {
+		case 'C':
+			buffer.WriteString(fmt.Sprintf("%02d", t.Year()/100))
+		case 'j':
+			buffer.WriteString(fmt.Sprintf("%03d", t.Time.YearDay()))
+		case 's':
+			buffer.WriteString(strconv.FormatInt(t.Unix(), 10))
+		case 'u':
+			weekday := int(t.Weekday())
+			if weekday == 0 {
+				weekday = 7
+			}
+			buffer.WriteString(strconv.Itoa(weekday))
+		case 'w':
+			buffer.WriteString(strconv.Itoa(int(t.Weekday())))
+		case 'V':
+			_, week := t.ISOWeek()
+			buffer.WriteString(fmt.Sprintf("%02d", week))
+		default:
+			buffer.WriteByte('%')
+			buffer.WriteByte(directive)
+		}
+	}
+	return buffer.String()
+}
+
+// StrftimeToLayout converts strftime <format> like "%Y-%m-%d %H:%M:%S" to stdlib layout
+// like "2006-01-02 15:04:05". It returns error if <format> contains directive that is
+// not supported in parsing, like "%j" or "%s".
+//
+// Note that the literal chars of <format> are kept in the layout, so they should not be
+// the reserved words of stdlib layout, like "Jan" or "2006".
+func StrftimeToLayout(format string) (string, error) {
+	buffer := bytes.NewBuffer(nil)
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			buffer.WriteByte(format[i])
+			continue
+		}
+		if i == len(format)-1 {
+			return "", gerror.Newf(`incomplete strftime directive at the end of format "%s"`, format)
+		}
+		i++
+		layout, ok := strftimeLayouts[format[i]]
+		if !ok {
+			return "", gerror.Newf(`unsupported strftime directive "%%%c" in format "%s"`, format[i], format)
+		}
+		buffer.WriteString(layout)
+	}
+	return buffer.String(), nil
+}
+
+// StrToTimeStrftime parses string <str> to *Time object with given strftime <format>.
+// The parameter <format> is like "%Y-%m-%d %H:%M:%S".
+func StrToTimeStrftime(str string, format string) (*Time, error) {
+	layout, err := StrftimeToLayout(format)
+	if err != nil {
+		return nil, err
+	}
+	return StrToTimeLayout(str, layout)
+}
@@ -221,8 +221,10 @@ func (t *Time) ToLocation(location *time.Location) *Time {
 }
 
 // ToZone converts current time to specified zone like: Asia/Shanghai.
+// It uses the default zone of SetDefaultZone if <zone> is empty.
+// The loaded locations are cached, see LoadLocation.
 func (t *Time) ToZone(zone string) (*Time, error) {
-	if l, err := time.LoadLocation(zone); err == nil {
+	if l, err := getZoneLocation(zone); err == nil {
 		return t.ToLocation(l), nil
 	} else {
 		return nil, err
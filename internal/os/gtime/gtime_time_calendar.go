@@ -0,0 +1,118 @@
+package gtime
+
+import (
+	"fmt"
+	"time"
+)
+
+// Quarter returns the quarter of the year of the time, which is 1 to 4.
+func (t *Time) Quarter() int {
+	return (int(t.Month())-1)/3 + 1
+}
+
+// ISOWeekStr returns the ISO 8601 week of the time in form of "2006-W01",
+// in which the year is the ISO year that may differ from the calendar year
+// in the first and last week of the year.
+func (t *Time) ISOWeekStr() string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%04d-W%02d", year, week)
+}
+
+// StartOfISOWeek returns the start time of the ISO 8601 <week> of ISO <year> in local timezone,
+// which is 00:00:00 of the Monday of the week.
+func StartOfISOWeek(year, week int) *Time {
+	// The 4th of January is always in the first ISO week.
+	t := NewFromTime(time.Date(year, time.January, 4, 0, 0, 0, 0, time.Local)).StartOfWeek()
+	return t.AddDate(0, 0, (week-1)*7)
+}
+
+// StartOfMinute returns the start time of the minute of the time.
+func (t *Time) StartOfMinute() *Time {
+	return t.newDate(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute())
+}
+
+// StartOfHour returns the start time of the hour of the time.
+func (t *Time) StartOfHour() *Time {
+	return t.newDate(t.Year(), t.Month(), t.Day(), t.Hour(), 0)
+}
+
+// StartOfDay returns the start time of the day of the time, which is 00:00:00 of the day.
+func (t *Time) StartOfDay() *Time {
+	return t.newDate(t.Year(), t.Month(), t.Day(), 0, 0)
+}
+
+// StartOfWeek returns the start time of the week of the time.
+// The optional parameter <weekStart> specifies the first day of week, which is Monday in default
+// as ISO 8601 specifies.
+func (t *Time) StartOfWeek(weekStart ...time.Weekday) *Time {
+	start := time.Monday
+	if len(weekStart) > 0 {
+		start = weekStart[0]
+	}
+	days := (int(t.Weekday()) - int(start) + 7) % 7
+	return t.newDate(t.Year(), t.Month(), t.Day()-days, 0, 0)
+}
+
+// StartOfMonth returns the start time of the month of the time.
+func (t *Time) StartOfMonth() *Time {
+	return t.newDate(t.Year(), t.Month(), 1, 0, 0)
+}
+
+// StartOfQuarter returns the start time of the quarter of the time.
+func (t *Time) StartOfQuarter() *Time {
+	return t.newDate(t.Year(), time.Month((t.Quarter()-1)*3+1), 1, 0, 0)
+}
+
+// StartOfYear returns the start time of the year of the time.
+func (t *Time) StartOfYear() *Time {
+	return t.newDate(t.Year(), time.January, 1, 0, 0)
+}
+
+// EndOfMinute returns the end time of the minute of the time.
+func (t *Time) EndOfMinute() *Time {
+	return t.newEndDate(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1)
+}
+
+// EndOfHour returns the end time of the hour of the time.
+func (t *Time) EndOfHour() *Time {
+	return t.newEndDate(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0)
+}
+
+// EndOfDay returns the end time of the day of the time, which is 23:59:59.999999999 of the day.
+func (t *Time) EndOfDay() *Time {
+	return t.newEndDate(t.Year(), t.Month(), t.Day()+1, 0, 0)
+}
+
+// EndOfWeek returns the end time of the week of the time.
+// The optional parameter <weekStart> specifies the first day of week, which is Monday in default.
+func (t *Time) EndOfWeek(weekStart ...time.Weekday) *Time {
+	start := t.StartOfWeek(weekStart...)
+	return start.newEndDate(start.Year(), start.Month(), start.Day()+7, 0, 0)
+}
+
+// EndOfMonth returns the end time of the month of the time.
+func (t *Time) EndOfMonth() *Time {
+	return t.newEndDate(t.Year(), t.Month()+1, 1, 0, 0)
+}
+
+// EndOfQuarter returns the end time of the quarter of the time.
+func (t *Time) EndOfQuarter() *Time {
+	return t.newEndDate(t.Year(), time.Month(t.Quarter()*3+1), 1, 0, 0)
+}
+
+// EndOfYear returns the end time of the year of the time.
+func (t *Time) EndOfYear() *Time {
+	return t.newEndDate(t.Year()+1, time.January, 1, 0, 0)
+}
+
+// newDate creates and returns a new Time object in the same location of <t>.
+// It uses time.Date instead of Truncate, which is aware of the timezone and daylight saving time.
+func (t *Time) newDate(year int, month time.Month, day, hour, min int) *Time {
+	return NewFromTime(time.Date(year, month, day, hour, min, 0, 0, t.Location()))
+}
+
+// newEndDate returns the time 1 nanosecond before the given date in the same location of <t>,
+// which is the end time of the previous period.
+func (t *Time) newEndDate(year int, month time.Month, day, hour, min int) *Time {
+	return NewFromTime(time.Date(year, month, day, hour, min, 0, 0, t.Location()).Add(-time.Nanosecond))
+}
@@ -0,0 +1,105 @@
+package gtime
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const (
+	// zoneCacheCap is the max count of the cached locations.
+	zoneCacheCap = 128
+)
+
+// zoneCacheItem is the item of the location cache list.
+type zoneCacheItem struct {
+	name     string
+	location *time.Location
+}
+
+var (
+	// zoneMu protects the location cache and the default zone.
+	zoneMu = sync.Mutex{}
+	// zoneMap and zoneList compose the LRU cache of the loaded locations,
+	// which prevents time.LoadLocation reading the time zone database from disk repeatedly.
+	zoneMap  = make(map[string]*list.Element)
+	zoneList = list.New()
+	// defaultZone is the location used for conversion if no zone name is given.
+	defaultZone *time.Location
+)
+
+// LoadLocation returns the *time.Location with given zone <name> like: Asia/Shanghai.
+// It acts as time.LoadLocation, but caches the loaded locations using LRU,
+// so it is suitable for the hot paths like per-request timezone conversion.
+func LoadLocation(name string) (*time.Location, error) {
+	zoneMu.Lock()
+	if element, ok := zoneMap[name]; ok {
+		zoneList.MoveToFront(element)
+		zoneMu.Unlock()
+		return element.Value.(*zoneCacheItem).location, nil
+	}
+	zoneMu.Unlock()
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	zoneMu.Lock()
+	defer zoneMu.Unlock()
+	if _, ok := zoneMap[name]; !ok {
+		zoneMap[name] = zoneList.PushFront(&zoneCacheItem{
+			name:     name,
+			location: location,
+		})
+		for zoneList.Len() > zoneCacheCap {
+			element := zoneList.Back()
+			zoneList.Remove(element)
+			delete(zoneMap, element.Value.(*zoneCacheItem).name)
+		}
+	}
+	return location, nil
+}
+
+// SetDefaultZone sets the default zone like: Asia/Shanghai, which is used by ToZone and
+// the method Time.ToZone if the given zone name is empty. It clears the default zone if
+// <zone> is empty.
+//
+// Different from SetTimeZone, it does not change the local time zone of the process.
+func SetDefaultZone(zone string) error {
+	var location *time.Location
+	if zone != "" {
+		l, err := LoadLocation(zone)
+		if err != nil {
+			return err
+		}
+		location = l
+	}
+	zoneMu.Lock()
+	defaultZone = location
+	zoneMu.Unlock()
+	return nil
+}
+
+// GetDefaultZone returns the default zone set by SetDefaultZone,
+// which is nil if no default zone is set.
+func GetDefaultZone() *time.Location {
+	zoneMu.Lock()
+	defer zoneMu.Unlock()
+	return defaultZone
+}
+
+// ToZone converts stdlib time <t> to specified <zone> like: Asia/Shanghai.
+// It uses the default zone of SetDefaultZone if <zone> is empty.
+func ToZone(t time.Time, zone string) (*Time, error) {
+	return NewFromTime(t).ToZone(zone)
+}
+
+// getZoneLocation returns the location of <zone> using cache,
+// or the default zone if <zone> is empty and the default zone is set.
+func getZoneLocation(zone string) (*time.Location, error) {
+	if zone == "" {
+		if location := GetDefaultZone(); location != nil {
+			return location, nil
+		}
+	}
+	return LoadLocation(zone)
+}
@@ -21,26 +21,39 @@ import (
 )
 
 const (
-	StatusReady           = 0             // Job is ready for running.
-	StatusRunning         = 1             // Job is already running.
-	StatusStopped         = 2             // Job is stopped.
-	StatusReset           = 3             // Job is reset.
-	StatusClosed          = -1            // Job is closed and waiting to be deleted.
-	gPanicExit            = "exit"        // Internal usage for custom job exit function with panic.
-	gDefaultTimes         = math.MaxInt32 // Default limit running times, a big number.
-	gDefaultSlotNumber    = 10            // Default slot number.
-	gDefaultWheelInterval = 50            // Default wheel interval.
-	gDefaultWheelLevel    = 6             // Default wheel level.
-	gCmdenvKey            = "gf.gtimer"   // Configuration key for command argument or environment.
+	StatusReady           = 0                     // Job is ready for running.
+	StatusRunning         = 1                     // Job is already running.
+	StatusStopped         = 2                     // Job is stopped.
+	StatusReset           = 3                     // Job is reset.
+	StatusClosed          = -1                    // Job is closed and waiting to be deleted.
+	gPanicExit            = "exit"                // Internal usage for custom job exit function with panic.
+	gDefaultTimes         = math.MaxInt32         // Default limit running times, a big number.
+	gDefaultSlotNumber    = 10                    // Default slot number.
+	gDefaultWheelInterval = 50                    // Default wheel interval.
+	gDefaultWheelLevel    = 6                     // Default wheel level.
+	gDefaultWorkerNumber  = 0                     // Default worker number of the pool running jobs, no limit if it is not greater than 0.
+	gCmdenvKey            = "gf.gtimer"           // Configuration key for command argument or environment.
+	gWaitCheckInterval    = 10 * time.Millisecond // Interval checking running jobs for graceful closing.
 )
 
 var (
 	defaultSlots    = cmdenv.Get(fmt.Sprintf("%s.slots", gCmdenvKey), gDefaultSlotNumber).Int()
 	defaultLevel    = cmdenv.Get(fmt.Sprintf("%s.level", gCmdenvKey), gDefaultWheelLevel).Int()
 	defaultInterval = cmdenv.Get(fmt.Sprintf("%s.interval", gCmdenvKey), gDefaultWheelInterval).Duration() * time.Millisecond
+	defaultWorkers  = cmdenv.Get(fmt.Sprintf("%s.workers", gCmdenvKey), gDefaultWorkerNumber).Int()
 	defaultTimer    = New(defaultSlots, defaultInterval, defaultLevel)
 )
 
+// monotonicBase is the base time for monotonic clock readings of the package.
+var monotonicBase = time.Now()
+
+// monotonicMs returns the elapsed milliseconds since the package initialized.
+// It uses the monotonic clock reading of time.Time, so the tick accounting is not affected
+// by wall clock changes like NTP steps or manual time setting.
+func monotonicMs() int64 {
+	return int64(time.Since(monotonicBase) / time.Millisecond)
+}
+
 // SetTimeout runs the job once after duration of <delay>.
 // It is like the one in javascript.
 func SetTimeout(delay time.Duration, job JobFunc) {
@@ -88,12 +101,53 @@ func AddTimes(interval time.Duration, times int, job JobFunc) *Entry {
 	return defaultTimer.AddTimes(interval, times, job)
 }
 
+// AddNamed adds a timing job named <name> to the default timer, which runs in interval of <interval>.
+// It returns an error if the <name> is already used.
+func AddNamed(name string, interval time.Duration, job JobFunc) (*Entry, error) {
+	return defaultTimer.AddNamed(name, interval, job)
+}
+
+// AddBatch adds multiple timing jobs to the default timer in one pass.
+// It returns an error and adds nothing if any name of the <specs> is already used.
+func AddBatch(specs []EntrySpec) ([]*Entry, error) {
+	return defaultTimer.AddBatch(specs)
+}
+
+// Get returns the timing job entry named <name> of the default timer.
+// It returns nil if no found.
+func Get(name string) *Entry {
+	return defaultTimer.Get(name)
+}
+
+// Remove closes and removes the timing job entry named <name> from the default timer.
+func Remove(name string) *Entry {
+	return defaultTimer.Remove(name)
+}
+
+// Entries returns all the named timing job entries of the default timer, ordered by name.
+func Entries() []*Entry {
+	return defaultTimer.Entries()
+}
+
+// AddAt adds a timing job to the default timer, which runs only once at the absolute time <at>.
+// The job runs as soon as possible if <at> is already in the past.
+func AddAt(at time.Time, job JobFunc) *Entry {
+	return defaultTimer.AddAt(at, job)
+}
+
 // DelayAdd adds a timing job after delay of <interval> duration.
 // Also see Add.
 func DelayAdd(delay time.Duration, interval time.Duration, job JobFunc) {
 	defaultTimer.DelayAdd(delay, interval, job)
 }
 
+// DelayAddAt adds a timing job to the default timer at the absolute time <at>,
+// which runs in interval of <interval>.
+// Also see Add.
+func DelayAddAt(at time.Time, interval time.Duration, job JobFunc) {
+	defaultTimer.DelayAddAt(at, interval, job)
+}
+
 // DelayAddEntry adds a timing job after delay of <interval> duration.
 // Also see AddEntry.
 func DelayAddEntry(delay time.Duration, interval time.Duration, job JobFunc, singleton bool, times int, status int) {
@@ -118,6 +172,18 @@ func DelayAddTimes(delay time.Duration, interval time.Duration, times int, job J
 	defaultTimer.DelayAddTimes(delay, interval, times, job)
 }
 
+// Start starts the default timer.
+// All entries of the default timer continue running in their intervals.
+func Start() {
+	defaultTimer.Start()
+}
+
+// Stop stops the default timer, which freezes all the entries of the default timer
+// until Start is called. Note that it does not interrupt the jobs that are currently running.
+func Stop() {
+	defaultTimer.Stop()
+}
+
 // Exit is used in timing job internally, which exits and marks it closed from timer.
 // The timing job will be automatically removed from timer later. It uses "panic-recover"
 // mechanism internally implementing this feature, which is designed for simplification
@@ -125,3 +191,14 @@ func DelayAddTimes(delay time.Duration, interval time.Duration, times int, job J
 func Exit() {
 	panic(gPanicExit)
 }
+
+// GetStats returns the statistics of the default timer.
+func GetStats() Stats {
+	return defaultTimer.Stats()
+}
+
+// AddRetry adds a job to the default timer, which runs immediately, and reschedules itself
+// using backoff <strategy> until it returns nil or it has been run <maxAttempts> times.
+func AddRetry(job RetryFunc, maxAttempts int, strategy RetryStrategy) *RetryEntry {
+	return defaultTimer.AddRetry(job, maxAttempts, strategy)
+}
@@ -1,6 +1,7 @@
 package gtimer
 
 import (
+	"github.com/ilylx/gconv/container/glist"
 	"github.com/ilylx/gconv/container/gtype"
 	"time"
 )
@@ -14,9 +15,11 @@ type Entry struct {
 	times         *gtype.Int  // Limit running times.
 	create        int64       // Timer ticks when the job installed.
 	interval      int64       // The interval ticks of the job.
-	createMs      int64       // The timestamp in milliseconds when job installed.
+	createMs      int64       // The monotonic milliseconds when job installed, see monotonicMs.
 	intervalMs    int64       // The interval milliseconds of the job.
 	rawIntervalMs int64       // Raw input interval in milliseconds.
+	name          string      // Entry name, which is not empty for named entries.
+	internal      bool        // Whether it's an internal entry for wheels rolling.
 }
 
 // JobFunc is the job function.
@@ -24,6 +27,15 @@ type JobFunc = func()
 
 // addEntry adds a timing job to the wheel.
 func (w *wheel) addEntry(interval time.Duration, job JobFunc, singleton bool, times int, status int) *Entry {
+	entry, slot := w.newEntry(interval, job, singleton, times, status)
+	// Install the job to the list of the slot.
+	slot.PushBack(entry)
+	return entry
+}
+
+// newEntry creates and returns a timing job of the wheel, along with the slot list
+// which the job should be installed to.
+func (w *wheel) newEntry(interval time.Duration, job JobFunc, singleton bool, times int, status int) (*Entry, *glist.List) {
 	if times <= 0 {
 		times = gDefaultTimes
 	}
@@ -36,7 +48,7 @@ func (w *wheel) addEntry(interval time.Duration, job JobFunc, singleton bool, ti
 		// then sets it to one tick, which means it will be run in one interval.
 		num = 1
 	}
-	nowMs := time.Now().UnixNano() / 1e6
+	nowMs := monotonicMs()
 	ticks := w.ticks.Val()
 	entry := &Entry{
 		wheel:         w,
@@ -50,9 +62,7 @@ func (w *wheel) addEntry(interval time.Duration, job JobFunc, singleton bool, ti
 		intervalMs:    ms,
 		rawIntervalMs: ms,
 	}
-	// Install the job to the list of the slot.
-	w.slots[(ticks+num)%w.number].PushBack(entry)
-	return entry
+	return entry, w.slots[(ticks+num)%w.number]
 }
 
 // addEntryByParent adds a timing job with parent entry.
@@ -61,7 +71,7 @@ func (w *wheel) addEntryByParent(interval int64, parent *Entry) *Entry {
 	if num == 0 {
 		num = 1
 	}
-	nowMs := time.Now().UnixNano() / 1e6
+	nowMs := monotonicMs()
 	ticks := w.ticks.Val()
 	entry := &Entry{
 		wheel:         w,
@@ -74,11 +84,18 @@ func (w *wheel) addEntryByParent(interval int64, parent *Entry) *Entry {
 		createMs:      nowMs,
 		intervalMs:    interval,
 		rawIntervalMs: parent.rawIntervalMs,
+		name:          parent.name,
+		internal:      parent.internal,
 	}
 	w.slots[(ticks+num)%w.number].PushBack(entry)
 	return entry
 }
 
+// Name returns the name of the job, which is empty if it is not a named job.
+func (entry *Entry) Name() string {
+	return entry.name
+}
+
 // Status returns the status of the job.
 func (entry *Entry) Status() int {
 	return entry.status.Val()
@@ -90,18 +107,46 @@ func (entry *Entry) SetStatus(status int) int {
 }
 
 // Start starts the job.
+// It does nothing if the job is already closed, or it's ready or running.
 func (entry *Entry) Start() {
-	entry.status.Set(StatusReady)
+	for {
+		switch status := entry.status.Val(); status {
+		case StatusClosed, StatusReady, StatusRunning:
+			return
+		default:
+			if entry.status.Cas(status, StatusReady) {
+				return
+			}
+		}
+	}
 }
 
 // Stop stops the job.
+// The job keeps its position in the timer and can be started again using Start.
+// Note that it does not interrupt the job that is currently running.
+// It does nothing if the job is already closed.
 func (entry *Entry) Stop() {
-	entry.status.Set(StatusStopped)
+	entry.setStatusIfNotClosed(StatusStopped)
 }
 
 // Reset reset the job.
+// It does nothing if the job is already closed.
 func (entry *Entry) Reset() {
-	entry.status.Set(StatusReset)
+	entry.setStatusIfNotClosed(StatusReset)
+}
+
+// setStatusIfNotClosed atomically sets the status of the job to <status>
+// if the job is not closed. It returns whether the status is set.
+func (entry *Entry) setStatusIfNotClosed(status int) bool {
+	for {
+		old := entry.status.Val()
+		if old == StatusClosed {
+			return false
+		}
+		if entry.status.Cas(old, status) {
+			return true
+		}
+	}
 }
 
 // Close closes the job, and then it will be removed from the timer.
@@ -135,6 +180,7 @@ func (entry *Entry) check(nowTicks int64, nowMs int64) (runnable, addable bool)
 	case StatusStopped:
 		return false, true
 	case StatusClosed:
+		entry.wheel.timer.removeNamedEntry(entry)
 		return false, false
 	case StatusReset:
 		return false, true
@@ -165,6 +211,7 @@ func (entry *Entry) check(nowTicks int64, nowMs int64) (runnable, addable bool)
 		if entry.IsSingleton() {
 			// Note that it is atomic operation to ensure concurrent safety.
 			if entry.status.Set(StatusRunning) == StatusRunning {
+				entry.wheel.timer.stats.overrun.Add(1)
 				return false, true
 			}
 		}
@@ -1,7 +1,6 @@
 package gtimer
 
 import (
-	"github.com/ilylx/gconv/container/glist"
 	"time"
 )
 
@@ -28,52 +27,74 @@ func (w *wheel) start() {
 }
 
 // proceed checks and rolls on the job.
-// If a timing job is time for running, it runs in an asynchronous goroutine,
+// If a timing job is time for running, it runs asynchronously in the worker pool of the timer,
 // or else it removes from current slot and re-installs the job to another wheel and slot
 // according to its leftover interval in milliseconds.
+//
+// Note that the slot list is processed inline in the ticking goroutine,
+// and the internal entries rolling the upper wheels are also run inline,
+// to avoid creating goroutines for each tick.
 func (w *wheel) proceed() {
-	n := w.ticks.Add(1)
-	l := w.slots[int(n%w.number)]
-	length := l.Len()
-	if length > 0 {
-		go func(l *glist.List, nowTicks int64) {
-			entry := (*Entry)(nil)
-			nowMs := time.Now().UnixNano() / 1e6
-			for i := length; i > 0; i-- {
-				if v := l.PopFront(); v == nil {
-					break
+	var (
+		n      = w.ticks.Add(1)
+		l      = w.slots[int(n%w.number)]
+		length = l.Len()
+	)
+	if length == 0 {
+		return
+	}
+	var (
+		entry = (*Entry)(nil)
+		nowMs = monotonicMs()
+	)
+	for i := length; i > 0; i-- {
+		if v := l.PopFront(); v == nil {
+			break
+		} else {
+			entry = v.(*Entry)
+		}
+		// Checks whether the time for running.
+		runnable, addable := entry.check(n, nowMs)
+		if runnable {
+			if entry.internal {
+				entry.job()
+			} else {
+				w.timer.stats.record(nowMs - entry.createMs - entry.intervalMs)
+				w.timer.dispatch(entry)
+			}
+		}
+		// If rolls on the job.
+		if addable {
+			//If STATUS_RESET , reset to runnable state.
+			if entry.Status() == StatusReset {
+				entry.SetStatus(StatusReady)
+			}
+			entry.wheel.timer.doAddEntryByParent(entry.rawIntervalMs, entry)
+		}
+	}
+}
+
+// dispatch runs the job of <entry> asynchronously using the worker pool of the timer.
+func (t *Timer) dispatch(entry *Entry) {
+	t.running.Add(1)
+	err := t.workers.Add(func() {
+		defer func() {
+			t.running.Add(-1)
+			if err := recover(); err != nil {
+				if err != gPanicExit {
+					panic(err)
 				} else {
-					entry = v.(*Entry)
-				}
-				// Checks whether the time for running.
-				runnable, addable := entry.check(nowTicks, nowMs)
-				if runnable {
-					// Just run it in another goroutine.
-					go func(entry *Entry) {
-						defer func() {
-							if err := recover(); err != nil {
-								if err != gPanicExit {
-									panic(err)
-								} else {
-									entry.Close()
-								}
-							}
-							if entry.Status() == StatusRunning {
-								entry.SetStatus(StatusReady)
-							}
-						}()
-						entry.job()
-					}(entry)
-				}
-				// If rolls on the job.
-				if addable {
-					//If STATUS_RESET , reset to runnable state.
-					if entry.Status() == StatusReset {
-						entry.SetStatus(StatusReady)
-					}
-					entry.wheel.timer.doAddEntryByParent(entry.rawIntervalMs, entry)
+					entry.Close()
 				}
 			}
-		}(l, n)
+			if entry.Status() == StatusRunning {
+				entry.SetStatus(StatusReady)
+			}
+		}()
+		entry.job()
+	})
+	if err != nil {
+		// The worker pool is closed along with the timer.
+		t.running.Add(-1)
 	}
 }
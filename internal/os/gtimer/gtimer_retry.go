@@ -0,0 +1,142 @@
+package gtimer
+
+import (
+	"github.com/ilylx/gconv/container/gtype"
+	"github.com/ilylx/gconv/internal/grand"
+	"math"
+	"sync"
+	"time"
+)
+
+// RetryFunc is the job function for retrying job, which returns nil if it succeeds.
+type RetryFunc = func() error
+
+// RetryStrategy is the backoff strategy for retrying job.
+type RetryStrategy interface {
+	// Delay returns the delay before the <attempt>th retry, which starts from 1.
+	Delay(attempt int) time.Duration
+}
+
+// ExponentialBackoff is the exponential backoff strategy with cap and jitter.
+// The delay before the nth retry is: Initial * Multiplier^(n-1), capped by Max,
+// then randomly adjusted within range of +/- Jitter ratio.
+type ExponentialBackoff struct {
+	Initial    time.Duration // Delay before the first retry.
+	Max        time.Duration // Max delay before any retry, no cap if it is 0.
+	Multiplier float64       // Multiplier of the delay for each retry, which is 2 if it is not greater than 1.
+	Jitter     float64       // Jitter ratio in range of [0, 1] of the delay, 0 means no jitter.
+}
+
+// RetryEntry is the entry for retrying job, which reschedules the job with backoff
+// until it succeeds or its attempts exceed the limit.
+type RetryEntry struct {
+	mu          sync.Mutex    // Mutex for concurrent safety of <entry> and <err>.
+	timer       *Timer        // Belonged timer.
+	job         RetryFunc     // The job function.
+	strategy    RetryStrategy // The backoff strategy.
+	maxAttempts int           // Max attempts, no limit if it is not greater than 0.
+	attempts    *gtype.Int    // Attempted times.
+	closed      *gtype.Bool   // Whether the retrying is finished or closed.
+	entry       *Entry        // Current scheduled entry of the job.
+	err         error         // The error of the last attempt.
+}
+
+// Delay implements the RetryStrategy interface.
+func (b ExponentialBackoff) Delay(attempt int) time.Duration {
+	multiplier := b.Multiplier
+	if multiplier <= 1 {
+		multiplier = 2
+	}
+	if attempt < 1 {
+		attempt = 1
+	}
+	delay := float64(b.Initial) * math.Pow(multiplier, float64(attempt-1))
+	if b.Max > 0 && delay > float64(b.Max) {
+		delay = float64(b.Max)
+	}
+	if delay > math.MaxInt64 {
+		delay = math.MaxInt64
+	}
+	if b.Jitter > 0 {
+		if n := int(delay * math.Min(b.Jitter, 1)); n > 0 {
+			delay += float64(grand.N(-n, n))
+		}
+	}
+	return time.Duration(delay)
+}
+
+// AddRetry adds a job which runs immediately, and reschedules itself using backoff
+// <strategy> until it returns nil or it has been run <maxAttempts> times.
+// There's no attempts limit if <maxAttempts> is not greater than 0.
+func (t *Timer) AddRetry(job RetryFunc, maxAttempts int, strategy RetryStrategy) *RetryEntry {
+	entry := &RetryEntry{
+		timer:       t,
+		job:         job,
+		strategy:    strategy,
+		maxAttempts: maxAttempts,
+		attempts:    gtype.NewInt(),
+		closed:      gtype.NewBool(),
+	}
+	entry.schedule(0)
+	return entry
+}
+
+// schedule schedules the next attempt after <delay>.
+func (entry *RetryEntry) schedule(delay time.Duration) {
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	entry.entry = entry.timer.AddOnce(delay, entry.run)
+	// Double check as it might be closed during scheduling.
+	if entry.closed.Val() {
+		entry.entry.Close()
+	}
+}
+
+// run runs the job once and schedules the next attempt if it fails.
+func (entry *RetryEntry) run() {
+	if entry.closed.Val() {
+		return
+	}
+	attempts := entry.attempts.Add(1)
+	err := entry.job()
+	entry.mu.Lock()
+	entry.err = err
+	entry.mu.Unlock()
+	if err == nil {
+		entry.closed.Set(true)
+		return
+	}
+	if entry.maxAttempts > 0 && attempts >= entry.maxAttempts {
+		entry.closed.Set(true)
+		return
+	}
+	entry.schedule(entry.strategy.Delay(attempts))
+}
+
+// Attempts returns the attempted times of the job.
+func (entry *RetryEntry) Attempts() int {
+	return entry.attempts.Val()
+}
+
+// LastError returns the error of the last attempt, which is nil if the job succeeds.
+func (entry *RetryEntry) LastError() error {
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	return entry.err
+}
+
+// IsClosed checks and returns whether the retrying is finished or closed.
+func (entry *RetryEntry) IsClosed() bool {
+	return entry.closed.Val()
+}
+
+// Close stops retrying the job.
+// Note that it does not interrupt the attempt that is currently running.
+func (entry *RetryEntry) Close() {
+	entry.closed.Set(true)
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.entry != nil {
+		entry.entry.Close()
+	}
+}
@@ -0,0 +1,65 @@
+package gtimer
+
+import (
+	"github.com/ilylx/gconv/container/gtype"
+	"time"
+)
+
+// Stats is the statistics of the timer, which is commonly used for monitoring.
+type Stats struct {
+	Pending    []int         // Pending entries count of each wheel level, from the lowest level.
+	Executed   int64         // Total count of the executed jobs.
+	Overrun    int64         // Total count of the skipped runs as the singleton job was still running.
+	AvgLatency time.Duration // Average latency between the scheduled and actual running time.
+}
+
+// timerStats holds the statistics counters of the timer.
+type timerStats struct {
+	executed  *gtype.Int64 // Executed jobs count.
+	overrun   *gtype.Int64 // Skipped singleton runs count.
+	latencyMs *gtype.Int64 // Total latency in milliseconds of the executed jobs.
+}
+
+// newTimerStats creates and returns the statistics counters.
+func newTimerStats() *timerStats {
+	return &timerStats{
+		executed:  gtype.NewInt64(),
+		overrun:   gtype.NewInt64(),
+		latencyMs: gtype.NewInt64(),
+	}
+}
+
+// record records one job execution with its latency in milliseconds.
+func (s *timerStats) record(latencyMs int64) {
+	s.executed.Add(1)
+	if latencyMs > 0 {
+		s.latencyMs.Add(latencyMs)
+	}
+}
+
+// Stats returns the statistics of the timer.
+//
+// Note that the pending entries count is a snapshot, which excludes the internal entries
+// for wheels rolling, and the entries being checked by the wheels at the moment.
+func (t *Timer) Stats() Stats {
+	stats := Stats{
+		Pending:  make([]int, t.length),
+		Executed: t.stats.executed.Val(),
+		Overrun:  t.stats.overrun.Val(),
+	}
+	for i, w := range t.wheels {
+		count := 0
+		for _, l := range w.slots {
+			count += l.Len()
+		}
+		// Each wheel except the top level one contains an internal entry rolling its upper wheel.
+		if i < t.length-1 && count > 0 {
+			count--
+		}
+		stats.Pending[i] = count
+	}
+	if stats.Executed > 0 {
+		stats.AvgLatency = time.Duration(t.stats.latencyMs.Val()/stats.Executed) * time.Millisecond
+	}
+	return stats
+}
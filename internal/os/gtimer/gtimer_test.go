@@ -0,0 +1,283 @@
+package gtimer
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const benchmarkEntryNumber = 100000
+
+func Benchmark_Add_100k(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		timer := New(gDefaultSlotNumber, time.Hour, 1)
+		for j := 0; j < benchmarkEntryNumber; j++ {
+			timer.Add(time.Hour, func() {})
+		}
+		timer.Close()
+	}
+}
+
+func Benchmark_Proceed_100k(b *testing.B) {
+	// The ticker never ticks during benchmark, the wheel proceeds manually.
+	timer := New(1, time.Hour, 1)
+	defer timer.Close()
+	for j := 0; j < benchmarkEntryNumber; j++ {
+		timer.Add(time.Hour, func() {})
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		timer.wheels[0].proceed()
+	}
+}
+
+func Benchmark_AddBatch_100k(b *testing.B) {
+	specs := make([]EntrySpec, benchmarkEntryNumber)
+	for j := 0; j < benchmarkEntryNumber; j++ {
+		specs[j] = EntrySpec{Interval: time.Hour, Job: func() {}}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		timer := New(gDefaultSlotNumber, time.Hour, 1)
+		if _, err := timer.AddBatch(specs); err != nil {
+			b.Fatal(err)
+		}
+		timer.Close()
+	}
+}
+
+func Test_Timer_JobPanic(t *testing.T) {
+	// The panicking job crashes the process, which is checked in a sub process.
+	if os.Getenv("GTIMER_TEST_JOB_PANIC") == "1" {
+		timer := New(10, 10*time.Millisecond)
+		timer.AddOnce(10*time.Millisecond, func() {
+			panic("job panics")
+		})
+		time.Sleep(time.Second)
+		return
+	}
+	cmd := exec.Command(os.Args[0], "-test.run=^Test_Timer_JobPanic$")
+	cmd.Env = append(os.Environ(), "GTIMER_TEST_JOB_PANIC=1")
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("process did not crash for the panicking job, output: %s", output)
+	}
+	if !strings.Contains(string(output), "job panics") {
+		t.Fatalf("panic is not reported, output: %s", output)
+	}
+}
+
+func Test_Timer_Dispatch(t *testing.T) {
+	var (
+		timer = New(10, 10*time.Millisecond)
+		count int32
+		n     = 1000
+	)
+	defer timer.Close()
+	for i := 0; i < n; i++ {
+		timer.AddOnce(20*time.Millisecond, func() {
+			atomic.AddInt32(&count, 1)
+		})
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for atomic.LoadInt32(&count) < int32(n) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if c := atomic.LoadInt32(&count); c != int32(n) {
+		t.Errorf("expected %d jobs run once, got %d", n, c)
+	}
+}
+
+func Test_Timer_Dispatch_Blocking(t *testing.T) {
+	// The blocking job does not delay the others in default.
+	timer := New(10, 10*time.Millisecond)
+	defer timer.Close()
+	var (
+		block = make(chan struct{})
+		count int32
+	)
+	defer close(block)
+	timer.AddOnce(10*time.Millisecond, func() { <-block })
+	timer.AddOnce(20*time.Millisecond, func() { atomic.AddInt32(&count, 1) })
+	deadline := time.Now().Add(2 * time.Second)
+	for atomic.LoadInt32(&count) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if atomic.LoadInt32(&count) != 1 {
+		t.Error("expected job not to be delayed by the blocking job")
+	}
+}
+
+func Test_Timer_Dispatch_Workers(t *testing.T) {
+	var (
+		workers = 4
+		timer   = NewWithWorkers(10, 10*time.Millisecond, workers)
+		count   int32
+		n       = 100
+	)
+	defer timer.Close()
+	for i := 0; i < n; i++ {
+		timer.AddOnce(20*time.Millisecond, func() {
+			atomic.AddInt32(&count, 1)
+			time.Sleep(time.Millisecond)
+		})
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(&count) < int32(n) && time.Now().Before(deadline) {
+		if size := timer.workers.Size(); size > workers {
+			t.Fatalf("expected at most %d workers, got %d", workers, size)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if c := atomic.LoadInt32(&count); c != int32(n) {
+		t.Errorf("expected %d jobs run once, got %d", n, c)
+	}
+}
+
+func Test_Timer_Dispatch_Singleton(t *testing.T) {
+	var (
+		timer   = New(10, 10*time.Millisecond)
+		running int32
+		overlap int32
+		count   int32
+	)
+	defer timer.Close()
+	timer.AddSingleton(10*time.Millisecond, func() {
+		if atomic.AddInt32(&running, 1) > 1 {
+			atomic.StoreInt32(&overlap, 1)
+		}
+		atomic.AddInt32(&count, 1)
+		time.Sleep(30 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+	})
+	time.Sleep(200 * time.Millisecond)
+	if atomic.LoadInt32(&overlap) != 0 {
+		t.Error("expected singleton job not running concurrently")
+	}
+	if atomic.LoadInt32(&count) < 2 {
+		t.Errorf("expected singleton job running repeatedly, got %d", count)
+	}
+}
+
+func Test_Timer_Dispatch_Exit(t *testing.T) {
+	var (
+		timer = New(10, 10*time.Millisecond)
+		count int32
+	)
+	defer timer.Close()
+	entry := timer.Add(10*time.Millisecond, func() {
+		atomic.AddInt32(&count, 1)
+		Exit()
+	})
+	time.Sleep(100 * time.Millisecond)
+	if c := atomic.LoadInt32(&count); c != 1 {
+		t.Errorf("expected job exited after running once, got %d", c)
+	}
+	if status := entry.Status(); status != StatusClosed {
+		t.Errorf("expected entry closed, got status %d", status)
+	}
+}
+
+func Test_Timer_Close_WaitRunningJobs(t *testing.T) {
+	var (
+		timer   = New(10, 10*time.Millisecond)
+		started = make(chan struct{})
+		done    int32
+	)
+	timer.AddOnce(10*time.Millisecond, func() {
+		close(started)
+		time.Sleep(50 * time.Millisecond)
+		atomic.StoreInt32(&done, 1)
+	})
+	<-started
+	if err := timer.CloseContext(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadInt32(&done) != 1 {
+		t.Error("expected close waiting for the running job")
+	}
+}
+
+func Test_Timer_CloseContext_Timeout(t *testing.T) {
+	var (
+		timer   = New(10, 10*time.Millisecond)
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+	defer close(release)
+	timer.AddOnce(10*time.Millisecond, func() {
+		close(started)
+		<-release
+	})
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := timer.CloseContext(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	// The timer is closed even if the waiting times out.
+	if timer.status.Val() != StatusClosed {
+		t.Error("expected timer closed")
+	}
+}
+
+func Test_Timer_AddBatch(t *testing.T) {
+	var (
+		timer  = New(10, 10*time.Millisecond)
+		counts = make([]int32, 3)
+	)
+	defer timer.Close()
+	specs := []EntrySpec{
+		{Name: "batch-a", Interval: 10 * time.Millisecond, Job: func() { atomic.AddInt32(&counts[0], 1) }, Times: 2},
+		{Interval: 20 * time.Millisecond, Job: func() { atomic.AddInt32(&counts[1], 1) }, Times: 1},
+		{Name: "batch-c", Interval: 10 * time.Millisecond, Job: func() { atomic.AddInt32(&counts[2], 1) }, Status: StatusStopped},
+	}
+	entries, err := timer.AddBatch(specs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(specs) {
+		t.Fatalf("expected %d entries, got %d", len(specs), len(entries))
+	}
+	// The entries are returned in the order of specs, and the named ones are registered.
+	for i, spec := range specs {
+		if name := entries[i].Name(); name != spec.Name {
+			t.Errorf("entry %d: expected name %q, got %q", i, spec.Name, name)
+		}
+	}
+	if timer.Get("batch-a") != entries[0] || timer.Get("batch-c") != entries[2] {
+		t.Error("expected named entries registered")
+	}
+	time.Sleep(200 * time.Millisecond)
+	for i, expect := range []int32{2, 1, 0} {
+		if c := atomic.LoadInt32(&counts[i]); c != expect {
+			t.Errorf("entry %d: expected running %d times, got %d", i, expect, c)
+		}
+	}
+}
+
+func Test_Timer_AddBatch_DuplicatedName(t *testing.T) {
+	timer := New(10, 10*time.Millisecond)
+	defer timer.Close()
+	if _, err := timer.AddNamed("exists", time.Hour, func() {}); err != nil {
+		t.Fatal(err)
+	}
+	tests := [][]EntrySpec{
+		{{Name: "new", Interval: time.Hour, Job: func() {}}, {Name: "exists", Interval: time.Hour, Job: func() {}}},
+		{{Name: "dup", Interval: time.Hour, Job: func() {}}, {Name: "dup", Interval: time.Hour, Job: func() {}}},
+	}
+	for i, specs := range tests {
+		if _, err := timer.AddBatch(specs); err == nil {
+			t.Errorf("test %d: expected error for duplicated name", i)
+		}
+	}
+	// Nothing is added if any name is duplicated.
+	if entries := timer.Entries(); len(entries) != 1 || entries[0].Name() != "exists" {
+		t.Errorf("expected only the existing entry, got %d entries", len(entries))
+	}
+}
@@ -1,19 +1,38 @@
 package gtimer
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/ilylx/gconv/container/glist"
+	"github.com/ilylx/gconv/container/gmap"
 	"github.com/ilylx/gconv/container/gtype"
+	"github.com/ilylx/gconv/internal/os/grpool"
+	"sort"
 	"time"
 )
 
 // Timer is a Hierarchical Timing Wheel manager for timing jobs.
 type Timer struct {
-	status     *gtype.Int // Timer status.
-	wheels     []*wheel   // The underlying wheels.
-	length     int        // Max level of the wheels.
-	number     int        // Slot Number of each wheel.
-	intervalMs int64      // Interval of the slot in milliseconds.
+	status     *gtype.Int      // Timer status.
+	wheels     []*wheel        // The underlying wheels.
+	length     int             // Max level of the wheels.
+	number     int             // Slot Number of each wheel.
+	intervalMs int64           // Interval of the slot in milliseconds.
+	entries    *gmap.StrAnyMap // Named entries registry.
+	stats      *timerStats     // Statistics of the timer.
+	running    *gtype.Int      // Count of the jobs which are currently running.
+	workers    *grpool.Pool    // Worker pool for running jobs asynchronously.
+}
+
+// EntrySpec is the specification of a timing job, which is used for batch adding.
+type EntrySpec struct {
+	Name      string        // Unique name of the job, which is optional. See AddNamed.
+	Interval  time.Duration // Running interval of the job.
+	Job       JobFunc       // The job function.
+	Singleton bool          // Whether the job running in singleton mode.
+	Times     int           // Limit running times of the job, no limit if it is not greater than 0.
+	Status    int           // Status of the job when it is added, which is StatusReady in default.
 }
 
 // Wheel is a slot wrapper for timing job install and uninstall.
@@ -24,7 +43,7 @@ type wheel struct {
 	number     int64         // Slot Number=len(slots).
 	ticks      *gtype.Int64  // Ticked count of the wheel, one tick is one of its interval passed.
 	totalMs    int64         // Total duration in milliseconds=number*interval.
-	createMs   int64         // Created timestamp in milliseconds.
+	createMs   int64         // Created monotonic milliseconds, see monotonicMs.
 	intervalMs int64         // Interval in milliseconds, which is the duration of one slot.
 }
 
@@ -32,7 +51,19 @@ type wheel struct {
 // The parameter <interval> specifies the interval of the timer.
 // The optional parameter <level> specifies the wheels count of the timer,
 // which is gDEFAULT_WHEEL_LEVEL in default.
+//
+// The jobs are run by a worker pool without limit in default, so a blocking job never delays
+// the others, like running each job in its own goroutine. The limit can be set by the command
+// argument or environment "gf.gtimer.workers", or per timer using NewWithWorkers.
 func New(slot int, interval time.Duration, level ...int) *Timer {
+	return NewWithWorkers(slot, interval, defaultWorkers, level...)
+}
+
+// NewWithWorkers creates and returns a timer like New, of which the jobs are run by a worker
+// pool with at most <workers> goroutines, no limit if it is not greater than 0.
+// Note that a limited pool queues the jobs if all its workers are busy, so the blocking jobs
+// delay the other jobs of the timer.
+func NewWithWorkers(slot int, interval time.Duration, workers int, level ...int) *Timer {
 	if slot <= 0 {
 		panic(fmt.Sprintf("invalid slot number: %d", slot))
 	}
@@ -46,7 +77,14 @@ func New(slot int, interval time.Duration, level ...int) *Timer {
 		length:     length,
 		number:     slot,
 		intervalMs: interval.Nanoseconds() / 1e6,
+		entries:    gmap.NewStrAnyMap(true),
+		stats:      newTimerStats(),
+		running:    gtype.NewInt(),
+		workers:    grpool.New(workers),
 	}
+	// The panics of the jobs crash the process like running in standalone goroutines,
+	// instead of being recovered by the worker pool.
+	t.workers.SetPanicHandler(panicJob)
 	for i := 0; i < length; i++ {
 		if i > 0 {
 			n := time.Duration(t.wheels[i-1].totalMs) * time.Millisecond
@@ -55,7 +93,7 @@ func New(slot int, interval time.Duration, level ...int) *Timer {
 			}
 			w := t.newWheel(i, slot, n)
 			t.wheels[i] = w
-			t.wheels[i-1].addEntry(n, w.proceed, false, gDefaultTimes, StatusReady)
+			t.wheels[i-1].addEntry(n, w.proceed, false, gDefaultTimes, StatusReady).internal = true
 		} else {
 			t.wheels[i] = t.newWheel(i, slot, interval)
 		}
@@ -64,6 +102,12 @@ func New(slot int, interval time.Duration, level ...int) *Timer {
 	return t
 }
 
+// panicJob is the panic handler of the worker pool, which panics again with the recovered value
+// and the stack of the job, as the timer never swallows the panics of the jobs.
+func panicJob(recovered interface{}, stack string) {
+	panic(fmt.Sprintf("%v\n\njob stack:\n%s", recovered, stack))
+}
+
 // newWheel creates and returns a single wheel.
 func (t *Timer) newWheel(level int, slot int, interval time.Duration) *wheel {
 	w := &wheel{
@@ -73,7 +117,7 @@ func (t *Timer) newWheel(level int, slot int, interval time.Duration) *wheel {
 		number:     int64(slot),
 		ticks:      gtype.NewInt64(),
 		totalMs:    int64(slot) * interval.Nanoseconds() / 1e6,
-		createMs:   time.Now().UnixNano() / 1e6,
+		createMs:   monotonicMs(),
 		intervalMs: interval.Nanoseconds() / 1e6,
 	}
 	for i := int64(0); i < w.number; i++ {
@@ -117,6 +161,12 @@ func (t *Timer) AddTimes(interval time.Duration, times int, job JobFunc) *Entry
 	return t.doAddEntry(interval, job, true, times, StatusReady)
 }
 
+// AddAt adds a timing job which runs only once at the absolute time <at>.
+// The job runs as soon as possible if <at> is already in the past.
+func (t *Timer) AddAt(at time.Time, job JobFunc) *Entry {
+	return t.AddOnce(untilTime(at), job)
+}
+
 // DelayAdd adds a timing job after delay of <interval> duration.
 // Also see Add.
 func (t *Timer) DelayAdd(delay time.Duration, interval time.Duration, job JobFunc) {
@@ -157,19 +207,195 @@ func (t *Timer) DelayAddTimes(delay time.Duration, interval time.Duration, times
 	})
 }
 
+// AddNamed adds a timing job named <name> to the timer, which runs in interval of <interval>.
+// The named job can be retrieved using Get and removed using Remove at runtime.
+// It returns an error if the <name> is already used.
+func (t *Timer) AddNamed(name string, interval time.Duration, job JobFunc) (*Entry, error) {
+	var entry *Entry
+	t.entries.LockFunc(func(m map[string]interface{}) {
+		if _, ok := m[name]; ok {
+			return
+		}
+		entry = t.doAddEntry(interval, job, false, gDefaultTimes, StatusStopped)
+		entry.name = name
+		m[name] = entry
+	})
+	if entry == nil {
+		return nil, errors.New(fmt.Sprintf(`timer entry "%s" already exists`, name))
+	}
+	// It starts running after the entry is registered,
+	// to avoid the entry from closing during registering.
+	entry.Start()
+	return entry, nil
+}
+
+// AddBatch adds multiple timing jobs to the timer in one pass, which is much faster than
+// adding them one by one for large amount of jobs, as the slot of the wheels is locked only once
+// for all the jobs installed into it.
+//
+// It returns the entries in the same order as <specs>. It returns an error and adds nothing
+// if any name of the <specs> is already used.
+func (t *Timer) AddBatch(specs []EntrySpec) ([]*Entry, error) {
+	var (
+		err     error
+		entries = make([]*Entry, len(specs))
+	)
+	t.entries.LockFunc(func(m map[string]interface{}) {
+		names := make(map[string]struct{})
+		for _, spec := range specs {
+			if spec.Name == "" {
+				continue
+			}
+			if _, ok := m[spec.Name]; ok {
+				err = errors.New(fmt.Sprintf(`timer entry "%s" already exists`, spec.Name))
+				return
+			}
+			if _, ok := names[spec.Name]; ok {
+				err = errors.New(fmt.Sprintf(`duplicated timer entry name "%s"`, spec.Name))
+				return
+			}
+			names[spec.Name] = struct{}{}
+		}
+		batches := make(map[*glist.List][]interface{})
+		for i, spec := range specs {
+			var (
+				w           = t.wheels[t.getLevelByIntervalMs(spec.Interval.Nanoseconds()/1e6)]
+				entry, slot = w.newEntry(spec.Interval, spec.Job, spec.Singleton, spec.Times, spec.Status)
+			)
+			if spec.Name != "" {
+				entry.name = spec.Name
+				m[spec.Name] = entry
+			}
+			entries[i] = entry
+			batches[slot] = append(batches[slot], entry)
+		}
+		for slot, batch := range batches {
+			slot.PushBacks(batch)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Get returns the timing job entry named <name>.
+// It returns nil if no found.
+func (t *Timer) Get(name string) *Entry {
+	if v := t.entries.Get(name); v != nil {
+		return v.(*Entry)
+	}
+	return nil
+}
+
+// Remove closes and removes the timing job entry named <name>.
+// It returns the removed entry, or nil if no found.
+func (t *Timer) Remove(name string) *Entry {
+	if v := t.entries.Remove(name); v != nil {
+		entry := v.(*Entry)
+		entry.Close()
+		return entry
+	}
+	return nil
+}
+
+// Entries returns all the named timing job entries of the timer, ordered by name.
+func (t *Timer) Entries() []*Entry {
+	entries := make([]*Entry, 0, t.entries.Size())
+	t.entries.Iterator(func(k string, v interface{}) bool {
+		entries = append(entries, v.(*Entry))
+		return true
+	})
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].name < entries[j].name
+	})
+	return entries
+}
+
+// removeNamedEntry removes the closed <entry> from the named entries registry.
+func (t *Timer) removeNamedEntry(entry *Entry) {
+	if entry.name == "" {
+		return
+	}
+	t.entries.LockFunc(func(m map[string]interface{}) {
+		// The name might be reused by another entry after it's removed.
+		if v, ok := m[entry.name]; ok && v.(*Entry).status == entry.status {
+			delete(m, entry.name)
+		}
+	})
+}
+
+// DelayAddAt adds a timing job at the absolute time <at>, which runs in interval of <interval>.
+// The job is added as soon as possible if <at> is already in the past.
+// Also see Add.
+func (t *Timer) DelayAddAt(at time.Time, interval time.Duration, job JobFunc) {
+	t.DelayAdd(untilTime(at), interval, job)
+}
+
+// untilTime returns the duration until <at>, which is 0 if <at> is in the past.
+func untilTime(at time.Time) time.Duration {
+	if d := time.Until(at); d > 0 {
+		return d
+	}
+	return 0
+}
+
 // Start starts the timer.
+// It does nothing if the timer is closed.
 func (t *Timer) Start() {
-	t.status.Set(StatusRunning)
+	t.status.Cas(StatusStopped, StatusRunning)
 }
 
-// Stop stops the timer.
+// Stop stops the timer, which freezes all the entries of the timer until Start is called.
+// It does nothing if the timer is closed.
 func (t *Timer) Stop() {
-	t.status.Set(StatusStopped)
+	t.status.Cas(StatusRunning, StatusStopped)
 }
 
-// Close closes the timer.
+// Close closes the timer, which stops the timer from ticking and releases all its entries.
+// It does not wait for the currently running jobs, see CloseContext for graceful shutdown.
 func (t *Timer) Close() {
+	t.doClose()
+}
+
+// CloseContext closes the timer like Close, but it waits for the currently running jobs
+// to finish before releasing entries, and the waiting is bounded by <ctx>.
+// It returns the error of <ctx> if the waiting is cancelled or timeout.
+func (t *Timer) CloseContext(ctx context.Context) error {
 	t.status.Set(StatusClosed)
+	err := t.waitRunningJobs(ctx)
+	t.doClose()
+	return err
+}
+
+// doClose stops the timer from ticking and releases the worker pool and all the entries.
+func (t *Timer) doClose() {
+	t.status.Set(StatusClosed)
+	t.workers.Close()
+	for _, w := range t.wheels {
+		for _, l := range w.slots {
+			l.RemoveAll()
+		}
+	}
+}
+
+// waitRunningJobs waits for all the running jobs to finish, bounded by <ctx>.
+func (t *Timer) waitRunningJobs(ctx context.Context) error {
+	if t.running.Val() <= 0 {
+		return nil
+	}
+	ticker := time.NewTicker(gWaitCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if t.running.Val() <= 0 {
+				return nil
+			}
+		}
+	}
 }
 
 // doAddEntry adds a timing job to timer for internal usage.
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"strings"
+	"unicode"
 )
 
 // IsLetterUpper checks whether the given byte b is in upper case.
@@ -86,3 +87,57 @@ func RemoveSymbols(s string) string {
 func EqualFoldWithoutChars(s1, s2 string) bool {
 	return strings.EqualFold(RemoveSymbols(s1), RemoveSymbols(s2))
 }
+
+// SplitCaseWords splits <s> into words for case converting. It is unicode-aware, and:
+// 1. Any char which is neither letter nor digit is treated as separator;
+// 2. A new word starts at an upper case letter after a lower case letter, eg: "fooBar" -> "foo", "Bar";
+// 3. The acronyms are treated as whole words, eg: "JSONData" -> "JSON", "Data";
+// 4. The digit sequences are treated as whole words, eg: "md5Sum" -> "md", "5", "Sum".
+func SplitCaseWords(s string) []string {
+	var (
+		words = make([]string, 0)
+		runes = []rune(s)
+		start = -1
+	)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start >= 0 && isCaseWordBoundary(runes, i) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// isCaseWordBoundary checks whether a new word starts at index <i> of <runes>,
+// in which the previous rune is a letter or digit.
+func isCaseWordBoundary(runes []rune, i int) bool {
+	var (
+		prev    = runes[i-1]
+		current = runes[i]
+	)
+	if unicode.IsDigit(prev) != unicode.IsDigit(current) {
+		return true
+	}
+	if !unicode.IsUpper(current) {
+		return false
+	}
+	if !unicode.IsUpper(prev) {
+		return unicode.IsLetter(prev)
+	}
+	// The last upper case letter of an acronym followed by lower case letters
+	// starts a new word, eg: the "D" in "JSONData".
+	return i+1 < len(runes) && unicode.IsLower(runes[i+1])
+}